@@ -0,0 +1,43 @@
+package clone
+
+import (
+	"context"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var clone_lxcCmd = &cobra.Command{
+	Use:   "lxc SRCID NEWID",
+	Short: "Clones the specified LXC container to the new ID",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		source := proxmox.NewVmRef(cli.ValidateExistingGuestID(args, 0))
+		newID := cli.ValidateExistingGuestID(args, 1)
+		opts := proxmox.ConfigLxcClone{NewID: newID}
+		opts.Full, _ = cmd.Flags().GetBool("full")
+		opts.Target, _ = cmd.Flags().GetString("target")
+		opts.Storage, _ = cmd.Flags().GetString("storage")
+		opts.Hostname, _ = cmd.Flags().GetString("name")
+		opts.Pool, _ = cmd.Flags().GetString("pool")
+		opts.SnapName, _ = cmd.Flags().GetString("snapname")
+		wait, _ := cmd.Flags().GetBool("wait")
+		c := cli.NewClient()
+		var upid string
+		if wait {
+			_, upid, err = c.CloneLxc(context.Background(), source, opts)
+		} else {
+			_, upid, err = c.StartCloneLxc(context.Background(), source, opts)
+		}
+		if err != nil {
+			return
+		}
+		return printClone(cloneCmd.OutOrStdout(), source.VmId(), newID, upid, wait)
+	},
+}
+
+func init() {
+	cloneCmd.AddCommand(clone_lxcCmd)
+	addCloneFlags(clone_lxcCmd)
+}
@@ -0,0 +1,43 @@
+package clone
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/spf13/cobra"
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone",
+	Short: "With this command you can clone existing guests within proxmox",
+}
+
+func init() {
+	cli.RootCmd.AddCommand(cloneCmd)
+}
+
+// Flags shared by the clone commands, read with cmd.Flags() as the values would persist between runs otherwise.
+func addCloneFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("full", false, "Create a full clone instead of a linked clone, required unless the source is a template")
+	cmd.Flags().String("target", "", "Node to create the clone on")
+	cmd.Flags().String("storage", "", "Storage for the disks of a full clone")
+	cmd.Flags().String("name", "", "Name of the clone")
+	cmd.Flags().String("pool", "", "Pool to add the clone to")
+	cmd.Flags().String("snapname", "", "Snapshot of the source to clone from")
+	cmd.Flags().Bool("wait", false, "Wait until the clone task has completed")
+}
+
+func printClone(out io.Writer, sourceID, newID int, upid string, wait bool) error {
+	return cli.PrintOutput(out, cli.Output{
+		Result: map[string]interface{}{"newid": newID, "upid": upid},
+		Text: func(out io.Writer) {
+			if wait {
+				fmt.Fprintf(out, "Guest with id (%d) has been cloned to (%d)\n", sourceID, newID)
+			} else {
+				fmt.Fprintf(out, "Cloning guest with id (%d) to (%d) has been started\n", sourceID, newID)
+			}
+			fmt.Fprintf(out, "NEWID: %d\nUPID: %s\n", newID, upid)
+		},
+	})
+}
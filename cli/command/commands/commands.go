@@ -1,6 +1,7 @@
 package commands
 
 import (
+	_ "github.com/perimeter-81/proxmox-api-go/cli/command/clone"
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/content"
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/content/iso"
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/content/template"
@@ -15,7 +16,11 @@ import (
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/list"
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/member"
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/member/group"
+	_ "github.com/perimeter-81/proxmox-api-go/cli/command/migrate"
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/node"
+	_ "github.com/perimeter-81/proxmox-api-go/cli/command/power"
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/set"
+	_ "github.com/perimeter-81/proxmox-api-go/cli/command/snapshot"
 	_ "github.com/perimeter-81/proxmox-api-go/cli/command/update"
+	_ "github.com/perimeter-81/proxmox-api-go/cli/command/wait"
 )
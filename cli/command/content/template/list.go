@@ -2,6 +2,7 @@ package template
 
 import (
 	"context"
+	"io"
 
 	"github.com/perimeter-81/proxmox-api-go/cli"
 	"github.com/perimeter-81/proxmox-api-go/proxmox"
@@ -17,8 +18,11 @@ var template_listCmd = &cobra.Command{
 		if err != nil {
 			return
 		}
-		cli.PrintRawJson(templateCmd.OutOrStdout(), format(templates))
-		return
+		list := format(templates)
+		return cli.PrintOutput(templateCmd.OutOrStdout(), cli.Output{
+			Result: list,
+			Text:   func(out io.Writer) { cli.PrintRawJson(out, list) },
+		})
 	},
 }
 
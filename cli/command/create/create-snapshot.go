@@ -25,7 +25,7 @@ var (
 				VmState:     memory,
 			}
 			memory = false
-			err = config.CreateSnapshot(context.Background(), cli.NewClient(), uint(id))
+			_, err = cli.NewClient().CreateSnapshot(context.Background(), proxmox.NewVmRef(id), config)
 			if err != nil {
 				return
 			}
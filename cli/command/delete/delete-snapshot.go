@@ -16,7 +16,7 @@ var (
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			id := cli.ValidateIntIDset(args, "GuestID")
 			snapName := cli.RequiredIDset(args, 1, "SnapshotName")
-			_, err = proxmox.DeleteSnapshot(context.Background(), cli.NewClient(), proxmox.NewVmRef(id), snapName)
+			_, err = cli.NewClient().DeleteSnapshot(context.Background(), proxmox.NewVmRef(id), snapName)
 			if err != nil {
 				return
 			}
@@ -31,8 +31,7 @@ var get_guestCmd = &cobra.Command{
 		if err != nil {
 			return
 		}
-		cli.PrintFormattedJson(GetCmd.OutOrStdout(), config)
-		return
+		return cli.PrintOutput(GetCmd.OutOrStdout(), cli.Output{Result: config})
 	},
 }
 
@@ -43,6 +43,5 @@ func getConfig(args []string, IDtype string) (err error) {
 	if err != nil {
 		return
 	}
-	cli.PrintFormattedJson(GetCmd.OutOrStdout(), config)
-	return
+	return cli.PrintOutput(GetCmd.OutOrStdout(), cli.Output{Result: config})
 }
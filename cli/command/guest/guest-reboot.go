@@ -0,0 +1,34 @@
+package guest
+
+import (
+	"context"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var guest_rebootCmd = &cobra.Command{
+	Use:   "reboot GUESTID [TIMEOUT]",
+	Short: "Shuts the specified guest down and starts it again",
+	Long: `Shuts the specified guest down and starts it again.
+The guest is stopped when it did not shut down within TIMEOUT seconds.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		vmr := proxmox.NewVmRef(cli.ValidateIntIDset(args, "GuestID"))
+		var timeout uint
+		if len(args) > 1 {
+			timeout = uint(cli.ValidateIntIDset(args[1:], "Timeout"))
+		}
+		c := cli.NewClient()
+		_, err = c.RebootVm(context.Background(), vmr, timeout)
+		if err == nil {
+			cli.PrintGuestStatus(GuestCmd.OutOrStdout(), vmr.VmId(), "rebooted")
+		}
+		return
+	},
+}
+
+func init() {
+	GuestCmd.AddCommand(guest_rebootCmd)
+}
@@ -3,6 +3,7 @@ package guest
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/perimeter-81/proxmox-api-go/cli"
 	"github.com/perimeter-81/proxmox-api-go/proxmox"
@@ -17,10 +18,15 @@ var guest_uptimeCmd = &cobra.Command{
 		vmr := proxmox.NewVmRef(cli.ValidateIntIDset(args, "GuestID"))
 		c := cli.NewClient()
 		vmState, err := c.GetVmState(context.Background(), vmr)
-		if err == nil {
-			fmt.Fprintf(GuestCmd.OutOrStdout(), "Uptime of guest with id (%d) is %d\n", vmr.VmId(), int(vmState["uptime"].(float64)))
+		if err != nil {
+			return
 		}
-		return
+		return cli.PrintOutput(GuestCmd.OutOrStdout(), cli.Output{
+			Result: map[string]interface{}{"vmid": vmr.VmId(), "uptime": vmState["uptime"]},
+			Text: func(out io.Writer) {
+				fmt.Fprintf(out, "Uptime of guest with id (%d) is %d\n", vmr.VmId(), int(vmState["uptime"].(float64)))
+			},
+		})
 	},
 }
 
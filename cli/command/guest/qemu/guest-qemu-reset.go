@@ -15,7 +15,7 @@ var qemu_resetCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		vmr := proxmox.NewVmRef(cli.ValidateIntIDset(args, "GuestID"))
 		c := cli.NewClient()
-		_, err = c.StartVm(context.Background(), vmr)
+		_, err = c.ResetVm(context.Background(), vmr)
 		if err == nil {
 			cli.PrintGuestStatus(qemuCmd.OutOrStdout(), vmr.VmId(), "reset")
 		}
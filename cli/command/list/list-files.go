@@ -2,6 +2,7 @@ package list
 
 import (
 	"context"
+	"io"
 
 	"github.com/perimeter-81/proxmox-api-go/cli"
 	"github.com/perimeter-81/proxmox-api-go/proxmox"
@@ -18,8 +19,10 @@ var list_FileCmd = &cobra.Command{
 		if err != nil {
 			return
 		}
-		cli.PrintRawJson(listCmd.OutOrStdout(), templates)
-		return
+		return cli.PrintOutput(listCmd.OutOrStdout(), cli.Output{
+			Result: templates,
+			Text:   func(out io.Writer) { cli.PrintRawJson(out, templates) },
+		})
 	},
 }
 
@@ -2,6 +2,7 @@ package list
 
 import (
 	"context"
+	"io"
 
 	"github.com/perimeter-81/proxmox-api-go/cli"
 	"github.com/perimeter-81/proxmox-api-go/proxmox"
@@ -30,12 +31,16 @@ var (
 			} else {
 				list = proxmox.FormatSnapshotsTree(jBody)
 			}
-			if len(list) == 0 {
-				listCmd.Printf("Guest with ID (%d) has no snapshots", id)
-			} else {
-				cli.PrintFormattedJson(listCmd.OutOrStdout(), list)
-			}
-			return
+			return cli.PrintOutput(listCmd.OutOrStdout(), cli.Output{
+				Result: list,
+				Text: func(out io.Writer) {
+					if len(list) == 0 {
+						listCmd.Printf("Guest with ID (%d) has no snapshots", id)
+					} else {
+						cli.PrintFormattedJson(out, list)
+					}
+				},
+			})
 		},
 	}
 )
@@ -0,0 +1,108 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var list_tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Prints a table of the tasks, newest first",
+	Long: `Prints a table of the tasks, newest first.
+Without --node the tasks of all nodes are listed.
+--since takes a duration relative to now (e.g. 2h) or a time in RFC3339 format.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		node, _ := cmd.Flags().GetString("node")
+		filter := proxmox.TaskFilter{}
+		filter.Type, _ = cmd.Flags().GetString("type")
+		filter.ErrorsOnly, _ = cmd.Flags().GetBool("errors-only")
+		filter.Limit, _ = cmd.Flags().GetUint("limit")
+		since, _ := cmd.Flags().GetString("since")
+		if since != "" {
+			if filter.Since, err = parseSince(since, time.Now()); err != nil {
+				return
+			}
+		}
+		c := cli.NewClient()
+		ctx := context.Background()
+		nodes := []string{node}
+		if node == "" {
+			if nodes, err = listNodeNames(ctx, c); err != nil {
+				return
+			}
+		}
+		tasks := make([]proxmox.Task, 0)
+		for _, e := range nodes {
+			nodeTasks, err := c.ListTasks(ctx, e, filter)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, nodeTasks...)
+		}
+		sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].StartTime.After(tasks[j].StartTime) })
+		if filter.Limit != 0 && uint(len(tasks)) > filter.Limit {
+			tasks = tasks[:filter.Limit]
+		}
+		headers := []string{"STARTTIME", "NODE", "TYPE", "ID", "USER", "STATUS", "UPID"}
+		rows := taskRows(tasks)
+		return cli.PrintOutput(listCmd.OutOrStdout(), cli.Output{
+			Result:  tasks,
+			Text:    func(out io.Writer) { cli.PrintTable(out, headers, rows) },
+			Headers: headers,
+			Rows:    rows,
+		})
+	},
+}
+
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(since); err == nil {
+		return now.Add(-duration), nil
+	}
+	parsed, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error: since must be a duration or a time in RFC3339 format")
+	}
+	return parsed, nil
+}
+
+func listNodeNames(ctx context.Context, c *proxmox.Client) ([]string, error) {
+	list, err := c.GetNodeList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]string, 0)
+	for _, e := range list["data"].([]interface{}) {
+		nodes = append(nodes, e.(map[string]interface{})["node"].(string))
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+func taskRows(tasks []proxmox.Task) [][]string {
+	rows := make([][]string, len(tasks))
+	for i, e := range tasks {
+		status := e.Status
+		if e.EndTime.IsZero() && status == "" {
+			status = "running"
+		}
+		rows[i] = []string{e.StartTime.Format(time.RFC3339), e.Node, e.Type, e.ID, e.User, status, e.UPID}
+	}
+	return rows
+}
+
+func init() {
+	listCmd.AddCommand(list_tasksCmd)
+	list_tasksCmd.Flags().String("node", "", "Only list the tasks of this node")
+	list_tasksCmd.Flags().String("type", "", "Only list tasks of this type, e.g. vzdump or qmstart")
+	list_tasksCmd.Flags().Bool("errors-only", false, "Only list tasks that failed")
+	list_tasksCmd.Flags().Uint("limit", 0, "Maximum number of tasks to list")
+	list_tasksCmd.Flags().String("since", "", "Only list tasks started after this moment")
+}
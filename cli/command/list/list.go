@@ -2,6 +2,7 @@ package list
 
 import (
 	"context"
+	"io"
 
 	"github.com/perimeter-81/proxmox-api-go/cli"
 	"github.com/spf13/cobra"
@@ -38,5 +39,8 @@ func listRaw(IDtype string) {
 		list, err = c.GetStorageList(ctx)
 	}
 	cli.LogFatalListing(IDtype, err)
-	cli.PrintRawJson(listCmd.OutOrStdout(), list)
+	cli.LogFatalError(cli.PrintOutput(listCmd.OutOrStdout(), cli.Output{
+		Result: list,
+		Text:   func(out io.Writer) { cli.PrintRawJson(out, list) },
+	}))
 }
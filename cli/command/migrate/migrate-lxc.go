@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var migrate_lxcCmd = &cobra.Command{
+	Use:   "lxc GUESTID TARGETNODE",
+	Short: "Migrates the specified LXC container to the target node",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts proxmox.MigrateOptions
+		opts.Restart, _ = cmd.Flags().GetBool("restart")
+		return migrateGuest(cmd, args, "lxc", opts)
+	},
+}
+
+func init() {
+	migrateCmd.AddCommand(migrate_lxcCmd)
+	migrate_lxcCmd.Flags().Bool("restart", false, "Shut the running container down and start it again on the target node")
+	addMigrateFlags(migrate_lxcCmd)
+}
@@ -0,0 +1,25 @@
+package migrate
+
+import (
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var migrate_qemuCmd = &cobra.Command{
+	Use:   "qemu GUESTID TARGETNODE",
+	Short: "Migrates the specified qemu guest to the target node",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts proxmox.MigrateOptions
+		opts.Online, _ = cmd.Flags().GetBool("online")
+		opts.WithLocalDisks, _ = cmd.Flags().GetBool("with-local-disks")
+		return migrateGuest(cmd, args, "qemu", opts)
+	},
+}
+
+func init() {
+	migrateCmd.AddCommand(migrate_qemuCmd)
+	migrate_qemuCmd.Flags().Bool("online", false, "Live migrate the guest when it is running")
+	migrate_qemuCmd.Flags().Bool("with-local-disks", false, "Also migrate the disks on local storage")
+	addMigrateFlags(migrate_qemuCmd)
+}
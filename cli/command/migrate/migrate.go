@@ -0,0 +1,66 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "With this command you can migrate existing guests to another node",
+}
+
+func init() {
+	cli.RootCmd.AddCommand(migrateCmd)
+}
+
+// Flags shared by the migrate commands, read with cmd.Flags() as the values would persist between runs otherwise.
+func addMigrateFlags(cmd *cobra.Command) {
+	cmd.Flags().String("target-storage", "", "Storage on the target node for the local volumes of the guest")
+	cmd.Flags().Int("bwlimit", 0, "Limit the bandwidth of the migration in KiB/s")
+	cmd.Flags().Bool("wait", false, "Wait until the migration task has completed")
+}
+
+func migrateGuest(cmd *cobra.Command, args []string, guestType string, opts proxmox.MigrateOptions) (err error) {
+	vmr := proxmox.NewVmRef(cli.ValidateExistingGuestID(args, 0))
+	opts.Target = args[1]
+	opts.TargetStorage, _ = cmd.Flags().GetString("target-storage")
+	opts.BwLimit, _ = cmd.Flags().GetInt("bwlimit")
+	wait, _ := cmd.Flags().GetBool("wait")
+	c := cli.NewClient()
+	if err = c.CheckVmRef(context.Background(), vmr); err != nil {
+		return
+	}
+	if vmr.GetVmType() != guestType {
+		return fmt.Errorf("guest with id (%d) is not a %s guest", vmr.VmId(), guestType)
+	}
+	var upid string
+	if wait {
+		upid, err = c.MigrateGuest(context.Background(), vmr, opts)
+	} else {
+		upid, err = c.StartMigrateGuest(context.Background(), vmr, opts)
+	}
+	if err != nil {
+		return
+	}
+	return printMigrate(migrateCmd.OutOrStdout(), vmr.VmId(), opts.Target, upid, wait)
+}
+
+func printMigrate(out io.Writer, id int, target, upid string, wait bool) error {
+	return cli.PrintOutput(out, cli.Output{
+		Result: map[string]interface{}{"vmid": id, "target": target, "upid": upid},
+		Text: func(out io.Writer) {
+			if wait {
+				fmt.Fprintf(out, "Guest with id (%d) has been migrated to (%s)\n", id, target)
+			} else {
+				fmt.Fprintf(out, "Migrating guest with id (%d) to (%s) has been started\n", id, target)
+			}
+			fmt.Fprintf(out, "UPID: %s\n", upid)
+		},
+	})
+}
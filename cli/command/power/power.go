@@ -0,0 +1,57 @@
+package power
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var powerCmd = &cobra.Command{
+	Use:   "power GUESTID start|stop|shutdown|reboot|reset|suspend|resume",
+	Short: "Changes the power state of the specified qemu guest or LXC container",
+	Long: `Changes the power state of the specified qemu guest or LXC container.
+With --wait the command waits until the task has completed, at most the timeout in seconds set by --timeout.
+--shutdown-timeout limits how long shutdown and reboot wait for the guest to shut down,
+the task fails and the guest keeps running when it did not shut down in time.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		vmr := proxmox.NewVmRef(cli.ValidateExistingGuestID(args, 0))
+		action := proxmox.PowerAction(args[1])
+		shutdownTimeout, _ := cmd.Flags().GetUint("shutdown-timeout")
+		wait, _ := cmd.Flags().GetBool("wait")
+		if timeout, _ := cli.RootCmd.Flags().GetInt("timeout"); wait && shutdownTimeout >= uint(timeout) {
+			return fmt.Errorf("--shutdown-timeout (%d) must be lower than --timeout (%d) when waiting", shutdownTimeout, timeout)
+		}
+		c := cli.NewClient()
+		var upid string
+		if wait {
+			upid, err = c.ChangePowerState(context.Background(), vmr, action, shutdownTimeout)
+		} else {
+			upid, err = c.StartChangePowerState(context.Background(), vmr, action, shutdownTimeout)
+		}
+		if err != nil {
+			return
+		}
+		return cli.PrintOutput(cmd.OutOrStdout(), cli.Output{
+			Result: map[string]interface{}{"vmid": vmr.VmId(), "action": action, "upid": upid},
+			Text: func(out io.Writer) {
+				if wait {
+					fmt.Fprintf(out, "Power action (%s) on guest with id (%d) has completed\n", action, vmr.VmId())
+				} else {
+					fmt.Fprintf(out, "Power action (%s) on guest with id (%d) has been started\n", action, vmr.VmId())
+				}
+				fmt.Fprintf(out, "UPID: %s\n", upid)
+			},
+		})
+	},
+}
+
+func init() {
+	cli.RootCmd.AddCommand(powerCmd)
+	powerCmd.Flags().Bool("wait", false, "Wait until the power task has completed")
+	powerCmd.Flags().Uint("shutdown-timeout", 0, "Seconds shutdown and reboot wait for the guest to shut down, 0 uses the default of Proxmox")
+}
@@ -0,0 +1,36 @@
+package snapshot
+
+import (
+	"context"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var snapshot_createCmd = &cobra.Command{
+	Use:   "create GUESTID SNAPSHOTNAME",
+	Short: "Creates a new snapshot of the specified guest",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		c := cli.NewClient()
+		vmr, err := guestRef(cmd, c, args)
+		if err != nil {
+			return
+		}
+		config := proxmox.ConfigSnapshot{Name: cli.RequiredIDset(args, 1, "SnapshotName")}
+		config.Description, _ = cmd.Flags().GetString("description")
+		config.VmState, _ = cmd.Flags().GetBool("vmstate")
+		upid, err := c.CreateSnapshot(context.Background(), vmr, config)
+		if err != nil {
+			return
+		}
+		return printSnapshotTask(snapshotCmd.OutOrStdout(), vmr.VmId(), config.Name, upid, "created")
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshot_createCmd)
+	snapshot_createCmd.Flags().String("description", "", "Description of the snapshot")
+	snapshot_createCmd.Flags().Bool("vmstate", false, "Include the memory of the running guest, qemu only")
+}
@@ -0,0 +1,31 @@
+package snapshot
+
+import (
+	"context"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/spf13/cobra"
+)
+
+var snapshot_deleteCmd = &cobra.Command{
+	Use:   "delete GUESTID SNAPSHOTNAME",
+	Short: "Deletes the specified snapshot of the guest",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		c := cli.NewClient()
+		vmr, err := guestRef(cmd, c, args)
+		if err != nil {
+			return
+		}
+		snapName := cli.RequiredIDset(args, 1, "SnapshotName")
+		upid, err := c.DeleteSnapshot(context.Background(), vmr, snapName)
+		if err != nil {
+			return
+		}
+		return printSnapshotTask(snapshotCmd.OutOrStdout(), vmr.VmId(), snapName, upid, "deleted")
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshot_deleteCmd)
+}
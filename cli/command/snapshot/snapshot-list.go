@@ -0,0 +1,79 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var snapshot_listCmd = &cobra.Command{
+	Use:   "list GUESTID",
+	Short: "Prints the snapshots of the specified guest as a tree",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		c := cli.NewClient()
+		vmr, err := guestRef(cmd, c, args)
+		if err != nil {
+			return
+		}
+		tree, err := c.GetSnapshotTree(context.Background(), vmr)
+		if err != nil {
+			return
+		}
+		return cli.PrintOutput(snapshotCmd.OutOrStdout(), cli.Output{
+			Result: tree,
+			Text: func(out io.Writer) {
+				if len(tree) == 0 {
+					fmt.Fprintf(out, "Guest with id (%d) has no snapshots\n", vmr.VmId())
+					return
+				}
+				printSnapshotTree(out, tree, 0)
+			},
+			Headers: []string{"NAME", "PARENT", "TIME", "VMSTATE", "DESCRIPTION"},
+			Rows:    snapshotRows(nil, tree, ""),
+		})
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshot_listCmd)
+}
+
+// Children are indented below their parent.
+func printSnapshotTree(out io.Writer, tree []*proxmox.Snapshot, depth int) {
+	for _, e := range tree {
+		line := strings.Repeat("  ", depth) + e.Name
+		if e.SnapTime != 0 {
+			line += " " + snapshotTime(e)
+		}
+		if e.Description != "" {
+			line += " " + strings.TrimSpace(e.Description)
+		}
+		fmt.Fprintln(out, line)
+		printSnapshotTree(out, e.Children, depth+1)
+	}
+}
+
+// The parent is passed down as the tree does not keep it in the children.
+func snapshotRows(rows [][]string, tree []*proxmox.Snapshot, parent string) [][]string {
+	for _, e := range tree {
+		rows = append(rows, []string{e.Name, parent, snapshotTime(e), strconv.FormatBool(e.VmState), strings.TrimSpace(e.Description)})
+		rows = snapshotRows(rows, e.Children, e.Name)
+	}
+	return rows
+}
+
+// Empty for snapshots without a time, e.g. those taken by older versions of Proxmox.
+func snapshotTime(snapshot *proxmox.Snapshot) string {
+	if snapshot.SnapTime == 0 {
+		return ""
+	}
+	return time.Unix(int64(snapshot.SnapTime), 0).Format(time.RFC3339)
+}
@@ -0,0 +1,23 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_snapshotRows(t *testing.T) {
+	tree := proxmox.FormatSnapshotsTree([]interface{}{
+		map[string]interface{}{"name": "base", "description": "first\n"},
+		map[string]interface{}{"name": "update", "parent": "base", "vmstate": float64(1)},
+		map[string]interface{}{"name": "test", "parent": "update"},
+		map[string]interface{}{"name": "other", "parent": "base"},
+	})
+	require.Equal(t, [][]string{
+		{"base", "", "", "false", "first"},
+		{"update", "base", "", "true", ""},
+		{"test", "update", "", "false", ""},
+		{"other", "base", "", "false", ""},
+	}, snapshotRows(nil, tree, ""))
+}
@@ -0,0 +1,48 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "With this command you can manage the snapshots of qemu guests and LXC containers",
+}
+
+func init() {
+	cli.RootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.PersistentFlags().String("type", "", "Type of the guest (qemu,lxc), detected when omitted")
+}
+
+// Resolves the guest and checks it is of the type given with --type.
+func guestRef(cmd *cobra.Command, c *proxmox.Client, args []string) (*proxmox.VmRef, error) {
+	vmr := proxmox.NewVmRef(cli.ValidateExistingGuestID(args, 0))
+	guestType, _ := cmd.Flags().GetString("type")
+	switch guestType {
+	case "", "qemu", "lxc":
+	default:
+		return nil, fmt.Errorf("type must be one of (qemu,lxc)")
+	}
+	if err := c.CheckVmRef(context.Background(), vmr); err != nil {
+		return nil, err
+	}
+	if guestType != "" && vmr.GetVmType() != guestType {
+		return nil, fmt.Errorf("guest with id (%d) is not a %s guest", vmr.VmId(), guestType)
+	}
+	return vmr, nil
+}
+
+func printSnapshotTask(out io.Writer, id int, snapName, upid, text string) error {
+	return cli.PrintOutput(out, cli.Output{
+		Result: map[string]interface{}{"vmid": id, "snapshot": snapName, "upid": upid},
+		Text: func(out io.Writer) {
+			fmt.Fprintf(out, "Snapshot (%s) of guest with id (%d) has been %s\nUPID: %s\n", snapName, id, text, upid)
+		},
+	})
+}
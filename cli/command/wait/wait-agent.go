@@ -0,0 +1,34 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/perimeter-81/proxmox-api-go/proxmox"
+	"github.com/spf13/cobra"
+)
+
+var wait_agentCmd = &cobra.Command{
+	Use:   "agent GUESTID",
+	Short: "Waits until the guest agent of the specified qemu guest responds",
+	Long: `Waits until the guest agent of the specified qemu guest responds.
+Exits with an error when the agent did not respond within the timeout in seconds set by --timeout.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		vmr := proxmox.NewVmRef(cli.ValidateIntIDset(args, "GuestID"))
+		timeout, _ := cli.RootCmd.Flags().GetInt("timeout")
+		c := cli.NewClient()
+		err = c.WaitForAgent(context.Background(), vmr, time.Duration(timeout)*time.Second)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(waitCmd.OutOrStdout(), "Guest agent of guest with id (%d) is responding\n", vmr.VmId())
+		return
+	},
+}
+
+func init() {
+	waitCmd.AddCommand(wait_agentCmd)
+}
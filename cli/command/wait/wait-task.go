@@ -0,0 +1,34 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/spf13/cobra"
+)
+
+var wait_taskCmd = &cobra.Command{
+	Use:   "task UPID",
+	Short: "Waits until the specified task completed",
+	Long: `Waits until the specified task completed.
+Exits with an error when the task failed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		upid := cli.RequiredIDset(args, 0, "UPID")
+		c := cli.NewClient()
+		exitStatus, err := c.WaitForTask(context.Background(), upid, nil)
+		if err != nil {
+			return
+		}
+		return cli.PrintOutput(waitCmd.OutOrStdout(), cli.Output{
+			Result: map[string]interface{}{"upid": upid, "exitstatus": exitStatus},
+			Text:   func(out io.Writer) { fmt.Fprintf(out, "Task (%s) completed with status %s\n", upid, exitStatus) },
+		})
+	},
+}
+
+func init() {
+	waitCmd.AddCommand(wait_taskCmd)
+}
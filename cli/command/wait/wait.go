@@ -0,0 +1,15 @@
+package wait
+
+import (
+	"github.com/perimeter-81/proxmox-api-go/cli"
+	"github.com/spf13/cobra"
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait",
+	Short: "With this command you can wait until a resource in proxmox is ready",
+}
+
+func init() {
+	cli.RootCmd.AddCommand(waitCmd)
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputFormat_Json  = "json"
+	OutputFormat_Yaml  = "yaml"
+	OutputFormat_Table = "table"
+)
+
+func init() {
+	RootCmd.PersistentFlags().StringP("output", "o", "", "output format (json|yaml|table), defaults to the human friendly output of the command")
+	// reject the format before the command changes anything, not when the result is printed
+	RootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		format, _ := RootCmd.PersistentFlags().GetString("output")
+		return validateOutputFormat(format)
+	}
+}
+
+func validateOutputFormat(format string) error {
+	switch format {
+	case "", OutputFormat_Json, OutputFormat_Yaml, OutputFormat_Table:
+		return nil
+	}
+	return fmt.Errorf("error: output must be one of (%s,%s,%s)", OutputFormat_Json, OutputFormat_Yaml, OutputFormat_Table)
+}
+
+// Result of a command, rendered in the format chosen with --output.
+type Output struct {
+	// Rendered as json or yaml, using the json names of the fields.
+	Result interface{}
+	// Human friendly rendering, used when no output format is chosen. Defaults to Result as indented json.
+	Text func(out io.Writer)
+	// Columns of the table format, commands without columns fall back to Text.
+	Headers []string
+	Rows    [][]string
+}
+
+// PrintOutput renders the output in the format chosen with --output.
+func PrintOutput(out io.Writer, output Output) error {
+	format, _ := RootCmd.PersistentFlags().GetString("output")
+	if err := validateOutputFormat(format); err != nil {
+		return err
+	}
+	if output.Text == nil {
+		output.Text = func(out io.Writer) { PrintFormattedJson(out, output.Result) }
+	}
+	switch format {
+	case "":
+		output.Text(out)
+	case OutputFormat_Json:
+		PrintFormattedJson(out, output.Result)
+	case OutputFormat_Yaml:
+		return printYaml(out, output.Result)
+	case OutputFormat_Table:
+		if output.Headers == nil {
+			output.Text(out)
+		} else {
+			PrintTable(out, output.Headers, output.Rows)
+		}
+	}
+	return nil
+}
+
+// Converts the input to json first, so the yaml has the same keys in the same order as the json output.
+func printYaml(out io.Writer, input interface{}) error {
+	jsonBytes, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err = yaml.Unmarshal(jsonBytes, &node); err != nil {
+		return err
+	}
+	resetYamlStyle(&node)
+	yamlBytes, err := yaml.Marshal(&node)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(yamlBytes)
+	return err
+}
+
+// Json is parsed as flow style yaml with quoted strings, reset it to the default block style.
+func resetYamlStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, e := range node.Content {
+		resetYamlStyle(e)
+	}
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setOutputFormat(t *testing.T, format string) {
+	require.NoError(t, RootCmd.PersistentFlags().Set("output", format))
+	t.Cleanup(func() { RootCmd.PersistentFlags().Set("output", "") })
+}
+
+func Test_PrintOutput(t *testing.T) {
+	output := Output{
+		Result:  []map[string]interface{}{{"name": "pve", "online": true}},
+		Text:    func(out io.Writer) { io.WriteString(out, "pve is online\n") },
+		Headers: []string{"NAME", "ONLINE"},
+		Rows:    [][]string{{"pve", "true"}},
+	}
+	tests := []struct {
+		format string
+		output string
+	}{
+		{format: "", output: "pve is online\n"},
+		{format: OutputFormat_Json, output: "[\n  {\n    \"name\": \"pve\",\n    \"online\": true\n  }\n]\n"},
+		{format: OutputFormat_Yaml, output: "- name: pve\n  online: true\n"},
+		{format: OutputFormat_Table, output: "NAME  ONLINE\npve   true\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			setOutputFormat(t, test.format)
+			var out bytes.Buffer
+			require.NoError(t, PrintOutput(&out, output))
+			require.Equal(t, test.output, out.String())
+		})
+	}
+
+	// commands without columns fall back to the human friendly output
+	setOutputFormat(t, OutputFormat_Table)
+	output.Headers = nil
+	var out bytes.Buffer
+	require.NoError(t, PrintOutput(&out, output))
+	require.Equal(t, "pve is online\n", out.String())
+}
+
+func Test_PrintOutput_invalid(t *testing.T) {
+	setOutputFormat(t, "xml")
+	err := RootCmd.PersistentPreRunE(RootCmd, nil)
+	require.EqualError(t, err, "error: output must be one of (json,yaml,table)")
+	var out bytes.Buffer
+	require.Error(t, PrintOutput(&out, Output{Result: "test"}))
+	require.Empty(t, out.String())
+
+	setOutputFormat(t, OutputFormat_Yaml)
+	require.NoError(t, RootCmd.PersistentPreRunE(RootCmd, nil))
+}
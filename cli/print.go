@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"text/tabwriter"
 )
 
 func PrintGuestStatus(out io.Writer, id int, text string) {
@@ -37,3 +39,13 @@ func PrintFormattedJson(out io.Writer, input interface{}) {
 	LogFatalError(err)
 	fmt.Fprintln(out, string(list))
 }
+
+// Prints the rows as columns aligned below the headers.
+func PrintTable(out io.Writer, headers []string, rows [][]string) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	LogFatalError(w.Flush())
+}
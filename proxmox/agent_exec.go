@@ -0,0 +1,127 @@
+package proxmox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Interval between polls of the status of a command started by the guest agent.
+const AgentExecPollInterval = 1 * time.Second
+
+// Outcome of a command executed by the guest agent.
+type AgentExecResult struct {
+	ExitCode int
+	// Signal that terminated the command, 0 when the command exited by itself.
+	Signal int
+	Stderr string
+	Stdout string
+	// The guest agent truncates output larger than 16MiB.
+	StderrTruncated bool
+	StdoutTruncated bool
+}
+
+// Parses the response of agent/exec-status, exited is false while the command is still running.
+func (result *AgentExecResult) mapToStruct(status map[string]interface{}) (exited bool) {
+	if !agentExecBool(status["exited"]) {
+		return false
+	}
+	if _, isSet := status["exitcode"]; isSet {
+		result.ExitCode = int(status["exitcode"].(float64))
+	}
+	if _, isSet := status["signal"]; isSet {
+		result.Signal = int(status["signal"].(float64))
+	}
+	if _, isSet := status["err-data"]; isSet {
+		result.Stderr = status["err-data"].(string)
+	}
+	if _, isSet := status["out-data"]; isSet {
+		result.Stdout = status["out-data"].(string)
+	}
+	result.StderrTruncated = agentExecBool(status["err-truncated"])
+	result.StdoutTruncated = agentExecBool(status["out-truncated"])
+	return true
+}
+
+// The guest agent reports booleans either as true/false or as 1/0.
+func agentExecBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v == 1
+	}
+	return false
+}
+
+// AgentExec executes the command with its arguments in the guest and waits until it exits or the timeout is reached.
+// Input is passed to the stdin of the command. Proxmox takes care of the base64 encoding of the input and output.
+// A timeout of 0 uses the task timeout of the client.
+// Returns Error_GuestAgentNotRunning when the agent is not running or not enabled.
+func (c *Client) AgentExec(ctx context.Context, vmr *VmRef, command string, args []string, input string, timeout time.Duration) (*AgentExecResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	err := c.CheckVmRef(ctx, vmr)
+	if err != nil {
+		return nil, err
+	}
+	// every part of the command is passed as a separate "command" value
+	values := url.Values{}
+	values.Add("command", command)
+	for _, arg := range args {
+		values.Add("command", arg)
+	}
+	if input != "" {
+		values.Set("input-data", input)
+	}
+	reqbody := bytes.NewBufferString(values.Encode()).Bytes()
+	resp, err := c.session.Post(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/agent/exec", vmr.node, vmr.vmId), nil, nil, &reqbody)
+	if err != nil {
+		if agentNotRunning(resp) {
+			return nil, errors.New(Error_GuestAgentNotRunning)
+		}
+		return nil, err
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := taskResponse["data"].(map[string]interface{})
+	if _, isSet := data["pid"]; !isSet {
+		return nil, fmt.Errorf("qemu agent exec not readable")
+	}
+	pid := strconv.Itoa(int(data["pid"].(float64)))
+
+	deadline := time.Now().Add(c.timeoutOrTaskTimeout(timeout))
+	for {
+		status, err := c.GetExecStatus(ctx, vmr, pid)
+		if err != nil {
+			return nil, err
+		}
+		result := AgentExecResult{}
+		if result.mapToStruct(status) {
+			return &result, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout waiting for command (%s) with pid (%s) to exit", command, pid)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(AgentExecPollInterval):
+		}
+	}
+}
+
+// Returns the timeout, or the task timeout of the client when the timeout is 0.
+func (c *Client) timeoutOrTaskTimeout(timeout time.Duration) time.Duration {
+	if timeout == 0 {
+		return time.Duration(c.TaskTimeout) * time.Second
+	}
+	return timeout
+}
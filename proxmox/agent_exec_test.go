@@ -0,0 +1,27 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AgentExecResult_mapToStruct(t *testing.T) {
+	result := AgentExecResult{}
+	require.False(t, result.mapToStruct(map[string]interface{}{"exited": float64(0)}))
+	require.False(t, result.mapToStruct(map[string]interface{}{"exited": false}))
+
+	result = AgentExecResult{}
+	require.True(t, result.mapToStruct(map[string]interface{}{
+		"exited":        float64(1),
+		"exitcode":      float64(2),
+		"out-data":      "hello\n",
+		"err-data":      "warning\n",
+		"out-truncated": true,
+	}))
+	require.Equal(t, AgentExecResult{ExitCode: 2, Stdout: "hello\n", Stderr: "warning\n", StdoutTruncated: true}, result)
+
+	result = AgentExecResult{}
+	require.True(t, result.mapToStruct(map[string]interface{}{"exited": true, "signal": float64(9)}))
+	require.Equal(t, AgentExecResult{Signal: 9}, result)
+}
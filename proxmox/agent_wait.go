@@ -0,0 +1,58 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Interval between pings of the guest agent by WaitForAgent.
+const AgentPingInterval = 2 * time.Second
+
+func (c *Client) pingAgent(ctx context.Context, vmr *VmRef) error {
+	_, err := c.session.Post(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/agent/ping", vmr.node, vmr.vmId), nil, nil, nil)
+	return err
+}
+
+// WaitForAgent pings the guest agent until it responds, e.g. after starting the guest.
+// Returns an error when the agent did not respond within the timeout, a timeout of 0 uses the task timeout of the client.
+func (c *Client) WaitForAgent(ctx context.Context, vmr *VmRef, timeout time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	if vmr.vmType != "qemu" {
+		return fmt.Errorf("guest %d is not a qemu guest", vmr.vmId)
+	}
+	timeout = c.timeoutOrTaskTimeout(timeout)
+	err := pollAgent(ctx, timeout, AgentPingInterval, func(ctx context.Context) error {
+		return c.pingAgent(ctx, vmr)
+	})
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("guest agent of guest %d did not respond within %s: %v", vmr.vmId, timeout, err)
+	}
+	return err
+}
+
+// Calls ping every interval until it succeeds.
+// Returns the error of the last ping when the timeout passed, or the error of ctx when it is done.
+func pollAgent(ctx context.Context, timeout, interval time.Duration, ping func(context.Context) error) error {
+	deadline, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		err := ping(deadline)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-deadline.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		case <-time.After(interval):
+		}
+	}
+}
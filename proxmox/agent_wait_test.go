@@ -0,0 +1,42 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pollAgent(t *testing.T) {
+	pings := 0
+	err := pollAgent(context.Background(), time.Second, time.Millisecond, func(context.Context) error {
+		pings++
+		if pings < 3 {
+			return errors.New("500 QEMU guest agent is not running")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, pings)
+
+	// the agent never responds
+	err = pollAgent(context.Background(), 20*time.Millisecond, time.Millisecond, func(context.Context) error {
+		return errors.New("500 QEMU guest agent is not running")
+	})
+	require.EqualError(t, err, "500 QEMU guest agent is not running")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = pollAgent(ctx, time.Second, time.Millisecond, func(context.Context) error {
+		return errors.New("500 QEMU guest agent is not running")
+	})
+	require.Equal(t, context.Canceled, err)
+}
+
+func Test_Client_timeoutOrTaskTimeout(t *testing.T) {
+	c := &Client{TaskTimeout: 300}
+	require.Equal(t, 300*time.Second, c.timeoutOrTaskTimeout(0))
+	require.Equal(t, 5*time.Second, c.timeoutOrTaskTimeout(5*time.Second))
+}
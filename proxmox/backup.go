@@ -0,0 +1,231 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type BackupMode string
+
+const (
+	BackupMode_Snapshot BackupMode = "snapshot"
+	BackupMode_Suspend  BackupMode = "suspend"
+	BackupMode_Stop     BackupMode = "stop"
+)
+
+// Empty is valid, Proxmox then uses snapshot.
+func (mode BackupMode) Validate() error {
+	switch mode {
+	case "", BackupMode_Snapshot, BackupMode_Suspend, BackupMode_Stop:
+		return nil
+	}
+	return errors.New("backup mode must be one of (snapshot,suspend,stop)")
+}
+
+type BackupCompression string
+
+const (
+	BackupCompression_None BackupCompression = "0"
+	BackupCompression_Zstd BackupCompression = "zstd"
+	BackupCompression_Gzip BackupCompression = "gzip"
+	BackupCompression_Lzo  BackupCompression = "lzo"
+)
+
+// Empty is valid, Proxmox then does not compress the backup.
+func (compress BackupCompression) Validate() error {
+	switch compress {
+	case "", BackupCompression_None, BackupCompression_Zstd, BackupCompression_Gzip, BackupCompression_Lzo:
+		return nil
+	}
+	return errors.New("backup compression must be one of (0,zstd,gzip,lzo)")
+}
+
+type BackupMailNotification string
+
+const (
+	BackupMailNotification_Always  BackupMailNotification = "always"
+	BackupMailNotification_Failure BackupMailNotification = "failure"
+)
+
+// Empty is valid, Proxmox then uses always.
+func (notification BackupMailNotification) Validate() error {
+	switch notification {
+	case "", BackupMailNotification_Always, BackupMailNotification_Failure:
+		return nil
+	}
+	return errors.New("backup mail notification must be one of (always,failure)")
+}
+
+// Number of backups to keep per period, nil values are not used for pruning.
+// When all values are nil all backups are kept.
+type BackupRetention struct {
+	Last    *uint `json:"keep-last,omitempty"`
+	Hourly  *uint `json:"keep-hourly,omitempty"`
+	Daily   *uint `json:"keep-daily,omitempty"`
+	Weekly  *uint `json:"keep-weekly,omitempty"`
+	Monthly *uint `json:"keep-monthly,omitempty"`
+	Yearly  *uint `json:"keep-yearly,omitempty"`
+}
+
+var backupRetentionKeys = []string{"keep-last", "keep-hourly", "keep-daily", "keep-weekly", "keep-monthly", "keep-yearly"}
+
+func (retention BackupRetention) values() []*uint {
+	return []*uint{retention.Last, retention.Hourly, retention.Daily, retention.Weekly, retention.Monthly, retention.Yearly}
+}
+
+func (retention BackupRetention) String() string {
+	settings := make([]string, 0, len(backupRetentionKeys))
+	for i, e := range retention.values() {
+		if e != nil {
+			settings = append(settings, backupRetentionKeys[i]+"="+strconv.FormatUint(uint64(*e), 10))
+		}
+	}
+	if len(settings) == 0 {
+		return "keep-all=1"
+	}
+	return strings.Join(settings, ",")
+}
+
+func (BackupRetention) mapToStruct(prune string) *BackupRetention {
+	retention := BackupRetention{}
+	pointers := []**uint{&retention.Last, &retention.Hourly, &retention.Daily, &retention.Weekly, &retention.Monthly, &retention.Yearly}
+	for _, e := range strings.Split(prune, ",") {
+		key, value, _ := strings.Cut(e, "=")
+		for i, k := range backupRetentionKeys {
+			if key == k {
+				if number, err := strconv.ParseUint(value, 10, 32); err == nil {
+					*pointers[i] = PointerUint(uint(number))
+				}
+			}
+		}
+	}
+	return &retention
+}
+
+func (retention BackupRetention) Validate() error {
+	for i, e := range retention.values() {
+		if e != nil && *e == 0 {
+			return errors.New("backup retention " + backupRetentionKeys[i] + " must be greater than 0")
+		}
+	}
+	return nil
+}
+
+// Options for CreateBackup, empty values use the defaults of the node.
+type BackupOptions struct {
+	GuestIDs []uint `json:"vmid"`
+	// Storage the backup is written to, must allow the backup content type.
+	Storage  string            `json:"storage,omitempty"`
+	Mode     BackupMode        `json:"mode,omitempty"`
+	Compress BackupCompression `json:"compress,omitempty"`
+	// Template for the notes of the backup, e.g. "{{guestname}}".
+	NotesTemplate string `json:"notes-template,omitempty"`
+	// Protect the backup from being pruned or removed.
+	Protected bool `json:"protected,omitempty"`
+	// Prune older backups according to Retention, or the retention of the storage when Retention is nil.
+	Remove    *bool            `json:"remove,omitempty"`
+	Retention *BackupRetention `json:"prune-backups,omitempty"`
+	MailTo    []string         `json:"mailto,omitempty"`
+	// When to send a mail to MailTo.
+	MailNotification BackupMailNotification `json:"mailnotification,omitempty"`
+}
+
+func (opts BackupOptions) mapToApiValues() map[string]interface{} {
+	ids := make([]string, len(opts.GuestIDs))
+	for i, e := range opts.GuestIDs {
+		ids[i] = strconv.FormatUint(uint64(e), 10)
+	}
+	params := map[string]interface{}{
+		"vmid":             strings.Join(ids, ","),
+		"storage":          opts.Storage,
+		"mode":             string(opts.Mode),
+		"compress":         string(opts.Compress),
+		"notes-template":   opts.NotesTemplate,
+		"mailto":           strings.Join(opts.MailTo, ","),
+		"mailnotification": string(opts.MailNotification),
+	}
+	if opts.Protected {
+		params["protected"] = true
+	}
+	if opts.Remove != nil {
+		params["remove"] = *opts.Remove
+	}
+	if opts.Retention != nil {
+		params["prune-backups"] = opts.Retention.String()
+	}
+	return params
+}
+
+func (opts BackupOptions) Validate() error {
+	if len(opts.GuestIDs) == 0 {
+		return errors.New("at least one guest id must be specified")
+	}
+	for _, e := range opts.GuestIDs {
+		if e < 100 {
+			return fmt.Errorf("guest id %d must be at least 100", e)
+		}
+	}
+	if err := opts.Mode.Validate(); err != nil {
+		return err
+	}
+	if err := opts.Compress.Validate(); err != nil {
+		return err
+	}
+	if err := opts.MailNotification.Validate(); err != nil {
+		return err
+	}
+	if opts.Retention != nil {
+		return opts.Retention.Validate()
+	}
+	return nil
+}
+
+// Checks the options against the config of the storage the backup is written to.
+// The mode is not checked, every mode can write to every storage type that allows backups.
+// The mode only depends on the storage of the guest, vzdump falls back to suspend mode by itself
+// when a container has volumes on a storage without snapshot support.
+func (opts BackupOptions) validateStorage(storage map[string]interface{}) error {
+	content, _ := storage["content"].(string)
+	if !inArray(CSVtoArray(content), "backup") {
+		return fmt.Errorf("storage %s does not allow backups", opts.Storage)
+	}
+	if storage["type"] == "pbs" && opts.Compress != "" && opts.Compress != BackupCompression_None {
+		// Proxmox Backup Server always compresses the chunks with zstd.
+		return fmt.Errorf("compression is not supported on proxmox backup server storage %s", opts.Storage)
+	}
+	return nil
+}
+
+// CreateBackup starts a backup of the guests on the node, which have to reside on the node.
+// Returns the UPID of the backup task without waiting for it, use WaitForTask to wait for the backup to complete.
+func (c *Client) CreateBackup(ctx context.Context, node string, opts BackupOptions) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = opts.Validate(); err != nil {
+		return
+	}
+	if opts.Storage != "" {
+		storage, err := c.GetStorageConfig(ctx, opts.Storage)
+		if err != nil {
+			return "", err
+		}
+		if err = opts.validateStorage(storage); err != nil {
+			return "", err
+		}
+	}
+	reqbody := ParamsToBody(opts.mapToApiValues())
+	resp, err := c.session.Post(ctx, "/nodes/"+node+"/vzdump", nil, nil, &reqbody)
+	if err != nil {
+		return "", fmt.Errorf("error creating backup: %v, error status: %s", err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	return
+}
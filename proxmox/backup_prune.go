@@ -0,0 +1,98 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type PruneMark string
+
+const (
+	PruneMark_Keep      PruneMark = "keep"
+	PruneMark_Remove    PruneMark = "remove"
+	PruneMark_Protected PruneMark = "protected"
+	// Backups that do not follow the vzdump naming scheme are never pruned.
+	PruneMark_Renamed PruneMark = "renamed"
+)
+
+// Backup as evaluated against the retention by PruneBackups.
+type PruneBackupEntry struct {
+	VolID        string    `json:"volid"`
+	CreationTime time.Time `json:"ctime"`
+	// What pruning does to the backup, protected backups are always kept.
+	Mark PruneMark `json:"mark"`
+	// Type of the guest, qemu or lxc.
+	Type string `json:"type"`
+	VmID uint   `json:"vmid,omitempty"`
+}
+
+func (PruneBackupEntry) mapToStruct(params map[string]interface{}) PruneBackupEntry {
+	entry := PruneBackupEntry{}
+	if _, isSet := params["volid"]; isSet {
+		entry.VolID = params["volid"].(string)
+	}
+	if _, isSet := params["ctime"]; isSet {
+		entry.CreationTime = time.Unix(int64(params["ctime"].(float64)), 0)
+	}
+	if _, isSet := params["mark"]; isSet {
+		entry.Mark = PruneMark(params["mark"].(string))
+	}
+	if _, isSet := params["type"]; isSet {
+		entry.Type = params["type"].(string)
+	}
+	if _, isSet := params["vmid"]; isSet {
+		entry.VmID = uint(params["vmid"].(float64))
+	}
+	return entry
+}
+
+// Returns the backups that are removed, protected backups are never part of this.
+func PrunedBackups(entries []PruneBackupEntry) []PruneBackupEntry {
+	pruned := make([]PruneBackupEntry, 0)
+	for _, e := range entries {
+		if e.Mark == PruneMark_Remove {
+			pruned = append(pruned, e)
+		}
+	}
+	return pruned
+}
+
+func pruneBackupsUrl(node, storage string, vmid uint, keep BackupRetention) string {
+	query := url.Values{"prune-backups": []string{keep.String()}}
+	if vmid != 0 {
+		query.Set("vmid", strconv.FormatUint(uint64(vmid), 10))
+	}
+	return "/nodes/" + node + "/storage/" + storage + "/prunebackups?" + query.Encode()
+}
+
+// PruneBackups evaluates the backups on the storage against the retention, when vmid is 0 the backups of all guests are evaluated.
+// With dryRun nothing is removed, otherwise the backups marked for removal are removed and the call waits for the prune task.
+// Returns the evaluation of every backup, protected backups are marked as such and never removed.
+func (c *Client) PruneBackups(ctx context.Context, node, storage string, vmid uint, keep BackupRetention, dryRun bool) ([]PruneBackupEntry, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := keep.Validate(); err != nil {
+		return nil, err
+	}
+	pruneUrl := pruneBackupsUrl(node, storage, vmid, keep)
+	list, err := c.GetItemListInterfaceArray(ctx, pruneUrl)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]PruneBackupEntry, len(list))
+	for i, e := range list {
+		entries[i] = PruneBackupEntry{}.mapToStruct(e.(map[string]interface{}))
+	}
+	if dryRun || len(PrunedBackups(entries)) == 0 {
+		return entries, nil
+	}
+	exitStatus, err := c.DeleteWithTask(ctx, pruneUrl)
+	if err != nil {
+		return entries, fmt.Errorf("error pruning backups: %v, error status: %s", err, exitStatus)
+	}
+	return entries, nil
+}
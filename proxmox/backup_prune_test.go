@@ -0,0 +1,42 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PruneBackupEntry_mapToStruct(t *testing.T) {
+	require.Equal(t, PruneBackupEntry{
+		VolID:        "local:backup/vzdump-qemu-100-2023_01_01-00_00_00.vma.zst",
+		CreationTime: time.Unix(1672531200, 0),
+		Mark:         PruneMark_Protected,
+		Type:         "qemu",
+		VmID:         100,
+	}, PruneBackupEntry{}.mapToStruct(map[string]interface{}{
+		"volid": "local:backup/vzdump-qemu-100-2023_01_01-00_00_00.vma.zst",
+		"ctime": float64(1672531200),
+		"mark":  "protected",
+		"type":  "qemu",
+		"vmid":  float64(100),
+	}))
+}
+
+func Test_PrunedBackups(t *testing.T) {
+	entries := []PruneBackupEntry{
+		{VolID: "a", Mark: PruneMark_Keep},
+		{VolID: "b", Mark: PruneMark_Remove},
+		{VolID: "c", Mark: PruneMark_Protected},
+		{VolID: "d", Mark: PruneMark_Renamed},
+		{VolID: "e", Mark: PruneMark_Remove},
+	}
+	require.Equal(t, []PruneBackupEntry{{VolID: "b", Mark: PruneMark_Remove}, {VolID: "e", Mark: PruneMark_Remove}}, PrunedBackups(entries))
+	require.Empty(t, PrunedBackups(nil))
+}
+
+func Test_pruneBackupsUrl(t *testing.T) {
+	require.Equal(t, "/nodes/pve/storage/local/prunebackups?prune-backups=keep-last%3D2%2Ckeep-daily%3D7&vmid=100",
+		pruneBackupsUrl("pve", "local", 100, BackupRetention{Last: PointerUint(2), Daily: PointerUint(7)}))
+	require.Equal(t, "/nodes/pve/storage/local/prunebackups?prune-backups=keep-all%3D1", pruneBackupsUrl("pve", "local", 0, BackupRetention{}))
+}
@@ -0,0 +1,150 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Options for RestoreVM and RestoreLxc.
+type RestoreOptions struct {
+	// Node the guest is restored on.
+	Node string `json:"node"`
+	// Volume ID or path of the vzdump archive, e.g. "local:backup/vzdump-qemu-100-2023_01_01-00_00_00.vma.zst".
+	Archive string `json:"archive"`
+	// ID of the restored guest, when 0 the next free ID is used.
+	GuestID int `json:"vmid,omitempty"`
+	// Storage all volumes are restored to, when empty the volumes are restored to the storage they were backed up from.
+	Storage string `json:"storage,omitempty"`
+	// Regenerate unique properties like the MAC addresses of the network interfaces.
+	Unique bool `json:"unique,omitempty"`
+	// Overwrite the existing guest with GuestID, all its volumes are replaced.
+	Force bool   `json:"force,omitempty"`
+	Pool  string `json:"pool,omitempty"`
+	// Start the guest after the restore completed.
+	Start bool `json:"start,omitempty"`
+	// Bandwidth limit in KiB/s, 0 uses the limit of the node.
+	BandwidthLimit uint `json:"bwlimit,omitempty"`
+}
+
+func (opts RestoreOptions) mapToApiValues(guestType string, guestID int) map[string]interface{} {
+	params := map[string]interface{}{
+		"vmid":    guestID,
+		"storage": opts.Storage,
+		"pool":    opts.Pool,
+	}
+	if guestType == "lxc" {
+		params["ostemplate"] = opts.Archive
+		params["restore"] = true
+	} else {
+		params["archive"] = opts.Archive
+	}
+	if opts.Unique {
+		params["unique"] = true
+	}
+	if opts.Force {
+		params["force"] = true
+	}
+	if opts.Start {
+		params["start"] = true
+	}
+	if opts.BandwidthLimit != 0 {
+		params["bwlimit"] = opts.BandwidthLimit
+	}
+	return params
+}
+
+func (opts RestoreOptions) Validate() error {
+	if err := ValidateStringNotEmpty(opts.Node, "node"); err != nil {
+		return err
+	}
+	if err := ValidateStringNotEmpty(opts.Archive, "archive"); err != nil {
+		return err
+	}
+	if opts.GuestID != 0 && opts.GuestID < 100 {
+		return errors.New("guest id must be at least 100")
+	}
+	if opts.Force && opts.GuestID == 0 {
+		return errors.New("force requires the guest id of the guest to overwrite")
+	}
+	return nil
+}
+
+// Checks if the guest may be restored with the ID, an existing guest is only overwritten when forced
+// and has to be of the same type on the same node.
+func (opts RestoreOptions) validateExisting(guestType string, existing *VmRef) error {
+	if existing == nil {
+		return nil
+	}
+	if !opts.Force {
+		return fmt.Errorf("guest %d already exists, set force to overwrite it", existing.vmId)
+	}
+	if existing.vmType != guestType {
+		return fmt.Errorf("guest %d is of type %s and can not be overwritten by a %s backup", existing.vmId, existing.vmType, guestType)
+	}
+	if existing.node != opts.Node {
+		return fmt.Errorf("guest %d resides on node %s and can not be overwritten from node %s", existing.vmId, existing.node, opts.Node)
+	}
+	return nil
+}
+
+func (c *Client) restoreGuest(ctx context.Context, guestType string, opts RestoreOptions) (vmr *VmRef, upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = opts.Validate(); err != nil {
+		return
+	}
+	guestID := opts.GuestID
+	if guestID == 0 {
+		if guestID, err = c.GetNextID(ctx, 0); err != nil {
+			return
+		}
+	} else {
+		exists, err := c.VMIdExists(ctx, guestID)
+		if err != nil {
+			return nil, "", err
+		}
+		var existing *VmRef
+		if exists {
+			existing = NewVmRef(guestID)
+			if err = c.CheckVmRef(ctx, existing); err != nil {
+				return nil, "", err
+			}
+		}
+		if err = opts.validateExisting(guestType, existing); err != nil {
+			return nil, "", err
+		}
+	}
+	reqbody := ParamsToBody(opts.mapToApiValues(guestType, guestID))
+	resp, err := c.session.Post(ctx, "/nodes/"+opts.Node+"/"+guestType, nil, nil, &reqbody)
+	if err != nil {
+		return nil, "", fmt.Errorf("error restoring backup %s: %v, error status: %s", opts.Archive, err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	if err != nil {
+		return nil, upid, err
+	}
+	vmr = NewVmRef(guestID)
+	vmr.vmType = guestType
+	vmr.node = opts.Node
+	vmr.pool = opts.Pool
+	return
+}
+
+// RestoreVM creates a QEMU guest from the vzdump archive, or overwrites the existing guest when forced.
+// Returns the reference to the restored guest and the UPID of the restore task.
+func (c *Client) RestoreVM(ctx context.Context, opts RestoreOptions) (vmr *VmRef, upid string, err error) {
+	return c.restoreGuest(ctx, "qemu", opts)
+}
+
+// RestoreLxc creates an LXC container from the vzdump archive, or overwrites the existing container when forced.
+// Returns the reference to the restored container and the UPID of the restore task.
+func (c *Client) RestoreLxc(ctx context.Context, opts RestoreOptions) (vmr *VmRef, upid string, err error) {
+	return c.restoreGuest(ctx, "lxc", opts)
+}
@@ -0,0 +1,34 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RestoreOptions_mapToApiValues(t *testing.T) {
+	opts := RestoreOptions{Node: "pve", Archive: "local:backup/vzdump-qemu-100.vma.zst", Storage: "local-lvm", Unique: true, Force: true, BandwidthLimit: 1024}
+	require.Equal(t, "archive=local%3Abackup%2Fvzdump-qemu-100.vma.zst&bwlimit=1024&force=1&storage=local-lvm&unique=1&vmid=100",
+		ParamsToValues(opts.mapToApiValues("qemu", 100)).Encode())
+	opts = RestoreOptions{Node: "pve", Archive: "local:backup/vzdump-lxc-101.tar.zst", Start: true}
+	require.Equal(t, "ostemplate=local%3Abackup%2Fvzdump-lxc-101.tar.zst&restore=1&start=1&vmid=200",
+		ParamsToValues(opts.mapToApiValues("lxc", 200)).Encode())
+}
+
+func Test_RestoreOptions_Validate(t *testing.T) {
+	require.NoError(t, RestoreOptions{Node: "pve", Archive: "local:backup/a.vma"}.Validate())
+	require.NoError(t, RestoreOptions{Node: "pve", Archive: "local:backup/a.vma", GuestID: 100, Force: true}.Validate())
+	require.Error(t, RestoreOptions{Archive: "local:backup/a.vma"}.Validate())
+	require.Error(t, RestoreOptions{Node: "pve"}.Validate())
+	require.Error(t, RestoreOptions{Node: "pve", Archive: "local:backup/a.vma", GuestID: 99}.Validate())
+	require.Error(t, RestoreOptions{Node: "pve", Archive: "local:backup/a.vma", Force: true}.Validate())
+}
+
+func Test_RestoreOptions_validateExisting(t *testing.T) {
+	existing := &VmRef{vmId: 100, node: "pve", vmType: "qemu"}
+	require.NoError(t, RestoreOptions{Node: "pve"}.validateExisting("qemu", nil))
+	require.Error(t, RestoreOptions{Node: "pve"}.validateExisting("qemu", existing))
+	require.NoError(t, RestoreOptions{Node: "pve", Force: true}.validateExisting("qemu", existing))
+	require.Error(t, RestoreOptions{Node: "pve", Force: true}.validateExisting("lxc", existing))
+	require.Error(t, RestoreOptions{Node: "pve2", Force: true}.validateExisting("qemu", existing))
+}
@@ -0,0 +1,51 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BackupRetention(t *testing.T) {
+	require.Equal(t, "keep-all=1", BackupRetention{}.String())
+	retention := BackupRetention{Last: PointerUint(3), Weekly: PointerUint(2)}
+	require.Equal(t, "keep-last=3,keep-weekly=2", retention.String())
+	require.Equal(t, &retention, BackupRetention{}.mapToStruct("keep-last=3,keep-weekly=2"))
+	require.Equal(t, &BackupRetention{}, BackupRetention{}.mapToStruct("keep-all=1"))
+	require.NoError(t, retention.Validate())
+	require.Error(t, BackupRetention{Daily: PointerUint(0)}.Validate())
+}
+
+func Test_BackupOptions_mapToApiValues(t *testing.T) {
+	require.Equal(t, "compress=zstd&mailto=a%40example.com%2Cb%40example.com&mode=stop&notes-template=%7B%7Bguestname%7D%7D&protected=1&prune-backups=keep-last%3D1&remove=0&vmid=100%2C101",
+		ParamsToValues(BackupOptions{
+			GuestIDs:      []uint{100, 101},
+			Mode:          BackupMode_Stop,
+			Compress:      BackupCompression_Zstd,
+			NotesTemplate: "{{guestname}}",
+			Protected:     true,
+			Remove:        PointerBool(false),
+			Retention:     &BackupRetention{Last: PointerUint(1)},
+			MailTo:        []string{"a@example.com", "b@example.com"},
+		}.mapToApiValues()).Encode())
+	require.Equal(t, "vmid=100", ParamsToValues(BackupOptions{GuestIDs: []uint{100}}.mapToApiValues()).Encode())
+}
+
+func Test_BackupOptions_Validate(t *testing.T) {
+	require.NoError(t, BackupOptions{GuestIDs: []uint{100}, Mode: BackupMode_Snapshot, Compress: BackupCompression_Lzo}.Validate())
+	require.Error(t, BackupOptions{}.Validate())
+	require.Error(t, BackupOptions{GuestIDs: []uint{99}}.Validate())
+	require.Error(t, BackupOptions{GuestIDs: []uint{100}, Mode: "pause"}.Validate())
+	require.Error(t, BackupOptions{GuestIDs: []uint{100}, Compress: "xz"}.Validate())
+	require.Error(t, BackupOptions{GuestIDs: []uint{100}, Retention: &BackupRetention{Last: PointerUint(0)}}.Validate())
+}
+
+func Test_BackupOptions_validateStorage(t *testing.T) {
+	opts := BackupOptions{GuestIDs: []uint{100}, Storage: "store"}
+	require.NoError(t, opts.validateStorage(map[string]interface{}{"type": "dir", "content": "iso,backup"}))
+	require.Error(t, opts.validateStorage(map[string]interface{}{"type": "lvmthin", "content": "images,rootdir"}))
+	require.NoError(t, opts.validateStorage(map[string]interface{}{"type": "pbs", "content": "backup"}))
+	opts.Compress = BackupCompression_Zstd
+	require.NoError(t, opts.validateStorage(map[string]interface{}{"type": "nfs", "content": "backup"}))
+	require.Error(t, opts.validateStorage(map[string]interface{}{"type": "pbs", "content": "backup"}))
+}
@@ -0,0 +1,84 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type VmAction string
+
+const (
+	VmAction_Reset    VmAction = "reset"
+	VmAction_Resume   VmAction = "resume"
+	VmAction_Shutdown VmAction = "shutdown"
+	VmAction_Start    VmAction = "start"
+	VmAction_Stop     VmAction = "stop"
+	VmAction_Suspend  VmAction = "suspend"
+)
+
+func (action VmAction) Validate() error {
+	switch action {
+	case VmAction_Reset, VmAction_Resume, VmAction_Shutdown, VmAction_Start, VmAction_Stop, VmAction_Suspend:
+		return nil
+	}
+	return fmt.Errorf("vm action must be one of (%s,%s,%s,%s,%s,%s)", VmAction_Reset, VmAction_Resume, VmAction_Shutdown, VmAction_Start, VmAction_Stop, VmAction_Suspend)
+}
+
+// Outcome of the action on a single guest of BulkVmAction.
+type BulkVmResult struct {
+	// UPID of the task, empty when the task was never started.
+	Upid       string
+	ExitStatus string
+	Err        error
+}
+
+// BulkVmAction executes the action on all guests with at most concurrency actions running at the same time.
+// A failure of one guest does not abort the others, guests that are locked are skipped with an error.
+// The results are keyed by the ID of the guest.
+func (c *Client) BulkVmAction(ctx context.Context, vmrs []*VmRef, action VmAction, concurrency int) (map[int]BulkVmResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := action.Validate(); err != nil {
+		return nil, err
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results := make(map[int]BulkVmResult, len(vmrs))
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for _, vmr := range vmrs {
+		wg.Add(1)
+		go func(vmr *VmRef) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			result := c.vmActionTask(ctx, vmr, action)
+			mutex.Lock()
+			results[vmr.vmId] = result
+			mutex.Unlock()
+		}(vmr)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func (c *Client) vmActionTask(ctx context.Context, vmr *VmRef, action VmAction) (result BulkVmResult) {
+	if result.Err = ctx.Err(); result.Err != nil {
+		return
+	}
+	vmConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		result.Err = err
+		return
+	}
+	if lock, isSet := vmConfig["lock"]; isSet {
+		result.Err = fmt.Errorf("guest is locked (%v)", lock)
+		return
+	}
+	result.Upid, result.ExitStatus, result.Err = c.statusChangeVmTask(ctx, vmr, nil, string(action))
+	return
+}
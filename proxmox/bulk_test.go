@@ -0,0 +1,21 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VmAction_Validate(t *testing.T) {
+	for _, action := range []VmAction{VmAction_Reset, VmAction_Resume, VmAction_Shutdown, VmAction_Start, VmAction_Stop, VmAction_Suspend} {
+		require.NoError(t, action.Validate())
+	}
+	require.Error(t, VmAction("hibernate").Validate())
+	require.Error(t, VmAction("").Validate())
+}
+
+func Test_BulkVmAction_InvalidAction(t *testing.T) {
+	results, err := (&Client{}).BulkVmAction(nil, []*VmRef{NewVmRef(100)}, VmAction("reboot"), 2)
+	require.Error(t, err)
+	require.Nil(t, results)
+}
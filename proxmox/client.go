@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +26,12 @@ const TaskStatusCheckInterval = 2
 
 const exitStatusSuccess = "OK"
 
+const Error_GuestProtected string = "guest is protected, protection must be disabled before it can be deleted"
+
+const Error_GuestAgentNotRunning string = "guest agent is not running"
+
+const Error_GuestNotRunning string = "guest is not running"
+
 const (
 	requestNumberRetries = 3
 	nodes                = "nodes"
@@ -296,6 +303,34 @@ func (c *Client) GetVmConfig(ctx context.Context, vmr *VmRef) (vmConfig map[stri
 	return c.GetItemConfigMapStringInterface(ctx, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/config", "vm", "CONFIG")
 }
 
+// GetVmProtection returns true when the protection flag is set on the guest.
+func (c *Client) GetVmProtection(ctx context.Context, vmr *VmRef) (protected bool, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return
+	}
+	if _, isSet := vmConfig["protection"]; isSet {
+		protected = Itob(int(vmConfig["protection"].(float64)))
+	}
+	return
+}
+
+// SetVmProtection sets or clears the protection flag of the guest.
+// A protected guest and its disks can not be removed.
+func (c *Client) SetVmProtection(ctx context.Context, vmr *VmRef, protected bool) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	err = c.CheckVmRef(ctx, vmr)
+	if err != nil {
+		return
+	}
+	return c.Put(ctx, map[string]interface{}{"protection": protected}, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/config")
+}
+
 // GetStorage Get status for all datastores
 func (c *Client) GetStorage(ctx context.Context, nodeName string) (storageStatus []interface{}, err error) {
 	if ctx == nil {
@@ -391,6 +426,7 @@ func (a *AgentNetworkInterface) UnmarshalJSON(b []byte) error {
 			IPAddressType string `json:"ip-address-type"`
 			Prefix        int    `json:"prefix"`
 		} `json:"ip-addresses"`
+		MTU        int              `json:"mtu"`
 		Name       string           `json:"name"`
 		Statistics map[string]int64 `json:"statistics"`
 	}
@@ -407,11 +443,36 @@ func (a *AgentNetworkInterface) UnmarshalJSON(b []byte) error {
 		}
 	}
 	a.MACAddress = intermediate.HardwareAddress
+	a.MTU = intermediate.MTU
 	a.Name = intermediate.Name
 	a.Statistics = intermediate.Statistics
 	return nil
 }
 
+// IPv4Addresses returns the IPv4 addresses of the interface.
+func (a AgentNetworkInterface) IPv4Addresses() []net.IP {
+	ips := []net.IP{}
+	for _, ip := range a.IPAddresses {
+		if ip.To4() != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// IPv6Addresses returns the IPv6 addresses of the interface.
+func (a AgentNetworkInterface) IPv6Addresses() []net.IP {
+	ips := []net.IP{}
+	for _, ip := range a.IPAddresses {
+		if ip.To4() == nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// GetVmAgentNetworkInterfaces returns the network interfaces as reported by the guest agent.
+// Returns Error_GuestAgentNotRunning when the agent is not running or not enabled.
 func (c *Client) GetVmAgentNetworkInterfaces(ctx context.Context, vmr *VmRef) ([]AgentNetworkInterface, error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -421,25 +482,22 @@ func (c *Client) GetVmAgentNetworkInterfaces(ctx context.Context, vmr *VmRef) ([
 	return ifs, err
 }
 
-func (c *Client) doAgentGet(ctx context.Context, vmr *VmRef, command string, output interface{}) error {
+// GetVmAgentNetworkInterfacesWithTimeout is GetVmAgentNetworkInterfaces, giving up when the agent did not respond within the timeout.
+func (c *Client) GetVmAgentNetworkInterfacesWithTimeout(ctx context.Context, vmr *VmRef, timeout time.Duration) ([]AgentNetworkInterface, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	err := c.CheckVmRef(ctx, vmr)
-	if err != nil {
-		return err
-	}
-
-	url := fmt.Sprintf("/nodes/%s/%s/%d/agent/%s", vmr.node, vmr.vmType, vmr.vmId, command)
-	resp, err := c.session.Get(ctx, url, nil, nil)
-	if err != nil {
-		return err
-	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.GetVmAgentNetworkInterfaces(ctx, vmr)
+}
 
-	return TypedResponse(resp, output)
+// Proxmox reports in the status of the response that the guest agent is not running or not enabled.
+func agentNotRunning(resp *http.Response) bool {
+	return resp != nil && (strings.Contains(resp.Status, "agent is not running") || strings.Contains(resp.Status, "No QEMU guest agent configured"))
 }
 
-func (c *Client) CreateTemplate(ctx context.Context, vmr *VmRef) error {
+func (c *Client) doAgentGet(ctx context.Context, vmr *VmRef, command string, output interface{}) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -448,27 +506,22 @@ func (c *Client) CreateTemplate(ctx context.Context, vmr *VmRef) error {
 		return err
 	}
 
-	url := fmt.Sprintf("/nodes/%s/%s/%d/template", vmr.node, vmr.vmType, vmr.vmId)
-	resp, err := c.session.Post(ctx, url, nil, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	taskResponse, err := ResponseJSON(resp)
-	if err != nil {
-		return err
-	}
-
-	exitStatus, err := c.WaitForCompletion(ctx, taskResponse)
+	url := fmt.Sprintf("/nodes/%s/%s/%d/agent/%s", vmr.node, vmr.vmType, vmr.vmId, command)
+	resp, err := c.session.Get(ctx, url, nil, nil)
 	if err != nil {
+		if agentNotRunning(resp) {
+			return errors.New(Error_GuestAgentNotRunning)
+		}
 		return err
 	}
 
-	if exitStatus != "OK" {
-		return errors.New("Can't convert Vm to template:" + exitStatus)
-	}
+	return TypedResponse(resp, output)
+}
 
-	return nil
+// CreateTemplate converts the QEMU or LXC guest into a template, same as ConvertToTemplate with all disks.
+func (c *Client) CreateTemplate(ctx context.Context, vmr *VmRef) error {
+	_, err := c.ConvertToTemplate(ctx, vmr, "")
+	return err
 }
 
 func (c *Client) MonitorCmd(ctx context.Context, vmr *VmRef, command string) (monitorRes map[string]interface{}, err error) {
@@ -578,6 +631,36 @@ func (c *Client) StatusChangeVm(ctx context.Context, vmr *VmRef, params map[stri
 	return
 }
 
+// Starts the status change task without retrying, returns the task response holding the UPID.
+func (c *Client) startStatusChangeVm(ctx context.Context, vmr *VmRef, params map[string]interface{}, setStatus string) (taskResponse map[string]interface{}, exitStatus string, err error) {
+	err = c.CheckVmRef(ctx, vmr)
+	if err != nil {
+		return
+	}
+	url := fmt.Sprintf("/nodes/%s/%s/%d/status/%s", vmr.node, vmr.vmType, vmr.vmId, setStatus)
+	reqbody := ParamsToBody(params)
+	resp, err := c.session.Post(ctx, url, nil, nil, &reqbody)
+	if err != nil {
+		return nil, c.HandleTaskError(resp), err
+	}
+	taskResponse, err = ResponseJSON(resp)
+	return
+}
+
+// Same as StatusChangeVm, but returns the UPID of the task and does not retry.
+func (c *Client) statusChangeVmTask(ctx context.Context, vmr *VmRef, params map[string]interface{}, setStatus string) (upid, exitStatus string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	taskResponse, exitStatus, err := c.startStatusChangeVm(ctx, vmr, params, setStatus)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	exitStatus, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
+
 func (c *Client) StartVm(ctx context.Context, vmr *VmRef) (exitStatus string, err error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -630,6 +713,44 @@ func (c *Client) ResumeVm(ctx context.Context, vmr *VmRef) (exitStatus string, e
 	return c.StatusChangeVm(ctx, vmr, nil, "resume")
 }
 
+// RebootVm shuts the guest down and starts it again, unlike ResetVm the guest gets to shut down cleanly.
+// The guest is stopped when it did not shut down within timeout seconds, 0 uses the default of Proxmox.
+// Returns Error_GuestNotRunning when the guest is not running.
+func (c *Client) RebootVm(ctx context.Context, vmr *VmRef, timeout uint) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmState, err := c.GetVmState(ctx, vmr)
+	if err != nil {
+		return
+	}
+	if vmState["status"] != "running" {
+		return "", errors.New(Error_GuestNotRunning)
+	}
+	params := map[string]interface{}{}
+	if timeout != 0 {
+		params["timeout"] = timeout
+	}
+	upid, _, err = c.statusChangeVmTask(ctx, vmr, params, "reboot")
+	return
+}
+
+// RebootLxc is RebootVm for containers, the container is stopped when it did not shut down within timeout seconds.
+// Returns Error_GuestNotRunning when the container is stopped.
+func (c *Client) RebootLxc(ctx context.Context, vmr *VmRef, timeout uint) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	err = c.CheckVmRef(ctx, vmr)
+	if err != nil {
+		return
+	}
+	if vmr.vmType != "lxc" {
+		return "", fmt.Errorf("guest %d is not an LXC container", vmr.vmId)
+	}
+	return c.RebootVm(ctx, vmr, timeout)
+}
+
 func (c *Client) DeleteVm(ctx context.Context, vmr *VmRef) (exitStatus string, err error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -646,6 +767,15 @@ func (c *Client) DeleteVmParams(ctx context.Context, vmr *VmRef, params map[stri
 		return "", err
 	}
 
+	// Check protection first, otherwise the HA config would be removed before the delete fails
+	protected, err := c.GetVmProtection(ctx, vmr)
+	if err != nil {
+		return "", err
+	}
+	if protected {
+		return "", errors.New(Error_GuestProtected)
+	}
+
 	//Remove HA if required
 	if vmr.haState != "" {
 		url := fmt.Sprintf("/cluster/ha/resources/%d", vmr.vmId)
@@ -1516,9 +1646,70 @@ func (c *Client) DeleteQemuIPSetNetwork(ctx context.Context, vmr *VmRef, IPSetNa
 }
 
 func (c *Client) Upload(ctx context.Context, node string, storage string, contentType string, filename string, file io.Reader) error {
+	_, err := c.UploadFile(ctx, node, storage, contentType, filename, file, nil)
+	return err
+}
+
+var uploadChecksumAlgorithms = []string{"md5", "sha1", "sha224", "sha256", "sha384", "sha512"}
+
+// Optional settings of UploadFile.
+type UploadOptions struct {
+	// Proxmox verifies the uploaded file against the checksum, both Checksum and ChecksumAlgorithm have to be set.
+	Checksum          string
+	ChecksumAlgorithm string
+	// Called every time a part of the request has been sent, sent and total include the form fields around the file.
+	Progress func(sent, total int64)
+}
+
+func (opts UploadOptions) fields() map[string]string {
+	fields := map[string]string{}
+	if opts.Checksum != "" {
+		fields["checksum"] = opts.Checksum
+		fields["checksum-algorithm"] = opts.ChecksumAlgorithm
+	}
+	return fields
+}
+
+func (opts UploadOptions) Validate() error {
+	if (opts.Checksum == "") != (opts.ChecksumAlgorithm == "") {
+		return errors.New("checksum and checksum algorithm must be set together")
+	}
+	if opts.ChecksumAlgorithm != "" && !inArray(uploadChecksumAlgorithms, opts.ChecksumAlgorithm) {
+		return errors.New("checksum algorithm must be one of (" + strings.Join(uploadChecksumAlgorithms, ",") + ")")
+	}
+	return nil
+}
+
+// Reports the number of bytes read from the wrapped reader.
+type progressReader struct {
+	reader   io.Reader
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.progress(r.sent, r.total)
+	}
+	return n, err
+}
+
+// UploadFile uploads the ISO image or container template to the storage and waits until Proxmox moved it into place.
+// Files are streamed to Proxmox, other readers are buffered in memory first. opts may be nil.
+// Returns the UPID of the upload task.
+func (c *Client) UploadFile(ctx context.Context, node string, storage string, contentType string, filename string, file io.Reader, opts *UploadOptions) (upid string, err error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+	if err = opts.Validate(); err != nil {
+		return
+	}
 	var doStreamingIO bool
 	var fileSize int64
 	var contentLength int64
@@ -1527,22 +1718,28 @@ func (c *Client) Upload(ctx context.Context, node string, storage string, conten
 		doStreamingIO = true
 		fileInfo, err := f.Stat()
 		if err != nil {
-			return err
+			return "", err
 		}
 		fileSize = fileInfo.Size()
 	}
 
 	var body io.Reader
 	var mimetype string
-	var err error
 
 	if doStreamingIO {
-		body, mimetype, contentLength, err = createStreamedUploadBody(contentType, filename, fileSize, file)
+		body, mimetype, contentLength, err = createStreamedUploadBody(contentType, filename, opts.fields(), fileSize, file)
 	} else {
-		body, mimetype, err = createUploadBody(contentType, filename, file)
+		body, mimetype, err = createUploadBody(contentType, filename, opts.fields(), file)
 	}
 	if err != nil {
-		return err
+		return
+	}
+	if buf, isBuffer := body.(*bytes.Buffer); isBuffer {
+		contentLength = int64(buf.Len())
+	}
+	// wrap the body that is sent, wrapping a buffered file would only track the buffering
+	if opts.Progress != nil {
+		body = &progressReader{reader: body, total: contentLength, progress: opts.Progress}
 	}
 
 	url := fmt.Sprintf("%s/nodes/%s/storage/%s/upload", c.session.ApiUrl, node, storage)
@@ -1551,37 +1748,51 @@ func (c *Client) Upload(ctx context.Context, node string, storage string, conten
 	headers.Add("Accept", "application/json")
 	req, err := c.session.NewRequest(ctx, http.MethodPost, url, &headers, body)
 	if err != nil {
-		return err
+		return
 	}
 
-	if doStreamingIO {
-		req.ContentLength = contentLength
-	}
+	// the length is lost when the body is wrapped
+	req.ContentLength = contentLength
 
 	resp, err := c.session.Do(req)
 	if err != nil {
-		return err
+		return
 	}
 
 	taskResponse, err := ResponseJSON(resp)
 	if err != nil {
-		return err
+		return
 	}
-	exitStatus, err := c.WaitForCompletion(ctx, taskResponse)
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
+
+// Writes the form fields that precede the file, in a fixed order so the body is reproducible.
+func writeUploadFields(w *multipart.Writer, contentType string, fields map[string]string) error {
+	err := w.WriteField("content", contentType)
 	if err != nil {
 		return err
 	}
-	if exitStatus != exitStatusSuccess {
-		return fmt.Errorf("moving file to destination failed: %v", exitStatus)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		err = w.WriteField(k, fields[k])
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func createUploadBody(contentType string, filename string, r io.Reader) (io.Reader, string, error) {
+func createUploadBody(contentType string, filename string, fields map[string]string, r io.Reader) (io.Reader, string, error) {
 	var buf bytes.Buffer
 	w := multipart.NewWriter(&buf)
 
-	err := w.WriteField("content", contentType)
+	err := writeUploadFields(w, contentType, fields)
 	if err != nil {
 		return nil, "", err
 	}
@@ -1605,11 +1816,11 @@ func createUploadBody(contentType string, filename string, r io.Reader) (io.Read
 
 // createStreamedUploadBody - Use MultiReader to create the multipart body from the file reader,
 // avoiding allocation of large files in memory before upload (useful e.g. for Windows ISOs).
-func createStreamedUploadBody(contentType string, filename string, fileSize int64, r io.Reader) (io.Reader, string, int64, error) {
+func createStreamedUploadBody(contentType string, filename string, fields map[string]string, fileSize int64, r io.Reader) (io.Reader, string, int64, error) {
 	var buf bytes.Buffer
 	w := multipart.NewWriter(&buf)
 
-	err := w.WriteField("content", contentType)
+	err := writeUploadFields(w, contentType, fields)
 	if err != nil {
 		return nil, "", 0, err
 	}
@@ -2007,6 +2218,9 @@ func (c *Client) CheckMetricServerExistence(ctx context.Context, id string) (exi
 		ctx = context.Background()
 	}
 	list, err := c.GetMetricsServerList(ctx)
+	if err != nil {
+		return
+	}
 	existance = ItemInKeyOfArray(list["data"].([]interface{}), "id", id)
 	return
 }
@@ -0,0 +1,99 @@
+package proxmox
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test the parsing of the network interfaces reported by the guest agent
+func Test_AgentNetworkInterface_UnmarshalJSON(t *testing.T) {
+	input := `[{
+		"name": "eth0",
+		"hardware-address": "bc:24:11:2a:3b:4c",
+		"mtu": 9000,
+		"ip-addresses": [
+			{"ip-address": "10.0.0.5", "ip-address-type": "ipv4", "prefix": 24},
+			{"ip-address": "fe80::be24:11ff:fe2a:3b4c%eth0", "ip-address-type": "ipv6", "prefix": 64}
+		],
+		"statistics": {"rx-bytes": 1024, "rx-dropped": 0, "rx-errs": 0, "rx-packets": 8, "tx-bytes": 2048, "tx-dropped": 1, "tx-errs": 0, "tx-packets": 16}
+	}, {
+		"name": "lo",
+		"hardware-address": "00:00:00:00:00:00"
+	}]`
+	output := []AgentNetworkInterface{
+		{
+			MACAddress:  "bc:24:11:2a:3b:4c",
+			IPAddresses: []net.IP{net.ParseIP("10.0.0.5"), net.ParseIP("fe80::be24:11ff:fe2a:3b4c")},
+			MTU:         9000,
+			Name:        "eth0",
+			Statistics:  map[string]int64{"rx-bytes": 1024, "rx-dropped": 0, "rx-errs": 0, "rx-packets": 8, "tx-bytes": 2048, "tx-dropped": 1, "tx-errs": 0, "tx-packets": 16},
+		},
+		{
+			MACAddress:  "00:00:00:00:00:00",
+			IPAddresses: []net.IP{},
+			Name:        "lo",
+		},
+	}
+	var ifs []AgentNetworkInterface
+	require.NoError(t, json.Unmarshal([]byte(input), &ifs))
+	require.Equal(t, output, ifs)
+}
+
+func Test_AgentNetworkInterface_IPAddresses(t *testing.T) {
+	iface := AgentNetworkInterface{IPAddresses: []net.IP{net.ParseIP("10.0.0.5"), net.ParseIP("fe80::1"), net.ParseIP("192.168.1.2")}}
+	require.Equal(t, []net.IP{net.ParseIP("10.0.0.5"), net.ParseIP("192.168.1.2")}, iface.IPv4Addresses())
+	require.Equal(t, []net.IP{net.ParseIP("fe80::1")}, iface.IPv6Addresses())
+	require.Equal(t, []net.IP{}, AgentNetworkInterface{}.IPv4Addresses())
+}
+
+func Test_agentNotRunning(t *testing.T) {
+	require.True(t, agentNotRunning(&http.Response{Status: "500 QEMU guest agent is not running"}))
+	require.True(t, agentNotRunning(&http.Response{Status: "500 No QEMU guest agent configured"}))
+	require.False(t, agentNotRunning(&http.Response{Status: "500 Internal Server Error"}))
+	require.False(t, agentNotRunning(nil))
+}
+
+func Test_UploadOptions_Validate(t *testing.T) {
+	require.NoError(t, UploadOptions{}.Validate())
+	require.NoError(t, UploadOptions{Checksum: "abc", ChecksumAlgorithm: "sha256"}.Validate())
+	require.Error(t, UploadOptions{Checksum: "abc"}.Validate())
+	require.Error(t, UploadOptions{ChecksumAlgorithm: "sha256"}.Validate())
+	require.Error(t, UploadOptions{Checksum: "abc", ChecksumAlgorithm: "crc32"}.Validate())
+}
+
+func Test_progressReader(t *testing.T) {
+	var reported []int64
+	r := &progressReader{reader: strings.NewReader("0123456789"), total: 10, progress: func(sent, total int64) {
+		require.Equal(t, int64(10), total)
+		reported = append(reported, sent)
+	}}
+	buf := make([]byte, 4)
+	for {
+		if _, err := r.Read(buf); err == io.EOF {
+			break
+		}
+	}
+	require.Equal(t, []int64{4, 8, 10}, reported)
+}
+
+func Test_createUploadBody(t *testing.T) {
+	fields := UploadOptions{Checksum: "abc", ChecksumAlgorithm: "sha256"}.fields()
+	body, mimetype, err := createUploadBody("iso", "debian.iso", fields, strings.NewReader("data"))
+	require.NoError(t, err)
+	_, params, err := mime.ParseMediaType(mimetype)
+	require.NoError(t, err)
+	form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1024)
+	require.NoError(t, err)
+	require.Equal(t, []string{"iso"}, form.Value["content"])
+	require.Equal(t, []string{"abc"}, form.Value["checksum"])
+	require.Equal(t, []string{"sha256"}, form.Value["checksum-algorithm"])
+	require.Equal(t, "debian.iso", form.File["filename"][0].Filename)
+}
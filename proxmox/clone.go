@@ -0,0 +1,47 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+)
+
+// Checks that the source guest is of the guestType and returns if it is a template.
+func (c *Client) cloneSourceIsTemplate(ctx context.Context, source *VmRef, guestType string) (bool, error) {
+	sourceConfig, err := c.GetVmConfig(ctx, source)
+	if err != nil {
+		return false, err
+	}
+	if source.vmType != guestType {
+		if guestType == "lxc" {
+			return false, fmt.Errorf("guest %d is not an LXC container", source.vmId)
+		}
+		return false, fmt.Errorf("guest %d is not a qemu guest", source.vmId)
+	}
+	if _, isSet := sourceConfig["template"]; isSet {
+		return Itob(int(sourceConfig["template"].(float64))), nil
+	}
+	return false, nil
+}
+
+// Starts the clone task of the source guest without waiting for it.
+func (c *Client) startCloneTask(ctx context.Context, source *VmRef, params map[string]interface{}) (map[string]interface{}, error) {
+	reqbody := ParamsToBody(params)
+	url := fmt.Sprintf("/nodes/%s/%s/%d/clone", source.node, source.vmType, source.vmId)
+	resp, err := c.session.Post(ctx, url, nil, nil, &reqbody)
+	if err != nil {
+		return nil, fmt.Errorf("%v, error status: %s", err, c.HandleTaskError(resp))
+	}
+	return ResponseJSON(resp)
+}
+
+// Reference to the guest created by a clone, which resides on the target node when set.
+func cloneVmRef(newID int, guestType, node, target, pool string) *VmRef {
+	vmr := NewVmRef(newID)
+	vmr.vmType = guestType
+	vmr.node = node
+	if target != "" {
+		vmr.node = target
+	}
+	vmr.pool = pool
+	return vmr
+}
@@ -0,0 +1,117 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type CloudInitSection string
+
+const (
+	CloudInitSection_User    CloudInitSection = "user"
+	CloudInitSection_Network CloudInitSection = "network"
+	CloudInitSection_Meta    CloudInitSection = "meta"
+)
+
+func (section CloudInitSection) Validate() error {
+	switch section {
+	case CloudInitSection_User, CloudInitSection_Network, CloudInitSection_Meta:
+		return nil
+	}
+	return errors.New("cloud-init section must be one of (user,network,meta)")
+}
+
+// Returns the disk holding the cloud-init drive (e.g. "ide2"), empty when the guest has none.
+func cloudInitDrive(vmConfig map[string]interface{}) string {
+	for key, value := range vmConfig {
+		if !rxDiskName.MatchString(key) {
+			continue
+		}
+		setting, _ := value.(string)
+		volume, _, _ := strings.Cut(setting, ",")
+		if strings.Contains(volume, "cloudinit") {
+			return key
+		}
+	}
+	return ""
+}
+
+// Settings that only apply to the cloud-init drive.
+var cloudInitSettings = []string{"cicustom", "cipassword", "citype", "ciupgrade", "ciuser", "nameserver", "searchdomain", "sshkeys"}
+
+// Returns the cloud-init drive followed by the cloud-init settings that are set, empty when there is no drive.
+func cloudInitDeleteKeys(vmConfig map[string]interface{}) []string {
+	drive := cloudInitDrive(vmConfig)
+	if drive == "" {
+		return nil
+	}
+	keys := []string{}
+	for key := range vmConfig {
+		if inArray(cloudInitSettings, key) || rxIpconfigName.MatchString(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return append([]string{drive}, keys...)
+}
+
+// RemoveCloudInitDrive removes the cloud-init drive of the virtual machine and clears the cloud-init settings,
+// e.g. before converting it into a template. Does nothing when the virtual machine has no cloud-init drive.
+func (c *Client) RemoveCloudInitDrive(ctx context.Context, vmr *VmRef) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return err
+	}
+	if vmr.vmType != "qemu" {
+		return fmt.Errorf("guest %d is not a virtual machine", vmr.vmId)
+	}
+	keys := cloudInitDeleteKeys(vmConfig)
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err = c.SetVmConfig(ctx, vmr, map[string]interface{}{"delete": strings.Join(keys, ",")})
+	return err
+}
+
+// RegenerateCloudInit rebuilds the cloud-init drive of the virtual machine from its current cloud-init settings,
+// so changes to them take effect on the next boot. Requires Proxmox VE 7.2 or newer.
+func (c *Client) RegenerateCloudInit(ctx context.Context, vmr *VmRef) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return err
+	}
+	if vmr.vmType != "qemu" {
+		return fmt.Errorf("guest %d is not a virtual machine", vmr.vmId)
+	}
+	if cloudInitDrive(vmConfig) == "" {
+		return fmt.Errorf("guest %d has no cloud-init drive", vmr.vmId)
+	}
+	return c.Put(ctx, nil, fmt.Sprintf("/nodes/%s/qemu/%d/cloudinit", vmr.node, vmr.vmId))
+}
+
+// DumpCloudInit returns the cloud-init data of the section as Proxmox renders it for the virtual machine,
+// e.g. the user-data holding the user and ssh keys or the network-config generated from the ipconfig settings.
+func (c *Client) DumpCloudInit(ctx context.Context, vmr *VmRef, section CloudInitSection) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := section.Validate(); err != nil {
+		return "", err
+	}
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return "", err
+	}
+	if vmr.vmType != "qemu" {
+		return "", fmt.Errorf("guest %d is not a virtual machine", vmr.vmId)
+	}
+	return c.GetItemConfigString(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/cloudinit/dump?type=%s", vmr.node, vmr.vmId, section), "cloud-init", string(section))
+}
@@ -0,0 +1,44 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CloudInitSection_Validate(t *testing.T) {
+	require.NoError(t, CloudInitSection_User.Validate())
+	require.NoError(t, CloudInitSection_Network.Validate())
+	require.NoError(t, CloudInitSection_Meta.Validate())
+	require.Error(t, CloudInitSection("vendor").Validate())
+	require.Error(t, CloudInitSection("").Validate())
+}
+
+func Test_cloudInitDrive(t *testing.T) {
+	require.Equal(t, "ide2", cloudInitDrive(map[string]interface{}{
+		"scsi0": "local-lvm:vm-100-disk-0,size=8G",
+		"ide2":  "local-lvm:vm-100-cloudinit,media=cdrom",
+		"name":  "cloudinit",
+	}))
+	require.Equal(t, "", cloudInitDrive(map[string]interface{}{
+		"ide2": "local:iso/debian.iso,media=cdrom",
+		"name": "cloudinit",
+	}))
+}
+
+func Test_cloudInitDeleteKeys(t *testing.T) {
+	require.Equal(t, []string{"ide2", "ciuser", "ipconfig0", "ipconfig1", "nameserver", "sshkeys"}, cloudInitDeleteKeys(map[string]interface{}{
+		"scsi0":      "local-lvm:vm-100-disk-0,size=8G",
+		"ide2":       "local-lvm:vm-100-cloudinit,media=cdrom",
+		"ciuser":     "debian",
+		"sshkeys":    "ssh-ed25519%20AAAA",
+		"nameserver": "1.1.1.1",
+		"ipconfig1":  "ip=dhcp",
+		"ipconfig0":  "ip=dhcp",
+		"name":       "cloudinit",
+	}))
+	require.Nil(t, cloudInitDeleteKeys(map[string]interface{}{
+		"ide2":   "local:iso/debian.iso,media=cdrom",
+		"ciuser": "debian",
+	}))
+}
@@ -0,0 +1,219 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Resource kinds accepted by the type filter of /cluster/resources.
+type ClusterResourceType string
+
+const (
+	ClusterResourceType_All     ClusterResourceType = ""
+	ClusterResourceType_Node    ClusterResourceType = "node"
+	ClusterResourceType_Sdn     ClusterResourceType = "sdn"
+	ClusterResourceType_Storage ClusterResourceType = "storage"
+	ClusterResourceType_Vm      ClusterResourceType = "vm"
+)
+
+func (resourceType ClusterResourceType) Validate() error {
+	switch resourceType {
+	case ClusterResourceType_All, ClusterResourceType_Node, ClusterResourceType_Sdn, ClusterResourceType_Storage, ClusterResourceType_Vm:
+		return nil
+	}
+	return errors.New("cluster resource type must be one of (node,sdn,storage,vm) or empty")
+}
+
+// Fields shared by all kinds of cluster resources, sizes are in bytes and uptime in seconds.
+type ClusterResource struct {
+	ID      string  `json:"id"`
+	Node    string  `json:"node,omitempty"`
+	Status  string  `json:"status,omitempty"`
+	CPU     float64 `json:"cpu,omitempty"`
+	MaxCPU  uint    `json:"maxcpu,omitempty"`
+	Mem     uint64  `json:"mem,omitempty"`
+	MaxMem  uint64  `json:"maxmem,omitempty"`
+	Disk    uint64  `json:"disk,omitempty"`
+	MaxDisk uint64  `json:"maxdisk,omitempty"`
+	Uptime  uint64  `json:"uptime,omitempty"`
+}
+
+func (ClusterResource) mapToStruct(params map[string]interface{}) ClusterResource {
+	resource := ClusterResource{}
+	if _, isSet := params["id"]; isSet {
+		resource.ID = params["id"].(string)
+	}
+	if _, isSet := params["node"]; isSet {
+		resource.Node = params["node"].(string)
+	}
+	if _, isSet := params["status"]; isSet {
+		resource.Status = params["status"].(string)
+	}
+	if _, isSet := params["cpu"]; isSet {
+		resource.CPU = params["cpu"].(float64)
+	}
+	if _, isSet := params["maxcpu"]; isSet {
+		resource.MaxCPU = uint(params["maxcpu"].(float64))
+	}
+	if _, isSet := params["mem"]; isSet {
+		resource.Mem = uint64(params["mem"].(float64))
+	}
+	if _, isSet := params["maxmem"]; isSet {
+		resource.MaxMem = uint64(params["maxmem"].(float64))
+	}
+	if _, isSet := params["disk"]; isSet {
+		resource.Disk = uint64(params["disk"].(float64))
+	}
+	if _, isSet := params["maxdisk"]; isSet {
+		resource.MaxDisk = uint64(params["maxdisk"].(float64))
+	}
+	if _, isSet := params["uptime"]; isSet {
+		resource.Uptime = uint64(params["uptime"].(float64))
+	}
+	return resource
+}
+
+// QEMU virtual machine or LXC container in the cluster.
+type ClusterResourceGuest struct {
+	ClusterResource
+	VmID     uint     `json:"vmid"`
+	Name     string   `json:"name,omitempty"`
+	Pool     string   `json:"pool,omitempty"`
+	Tags     []string `json:"tags"`
+	Template bool     `json:"template,omitempty"`
+	// "qemu" or "lxc"
+	Type    string `json:"type"`
+	HaState string `json:"hastate,omitempty"`
+}
+
+func (ClusterResourceGuest) mapToStruct(params map[string]interface{}) ClusterResourceGuest {
+	guest := ClusterResourceGuest{ClusterResource: ClusterResource{}.mapToStruct(params), Tags: []string{}}
+	if _, isSet := params["vmid"]; isSet {
+		guest.VmID = uint(params["vmid"].(float64))
+	}
+	if _, isSet := params["name"]; isSet {
+		guest.Name = params["name"].(string)
+	}
+	if _, isSet := params["pool"]; isSet {
+		guest.Pool = params["pool"].(string)
+	}
+	if _, isSet := params["tags"]; isSet {
+		guest.Tags = parseTags(params["tags"].(string))
+	}
+	if _, isSet := params["template"]; isSet {
+		guest.Template = Itob(int(params["template"].(float64)))
+	}
+	if _, isSet := params["type"]; isSet {
+		guest.Type = params["type"].(string)
+	}
+	if _, isSet := params["hastate"]; isSet {
+		guest.HaState = params["hastate"].(string)
+	}
+	return guest
+}
+
+// Storage as seen by a single node, shared storages are listed once per node.
+type ClusterResourceStorage struct {
+	ClusterResource
+	Storage    string        `json:"storage"`
+	PluginType string        `json:"plugintype,omitempty"`
+	Shared     bool          `json:"shared,omitempty"`
+	Content    []ContentType `json:"content"`
+}
+
+func (ClusterResourceStorage) mapToStruct(params map[string]interface{}) ClusterResourceStorage {
+	storage := ClusterResourceStorage{ClusterResource: ClusterResource{}.mapToStruct(params), Content: []ContentType{}}
+	if _, isSet := params["storage"]; isSet {
+		storage.Storage = params["storage"].(string)
+	}
+	if _, isSet := params["plugintype"]; isSet {
+		storage.PluginType = params["plugintype"].(string)
+	}
+	if _, isSet := params["shared"]; isSet {
+		storage.Shared = Itob(int(params["shared"].(float64)))
+	}
+	if content, isSet := params["content"]; isSet && content != "" {
+		for _, e := range strings.Split(content.(string), ",") {
+			storage.Content = append(storage.Content, contentTypeFromApiValue(e))
+		}
+	}
+	return storage
+}
+
+type ClusterResourceNode struct {
+	ClusterResource
+	// Subscription level of the node, empty without a subscription.
+	Level string `json:"level,omitempty"`
+}
+
+func (ClusterResourceNode) mapToStruct(params map[string]interface{}) ClusterResourceNode {
+	node := ClusterResourceNode{ClusterResource: ClusterResource{}.mapToStruct(params)}
+	if _, isSet := params["level"]; isSet {
+		node.Level = params["level"].(string)
+	}
+	return node
+}
+
+// SDN zone as seen by a single node.
+type ClusterResourceSdn struct {
+	ClusterResource
+	Sdn string `json:"sdn"`
+}
+
+func (ClusterResourceSdn) mapToStruct(params map[string]interface{}) ClusterResourceSdn {
+	sdn := ClusterResourceSdn{ClusterResource: ClusterResource{}.mapToStruct(params)}
+	if _, isSet := params["sdn"]; isSet {
+		sdn.Sdn = params["sdn"].(string)
+	}
+	return sdn
+}
+
+// All resources of the cluster grouped by kind, resources of unknown kinds (e.g. pools) are left out.
+type ClusterResources struct {
+	Guests   []ClusterResourceGuest   `json:"guests"`
+	Nodes    []ClusterResourceNode    `json:"nodes"`
+	Sdns     []ClusterResourceSdn     `json:"sdns"`
+	Storages []ClusterResourceStorage `json:"storages"`
+}
+
+func (ClusterResources) mapToStruct(resources []interface{}) *ClusterResources {
+	result := ClusterResources{
+		Guests:   []ClusterResourceGuest{},
+		Nodes:    []ClusterResourceNode{},
+		Sdns:     []ClusterResourceSdn{},
+		Storages: []ClusterResourceStorage{},
+	}
+	for _, e := range resources {
+		params := e.(map[string]interface{})
+		resourceType, _ := params["type"].(string)
+		switch resourceType {
+		case "qemu", "lxc":
+			result.Guests = append(result.Guests, ClusterResourceGuest{}.mapToStruct(params))
+		case "node":
+			result.Nodes = append(result.Nodes, ClusterResourceNode{}.mapToStruct(params))
+		case "sdn":
+			result.Sdns = append(result.Sdns, ClusterResourceSdn{}.mapToStruct(params))
+		case "storage":
+			result.Storages = append(result.Storages, ClusterResourceStorage{}.mapToStruct(params))
+		}
+	}
+	return &result
+}
+
+// GetClusterResources returns the typed resources of the cluster.
+// When resourceType is set only resources of that kind are requested, which keeps the response small on large clusters.
+func (c *Client) GetClusterResources(ctx context.Context, resourceType ClusterResourceType) (*ClusterResources, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := resourceType.Validate(); err != nil {
+		return nil, err
+	}
+	list, err := c.GetResourceList(ctx, string(resourceType))
+	if err != nil {
+		return nil, err
+	}
+	resources, _ := list["data"].([]interface{})
+	return ClusterResources{}.mapToStruct(resources), nil
+}
@@ -0,0 +1,94 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClusterResourceType_Validate(t *testing.T) {
+	for _, e := range []ClusterResourceType{ClusterResourceType_All, ClusterResourceType_Node, ClusterResourceType_Sdn, ClusterResourceType_Storage, ClusterResourceType_Vm} {
+		require.NoError(t, e.Validate(), string(e))
+	}
+	require.Error(t, ClusterResourceType("qemu").Validate())
+}
+
+func Test_ClusterResources_mapToStruct(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"id":       "qemu/100",
+			"type":     "qemu",
+			"node":     "pve1",
+			"status":   "running",
+			"vmid":     float64(100),
+			"name":     "web",
+			"pool":     "prod",
+			"tags":     "a;b",
+			"template": float64(0),
+			"cpu":      float64(0.5),
+			"maxcpu":   float64(2),
+			"mem":      float64(1024),
+			"maxmem":   float64(2048),
+			"uptime":   float64(60),
+			"hastate":  "started",
+		},
+		map[string]interface{}{
+			"id":         "storage/pve1/local",
+			"type":       "storage",
+			"node":       "pve1",
+			"status":     "available",
+			"storage":    "local",
+			"plugintype": "dir",
+			"shared":     float64(0),
+			"content":    "iso,vztmpl",
+			"disk":       float64(10),
+			"maxdisk":    float64(100),
+		},
+		map[string]interface{}{
+			"id":     "node/pve1",
+			"type":   "node",
+			"node":   "pve1",
+			"status": "online",
+			"level":  "c",
+			"maxcpu": float64(8),
+		},
+		map[string]interface{}{
+			"id":     "sdn/pve1/localnetwork",
+			"type":   "sdn",
+			"node":   "pve1",
+			"status": "ok",
+			"sdn":    "localnetwork",
+		},
+		map[string]interface{}{
+			"id":     "pool/prod",
+			"type":   "pool",
+			"poolid": "prod",
+		},
+	}
+	output := &ClusterResources{
+		Guests: []ClusterResourceGuest{{
+			ClusterResource: ClusterResource{ID: "qemu/100", Node: "pve1", Status: "running", CPU: 0.5, MaxCPU: 2, Mem: 1024, MaxMem: 2048, Uptime: 60},
+			VmID:            100,
+			Name:            "web",
+			Pool:            "prod",
+			Tags:            []string{"a", "b"},
+			Type:            "qemu",
+			HaState:         "started",
+		}},
+		Nodes: []ClusterResourceNode{{
+			ClusterResource: ClusterResource{ID: "node/pve1", Node: "pve1", Status: "online", MaxCPU: 8},
+			Level:           "c",
+		}},
+		Sdns: []ClusterResourceSdn{{
+			ClusterResource: ClusterResource{ID: "sdn/pve1/localnetwork", Node: "pve1", Status: "ok"},
+			Sdn:             "localnetwork",
+		}},
+		Storages: []ClusterResourceStorage{{
+			ClusterResource: ClusterResource{ID: "storage/pve1/local", Node: "pve1", Status: "available", Disk: 10, MaxDisk: 100},
+			Storage:         "local",
+			PluginType:      "dir",
+			Content:         []ContentType{ContentType_Iso, ContentType_Template},
+		}},
+	}
+	require.Equal(t, output, ClusterResources{}.mapToStruct(input))
+}
@@ -0,0 +1,115 @@
+package proxmox
+
+import (
+	"context"
+)
+
+// Membership of a node as reported by /cluster/status.
+type ClusterNodeStatus struct {
+	Name   string `json:"name"`
+	ID     uint   `json:"nodeid"`
+	IP     string `json:"ip"`
+	Online bool   `json:"online"`
+	// The node the request was handled by.
+	Local bool `json:"local"`
+	// Subscription level of the node, empty without subscription.
+	Level string `json:"level,omitempty"`
+}
+
+func (ClusterNodeStatus) mapToStruct(params map[string]interface{}) ClusterNodeStatus {
+	node := ClusterNodeStatus{}
+	if _, isSet := params["name"]; isSet {
+		node.Name = params["name"].(string)
+	}
+	if _, isSet := params["nodeid"]; isSet {
+		node.ID = uint(params["nodeid"].(float64))
+	}
+	if _, isSet := params["ip"]; isSet {
+		node.IP = params["ip"].(string)
+	}
+	if _, isSet := params["online"]; isSet {
+		node.Online = Itob(int(params["online"].(float64)))
+	}
+	if _, isSet := params["local"]; isSet {
+		node.Local = Itob(int(params["local"].(float64)))
+	}
+	if _, isSet := params["level"]; isSet {
+		node.Level = params["level"].(string)
+	}
+	return node
+}
+
+type ClusterStatus struct {
+	// Name of the cluster, empty when the node is not part of a cluster.
+	Name string `json:"name,omitempty"`
+	// Version of the cluster configuration.
+	Version uint `json:"version,omitempty"`
+	// Number of nodes in the cluster configuration.
+	NodeCount uint `json:"nodecount"`
+	// A standalone node is always quorate.
+	Quorate bool                `json:"quorate"`
+	Nodes   []ClusterNodeStatus `json:"nodes"`
+}
+
+func (ClusterStatus) mapToStruct(list []interface{}) *ClusterStatus {
+	status := ClusterStatus{Quorate: true}
+	clustered := false
+	for _, e := range list {
+		params := e.(map[string]interface{})
+		switch params["type"] {
+		case "cluster":
+			clustered = true
+			if _, isSet := params["name"]; isSet {
+				status.Name = params["name"].(string)
+			}
+			if _, isSet := params["version"]; isSet {
+				status.Version = uint(params["version"].(float64))
+			}
+			if _, isSet := params["nodes"]; isSet {
+				status.NodeCount = uint(params["nodes"].(float64))
+			}
+			if _, isSet := params["quorate"]; isSet {
+				status.Quorate = Itob(int(params["quorate"].(float64)))
+			}
+		case "node":
+			status.Nodes = append(status.Nodes, ClusterNodeStatus{}.mapToStruct(params))
+		}
+	}
+	if !clustered {
+		status.NodeCount = uint(len(status.Nodes))
+	}
+	return &status
+}
+
+// Returns the node the request was handled by, nil when it is not listed.
+func (status ClusterStatus) LocalNode() *ClusterNodeStatus {
+	for i := range status.Nodes {
+		if status.Nodes[i].Local {
+			return &status.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// Returns the nodes that are not online.
+func (status ClusterStatus) OfflineNodes() []ClusterNodeStatus {
+	offline := make([]ClusterNodeStatus, 0)
+	for _, node := range status.Nodes {
+		if !node.Online {
+			offline = append(offline, node)
+		}
+	}
+	return offline
+}
+
+// GetClusterStatus returns the quorum state of the cluster and the membership of its nodes.
+func (c *Client) GetClusterStatus(ctx context.Context) (*ClusterStatus, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/cluster/status")
+	if err != nil {
+		return nil, err
+	}
+	return ClusterStatus{}.mapToStruct(list), nil
+}
@@ -0,0 +1,30 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClusterStatus_mapToStruct(t *testing.T) {
+	status := ClusterStatus{}.mapToStruct([]interface{}{
+		map[string]interface{}{"type": "cluster", "id": "cluster", "name": "lab", "version": float64(5), "nodes": float64(3), "quorate": float64(0)},
+		map[string]interface{}{"type": "node", "id": "node/pve1", "name": "pve1", "nodeid": float64(1), "ip": "10.0.0.1", "online": float64(1), "local": float64(1), "level": "c"},
+		map[string]interface{}{"type": "node", "id": "node/pve2", "name": "pve2", "nodeid": float64(2), "ip": "10.0.0.2", "online": float64(0), "local": float64(0), "level": ""},
+	})
+	require.Equal(t, "lab", status.Name)
+	require.Equal(t, uint(5), status.Version)
+	require.Equal(t, uint(3), status.NodeCount)
+	require.False(t, status.Quorate)
+	require.Len(t, status.Nodes, 2)
+	require.Equal(t, ClusterNodeStatus{Name: "pve1", ID: 1, IP: "10.0.0.1", Online: true, Local: true, Level: "c"}, *status.LocalNode())
+	require.Equal(t, []ClusterNodeStatus{{Name: "pve2", ID: 2, IP: "10.0.0.2"}}, status.OfflineNodes())
+
+	standalone := ClusterStatus{}.mapToStruct([]interface{}{
+		map[string]interface{}{"type": "node", "name": "pve", "online": float64(1), "local": float64(1)},
+	})
+	require.True(t, standalone.Quorate)
+	require.Empty(t, standalone.Name)
+	require.Equal(t, uint(1), standalone.NodeCount)
+	require.Empty(t, standalone.OfflineNodes())
+}
@@ -0,0 +1,145 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Grants the role on the path to exactly one of User, Group or Token.
+type ACLEntry struct {
+	// e.g. "/", "/vms/100" or "/pool/prod"
+	Path  string    `json:"path"`
+	Role  RoleName  `json:"role"`
+	User  *UserID   `json:"user,omitempty"`
+	Group GroupName `json:"group,omitempty"`
+	// API token in the format username@realm!tokenid
+	Token string `json:"token,omitempty"`
+	// The role also applies to everything below the path.
+	Propagate bool `json:"propagate"`
+}
+
+func (entry ACLEntry) mapToApiValues(delete bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"path":      entry.Path,
+		"roles":     string(entry.Role),
+		"propagate": entry.Propagate,
+	}
+	if entry.User != nil {
+		params["users"] = entry.User.ToString()
+	}
+	if entry.Group != "" {
+		params["groups"] = string(entry.Group)
+	}
+	if entry.Token != "" {
+		params["tokens"] = entry.Token
+	}
+	if delete {
+		params["delete"] = true
+	}
+	return params
+}
+
+func (ACLEntry) mapToStruct(params map[string]interface{}) *ACLEntry {
+	entry := ACLEntry{}
+	if _, isSet := params["path"]; isSet {
+		entry.Path = params["path"].(string)
+	}
+	if _, isSet := params["roleid"]; isSet {
+		entry.Role = RoleName(params["roleid"].(string))
+	}
+	if _, isSet := params["propagate"]; isSet {
+		entry.Propagate = Itob(int(params["propagate"].(float64)))
+	}
+	if _, isSet := params["ugid"]; isSet {
+		ugid := params["ugid"].(string)
+		switch params["type"] {
+		case "user":
+			user := UserID{}.mapToStruct(ugid)
+			entry.User = &user
+		case "group":
+			entry.Group = GroupName(ugid)
+		case "token":
+			entry.Token = ugid
+		}
+	}
+	return &entry
+}
+
+func (entry ACLEntry) Validate() error {
+	if !strings.HasPrefix(entry.Path, "/") {
+		return fmt.Errorf("acl path (%s) must start with /", entry.Path)
+	}
+	if err := entry.Role.Validate(); err != nil {
+		return err
+	}
+	set := 0
+	if entry.User != nil {
+		if err := entry.User.Validate(); err != nil {
+			return err
+		}
+		set++
+	}
+	if entry.Group != "" {
+		if err := entry.Group.Validate(); err != nil {
+			return err
+		}
+		set++
+	}
+	if entry.Token != "" {
+		user, tokenID, _ := strings.Cut(entry.Token, "!")
+		if _, err := NewUserID(user); err != nil || tokenID == "" {
+			return errors.New("acl token must be in the format username@realm!tokenid")
+		}
+		set++
+	}
+	if set != 1 {
+		return errors.New("acl entry must have exactly one of user, group or token")
+	}
+	return nil
+}
+
+// GetACL returns all ACL entries of the cluster.
+func (c *Client) GetACL(ctx context.Context) ([]ACLEntry, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/access/acl")
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]ACLEntry, len(list))
+	for i, e := range list {
+		entries[i] = *ACLEntry{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return entries, nil
+}
+
+// SetACL adds the entries, existing entries of the cluster are kept.
+// All entries are validated before the first one is added.
+func (c *Client) SetACL(ctx context.Context, entries []ACLEntry) error {
+	return c.updateACL(ctx, entries, false)
+}
+
+// DeleteACL removes the entries, entries that do not exist are ignored by proxmox.
+func (c *Client) DeleteACL(ctx context.Context, entries []ACLEntry) error {
+	return c.updateACL(ctx, entries, true)
+}
+
+func (c *Client) updateACL(ctx context.Context, entries []ACLEntry, delete bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, e := range entries {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, e := range entries {
+		if err := c.Put(ctx, e.mapToApiValues(delete), "/access/acl"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ACLEntry_mapToApiValues(t *testing.T) {
+	entry := ACLEntry{Path: "/vms/100", Role: "PVEVMUser", User: &UserID{Name: "user", Realm: "pve"}, Propagate: true}
+	require.Equal(t, map[string]interface{}{
+		"path":      "/vms/100",
+		"roles":     "PVEVMUser",
+		"users":     "user@pve",
+		"propagate": true,
+	}, entry.mapToApiValues(false))
+	require.Equal(t, map[string]interface{}{
+		"path":      "/pool/prod",
+		"roles":     "PVEAdmin",
+		"groups":    "admins",
+		"propagate": false,
+		"delete":    true,
+	}, ACLEntry{Path: "/pool/prod", Role: "PVEAdmin", Group: "admins"}.mapToApiValues(true))
+}
+
+func Test_ACLEntry_mapToStruct(t *testing.T) {
+	testData := []struct {
+		input  map[string]interface{}
+		output *ACLEntry
+	}{
+		{
+			input:  map[string]interface{}{"path": "/", "roleid": "Administrator", "type": "user", "ugid": "root@pam", "propagate": float64(1)},
+			output: &ACLEntry{Path: "/", Role: "Administrator", User: &UserID{Name: "root", Realm: "pam"}, Propagate: true},
+		},
+		{
+			input:  map[string]interface{}{"path": "/vms", "roleid": "PVEAuditor", "type": "group", "ugid": "auditors", "propagate": float64(0)},
+			output: &ACLEntry{Path: "/vms", Role: "PVEAuditor", Group: "auditors"},
+		},
+		{
+			input:  map[string]interface{}{"path": "/storage", "roleid": "PVEDatastoreUser", "type": "token", "ugid": "ci@pve!deploy", "propagate": float64(1)},
+			output: &ACLEntry{Path: "/storage", Role: "PVEDatastoreUser", Token: "ci@pve!deploy", Propagate: true},
+		},
+	}
+	for _, e := range testData {
+		require.Equal(t, e.output, ACLEntry{}.mapToStruct(e.input))
+	}
+}
+
+func Test_ACLEntry_Validate(t *testing.T) {
+	user := &UserID{Name: "user", Realm: "pve"}
+	testData := []struct {
+		input ACLEntry
+		err   bool
+	}{
+		{input: ACLEntry{Path: "/", Role: "PVEAuditor", User: user}},
+		{input: ACLEntry{Path: "/vms/100", Role: "PVEAuditor", Group: "auditors"}},
+		{input: ACLEntry{Path: "/vms/100", Role: "PVEAuditor", Token: "user@pve!ci"}},
+		{input: ACLEntry{Path: "vms/100", Role: "PVEAuditor", User: user}, err: true},
+		{input: ACLEntry{Path: "/", User: user}, err: true},
+		{input: ACLEntry{Path: "/", Role: "PVEAuditor"}, err: true},
+		{input: ACLEntry{Path: "/", Role: "PVEAuditor", User: user, Group: "auditors"}, err: true},
+		{input: ACLEntry{Path: "/", Role: "PVEAuditor", User: &UserID{Name: "user"}}, err: true},
+		{input: ACLEntry{Path: "/", Role: "PVEAuditor", Token: "user@pve"}, err: true},
+		{input: ACLEntry{Path: "/", Role: "PVEAuditor", Token: "user!ci"}, err: true},
+	}
+	for _, e := range testData {
+		if e.err {
+			require.Error(t, e.input.Validate(), e.input)
+		} else {
+			require.NoError(t, e.input.Validate(), e.input)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Characters allowed in a systemd like calendar event, e.g. "daily", "sat 02:00" or "mon..fri 21:00".
+var rxBackupSchedule = regexp.MustCompile(`^[a-zA-Z0-9*:,./~ -]+$`)
+
+const backupJobUrl = "/cluster/backup"
+
+// Keys of the optional settings of a backup job, in the order they are removed in.
+var backupJobOptionals = []string{"comment", "compress", "exclude", "mailnotification", "mailto", "mode", "node", "notes-template", "pool", "prune-backups", "storage", "vmid"}
+
+// Guests a backup job applies to, exactly one of All, Pool or GuestIDs has to be set.
+type BackupJobSelection struct {
+	All bool `json:"all,omitempty"`
+	// Guests left out when All is set.
+	Exclude  []uint `json:"exclude,omitempty"`
+	Pool     string `json:"pool,omitempty"`
+	GuestIDs []uint `json:"vmid,omitempty"`
+}
+
+func (selection BackupJobSelection) Validate() error {
+	modes := 0
+	if selection.All {
+		modes++
+	}
+	if selection.Pool != "" {
+		modes++
+	}
+	if len(selection.GuestIDs) > 0 {
+		modes++
+	}
+	if modes != 1 {
+		return errors.New("backup job selection must be exactly one of (all,pool,vmid)")
+	}
+	if len(selection.Exclude) > 0 && !selection.All {
+		return errors.New("backup job selection exclude can only be used with all")
+	}
+	return nil
+}
+
+// Scheduled backup in /cluster/backup.
+type ConfigBackupJob struct {
+	ID       string `json:"id"`
+	Enabled  bool   `json:"enabled"`
+	Schedule string `json:"schedule"`
+	Comment  string `json:"comment,omitempty"`
+	// Only back up the guests on this node, when empty the guests on all nodes are backed up.
+	Node             string                 `json:"node,omitempty"`
+	Storage          string                 `json:"storage,omitempty"`
+	Selection        BackupJobSelection     `json:"selection"`
+	Mode             BackupMode             `json:"mode,omitempty"`
+	Compress         BackupCompression      `json:"compress,omitempty"`
+	Retention        *BackupRetention       `json:"prune-backups,omitempty"`
+	NotesTemplate    string                 `json:"notes-template,omitempty"`
+	MailNotification BackupMailNotification `json:"mailnotification,omitempty"`
+	MailTo           []string               `json:"mailto,omitempty"`
+}
+
+func (config ConfigBackupJob) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return client.Post(ctx, config.mapToApiValues(true), backupJobUrl)
+}
+
+// Updates the backup job, empty optional fields are removed from the job.
+func (config ConfigBackupJob) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return client.Put(ctx, config.mapToApiValues(false), backupJobUrl+"/"+config.ID)
+}
+
+func (config ConfigBackupJob) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"enabled":  config.Enabled,
+		"schedule": config.Schedule,
+		"all":      config.Selection.All,
+	}
+	if create {
+		params["id"] = config.ID
+	}
+	retention := ""
+	if config.Retention != nil {
+		retention = config.Retention.String()
+	}
+	optionals := map[string]string{
+		"comment":          config.Comment,
+		"compress":         string(config.Compress),
+		"exclude":          backupGuestIDsToCsv(config.Selection.Exclude),
+		"mailnotification": string(config.MailNotification),
+		"mailto":           strings.Join(config.MailTo, ","),
+		"mode":             string(config.Mode),
+		"node":             config.Node,
+		"notes-template":   config.NotesTemplate,
+		"pool":             config.Selection.Pool,
+		"prune-backups":    retention,
+		"storage":          config.Storage,
+		"vmid":             backupGuestIDsToCsv(config.Selection.GuestIDs),
+	}
+	var deletions string
+	for _, key := range backupJobOptionals {
+		if optionals[key] != "" {
+			params[key] = optionals[key]
+		} else if !create {
+			deletions = AddToList(deletions, key)
+		}
+	}
+	if deletions != "" {
+		params["delete"] = deletions
+	}
+	return params
+}
+
+func (ConfigBackupJob) mapToStruct(params map[string]interface{}) *ConfigBackupJob {
+	// Proxmox enables a job unless it is explicitly disabled.
+	config := ConfigBackupJob{Enabled: true}
+	if _, isSet := params["id"]; isSet {
+		config.ID = params["id"].(string)
+	}
+	if _, isSet := params["enabled"]; isSet {
+		config.Enabled = Itob(int(params["enabled"].(float64)))
+	}
+	if _, isSet := params["schedule"]; isSet {
+		config.Schedule = params["schedule"].(string)
+	}
+	if _, isSet := params["comment"]; isSet {
+		config.Comment = params["comment"].(string)
+	}
+	if _, isSet := params["node"]; isSet {
+		config.Node = params["node"].(string)
+	}
+	if _, isSet := params["storage"]; isSet {
+		config.Storage = params["storage"].(string)
+	}
+	if _, isSet := params["all"]; isSet {
+		config.Selection.All = Itob(int(params["all"].(float64)))
+	}
+	if _, isSet := params["exclude"]; isSet {
+		config.Selection.Exclude = backupGuestIDsFromCsv(params["exclude"].(string))
+	}
+	if _, isSet := params["pool"]; isSet {
+		config.Selection.Pool = params["pool"].(string)
+	}
+	if _, isSet := params["vmid"]; isSet {
+		config.Selection.GuestIDs = backupGuestIDsFromCsv(params["vmid"].(string))
+	}
+	if _, isSet := params["mode"]; isSet {
+		config.Mode = BackupMode(params["mode"].(string))
+	}
+	if _, isSet := params["compress"]; isSet {
+		config.Compress = BackupCompression(params["compress"].(string))
+	}
+	switch prune := params["prune-backups"].(type) {
+	case string:
+		config.Retention = BackupRetention{}.mapToStruct(prune)
+	case map[string]interface{}:
+		// Newer versions of Proxmox return the retention as an object.
+		settings := make([]string, 0, len(prune))
+		for key, value := range prune {
+			settings = append(settings, key+"="+strconv.Itoa(int(value.(float64))))
+		}
+		config.Retention = BackupRetention{}.mapToStruct(strings.Join(settings, ","))
+	}
+	if _, isSet := params["notes-template"]; isSet {
+		config.NotesTemplate = params["notes-template"].(string)
+	}
+	if _, isSet := params["mailnotification"]; isSet {
+		config.MailNotification = BackupMailNotification(params["mailnotification"].(string))
+	}
+	if mailTo, isSet := params["mailto"].(string); isSet && mailTo != "" {
+		config.MailTo = CSVtoArray(mailTo)
+	}
+	return &config
+}
+
+func (config ConfigBackupJob) Validate() error {
+	if !rxConfigID.MatchString(config.ID) {
+		return errors.New("backup job id must start with a letter, only contain letters, numbers, '-' and '_' and be 2 to 40 characters long")
+	}
+	if err := ValidateBackupSchedule(config.Schedule); err != nil {
+		return err
+	}
+	if err := config.Selection.Validate(); err != nil {
+		return err
+	}
+	if err := config.Mode.Validate(); err != nil {
+		return err
+	}
+	if err := config.Compress.Validate(); err != nil {
+		return err
+	}
+	if err := config.MailNotification.Validate(); err != nil {
+		return err
+	}
+	if config.Retention != nil {
+		return config.Retention.Validate()
+	}
+	return nil
+}
+
+// Only checks the characters of the calendar event, Proxmox validates the full syntax.
+func ValidateBackupSchedule(schedule string) error {
+	if schedule == "" {
+		return ErrorKeyEmpty("schedule")
+	}
+	if strings.TrimSpace(schedule) != schedule || !rxBackupSchedule.MatchString(schedule) {
+		return errors.New("schedule must be a calendar event, e.g. \"daily\" or \"mon..fri 21:00\"")
+	}
+	return nil
+}
+
+func backupGuestIDsToCsv(ids []uint) string {
+	list := make([]string, len(ids))
+	for i, e := range ids {
+		list[i] = strconv.FormatUint(uint64(e), 10)
+	}
+	return strings.Join(list, ",")
+}
+
+func backupGuestIDsFromCsv(csv string) []uint {
+	ids := make([]uint, 0)
+	for _, e := range CSVtoArray(csv) {
+		if id, err := strconv.ParseUint(strings.TrimSpace(e), 10, 32); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+func backupGuestIDInList(ids []uint, id uint) bool {
+	for _, e := range ids {
+		if e == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Groups the guests selected by the job by the node they reside on, the guest ids are sorted.
+func (config ConfigBackupJob) guestsPerNode(guests []interface{}) map[string][]uint {
+	perNode := map[string][]uint{}
+	for _, e := range guests {
+		guest := e.(map[string]interface{})
+		id := uint(guest["vmid"].(float64))
+		node, _ := guest["node"].(string)
+		pool, _ := guest["pool"].(string)
+		if config.Node != "" && node != config.Node {
+			continue
+		}
+		selected := false
+		switch {
+		case config.Selection.All:
+			selected = !backupGuestIDInList(config.Selection.Exclude, id)
+		case config.Selection.Pool != "":
+			selected = pool == config.Selection.Pool
+		default:
+			selected = backupGuestIDInList(config.Selection.GuestIDs, id)
+		}
+		if selected {
+			perNode[node] = append(perNode[node], id)
+		}
+	}
+	for _, ids := range perNode {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+	return perNode
+}
+
+func (config ConfigBackupJob) backupOptions(guestIDs []uint) BackupOptions {
+	return BackupOptions{
+		GuestIDs:         guestIDs,
+		Storage:          config.Storage,
+		Mode:             config.Mode,
+		Compress:         config.Compress,
+		NotesTemplate:    config.NotesTemplate,
+		Retention:        config.Retention,
+		MailTo:           config.MailTo,
+		MailNotification: config.MailNotification,
+	}
+}
+
+func (c *Client) ListBackupJobs(ctx context.Context) ([]ConfigBackupJob, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, backupJobUrl)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]ConfigBackupJob, len(list))
+	for i, e := range list {
+		jobs[i] = *ConfigBackupJob{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return jobs, nil
+}
+
+func (c *Client) GetBackupJob(ctx context.Context, id string) (*ConfigBackupJob, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ValidateStringNotEmpty(id, "id"); err != nil {
+		return nil, err
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, backupJobUrl+"/"+id, "backup job", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return ConfigBackupJob{}.mapToStruct(params), nil
+}
+
+func (c *Client) DeleteBackupJob(ctx context.Context, id string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ValidateStringNotEmpty(id, "id"); err != nil {
+		return err
+	}
+	return c.Delete(ctx, backupJobUrl+"/"+id)
+}
+
+// RunBackupJob backs up the guests selected by the job now, regardless of its schedule or whether it is enabled.
+// A backup is started on every node with selected guests, returns the UPIDs of the started backup tasks.
+func (c *Client) RunBackupJob(ctx context.Context, id string) (upids []string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	job, err := c.GetBackupJob(ctx, id)
+	if err != nil {
+		return
+	}
+	guests, err := c.GetVmList(ctx)
+	if err != nil {
+		return
+	}
+	perNode := job.guestsPerNode(guests["data"].([]interface{}))
+	nodes := make([]string, 0, len(perNode))
+	for node := range perNode {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		upid, err := c.CreateBackup(ctx, node, job.backupOptions(perNode[node]))
+		if err != nil {
+			return upids, err
+		}
+		upids = append(upids, upid)
+	}
+	return
+}
@@ -0,0 +1,113 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigBackupJob_mapToApiValues(t *testing.T) {
+	job := ConfigBackupJob{
+		ID:        "nightly",
+		Enabled:   true,
+		Schedule:  "21:00",
+		Storage:   "pbs",
+		Selection: BackupJobSelection{GuestIDs: []uint{100, 101}},
+		Mode:      BackupMode_Snapshot,
+		Retention: &BackupRetention{Daily: PointerUint(7)},
+	}
+	require.Equal(t, map[string]interface{}{
+		"id":            "nightly",
+		"enabled":       true,
+		"schedule":      "21:00",
+		"all":           false,
+		"storage":       "pbs",
+		"vmid":          "100,101",
+		"mode":          "snapshot",
+		"prune-backups": "keep-daily=7",
+	}, job.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{
+		"enabled":       true,
+		"schedule":      "21:00",
+		"all":           false,
+		"storage":       "pbs",
+		"vmid":          "100,101",
+		"mode":          "snapshot",
+		"prune-backups": "keep-daily=7",
+		"delete":        "comment,compress,exclude,mailnotification,mailto,node,notes-template,pool",
+	}, job.mapToApiValues(false))
+}
+
+func Test_ConfigBackupJob_mapToStruct(t *testing.T) {
+	require.Equal(t, &ConfigBackupJob{
+		ID:               "backup-1",
+		Enabled:          true,
+		Schedule:         "sat 02:00",
+		Selection:        BackupJobSelection{All: true, Exclude: []uint{100, 105}},
+		Compress:         BackupCompression_Zstd,
+		Retention:        &BackupRetention{Last: PointerUint(3)},
+		MailNotification: BackupMailNotification_Failure,
+		MailTo:           []string{"root@example.com"},
+	}, ConfigBackupJob{}.mapToStruct(map[string]interface{}{
+		"id":               "backup-1",
+		"schedule":         "sat 02:00",
+		"all":              float64(1),
+		"exclude":          "100,105",
+		"compress":         "zstd",
+		"prune-backups":    map[string]interface{}{"keep-last": float64(3)},
+		"mailnotification": "failure",
+		"mailto":           "root@example.com",
+	}))
+	job := ConfigBackupJob{}.mapToStruct(map[string]interface{}{"enabled": float64(0), "pool": "prod", "prune-backups": "keep-weekly=2"})
+	require.False(t, job.Enabled)
+	require.Equal(t, "prod", job.Selection.Pool)
+	require.Equal(t, &BackupRetention{Weekly: PointerUint(2)}, job.Retention)
+}
+
+func Test_ConfigBackupJob_Validate(t *testing.T) {
+	valid := func() ConfigBackupJob {
+		return ConfigBackupJob{ID: "nightly", Schedule: "mon..fri 21:00", Selection: BackupJobSelection{Pool: "prod"}}
+	}
+	require.NoError(t, valid().Validate())
+	testData := []struct {
+		name   string
+		change func(*ConfigBackupJob)
+	}{
+		{name: "invalid id", change: func(c *ConfigBackupJob) { c.ID = "1job" }},
+		{name: "empty schedule", change: func(c *ConfigBackupJob) { c.Schedule = "" }},
+		{name: "invalid schedule", change: func(c *ConfigBackupJob) { c.Schedule = "daily; rm" }},
+		{name: "no selection", change: func(c *ConfigBackupJob) { c.Selection = BackupJobSelection{} }},
+		{name: "multiple selections", change: func(c *ConfigBackupJob) { c.Selection.All = true }},
+		{name: "exclude without all", change: func(c *ConfigBackupJob) { c.Selection.Exclude = []uint{100} }},
+		{name: "invalid mode", change: func(c *ConfigBackupJob) { c.Mode = "pause" }},
+		{name: "invalid mail notification", change: func(c *ConfigBackupJob) { c.MailNotification = "never" }},
+		{name: "invalid retention", change: func(c *ConfigBackupJob) { c.Retention = &BackupRetention{Last: PointerUint(0)} }},
+	}
+	for _, e := range testData {
+		t.Run(e.name, func(*testing.T) {
+			job := valid()
+			e.change(&job)
+			require.Error(t, job.Validate())
+		})
+	}
+	for _, e := range []string{"daily", "*-*-* 00:00:00", "*/2:00", "sat,sun 03:30", "2023-01-01 ~01"} {
+		require.NoError(t, ValidateBackupSchedule(e), e)
+	}
+}
+
+func Test_ConfigBackupJob_guestsPerNode(t *testing.T) {
+	guests := []interface{}{
+		map[string]interface{}{"vmid": float64(101), "node": "pve1", "pool": "prod"},
+		map[string]interface{}{"vmid": float64(100), "node": "pve1"},
+		map[string]interface{}{"vmid": float64(200), "node": "pve2", "pool": "prod"},
+		map[string]interface{}{"vmid": float64(201), "node": "pve2"},
+	}
+	require.Equal(t, map[string][]uint{"pve1": {100, 101}, "pve2": {201}},
+		ConfigBackupJob{Selection: BackupJobSelection{All: true, Exclude: []uint{200}}}.guestsPerNode(guests))
+	require.Equal(t, map[string][]uint{"pve1": {101}, "pve2": {200}},
+		ConfigBackupJob{Selection: BackupJobSelection{Pool: "prod"}}.guestsPerNode(guests))
+	require.Equal(t, map[string][]uint{"pve2": {200, 201}},
+		ConfigBackupJob{Node: "pve2", Selection: BackupJobSelection{All: true}}.guestsPerNode(guests))
+	require.Equal(t, map[string][]uint{"pve1": {100}, "pve2": {201}},
+		ConfigBackupJob{Selection: BackupJobSelection{GuestIDs: []uint{100, 201, 300}}}.guestsPerNode(guests))
+}
@@ -19,7 +19,9 @@ type ConfigGroup struct {
 // Creates the specified group
 func (config *ConfigGroup) Create(client *Client) error {
 	ctx := context.Background()
-	config.Validate(true)
+	if err := config.Validate(true); err != nil {
+		return err
+	}
 	params := config.mapToApiValues(true)
 	err := client.Post(ctx, params, "/access/groups")
 	if err != nil {
@@ -83,7 +85,9 @@ func (config *ConfigGroup) Set(client *Client) (err error) {
 // Updates the specified group
 func (config *ConfigGroup) Update(client *Client) error {
 	ctx := context.Background()
-	config.Validate(false)
+	if err := config.Validate(false); err != nil {
+		return err
+	}
 	params := config.mapToApiValues(true)
 	err := client.Put(ctx, params, "/access/groups/"+string(config.Name))
 	if err != nil {
@@ -104,6 +108,9 @@ func (config *ConfigGroup) Validate(create bool) (err error) {
 	}
 	if create {
 		err = config.Name.Validate()
+		if err != nil {
+			return
+		}
 	}
 	if config.Members != nil {
 		for _, e := range *config.Members {
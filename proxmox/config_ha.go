@@ -0,0 +1,360 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var rxHaGroupName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_\-]+$`)
+
+const haGroupMaxPriority = 1000
+
+// Member of an HA group, guests prefer the nodes with the highest priority.
+type HaGroupNode struct {
+	Node string `json:"node"`
+	// nil means no priority, which proxmox treats as the lowest.
+	Priority *uint `json:"priority,omitempty"`
+}
+
+func (node HaGroupNode) String() string {
+	if node.Priority == nil {
+		return node.Node
+	}
+	return node.Node + ":" + strconv.FormatUint(uint64(*node.Priority), 10)
+}
+
+func (node HaGroupNode) Validate() error {
+	if node.Node == "" {
+		return errors.New("ha group node may not be empty")
+	}
+	if node.Priority != nil && *node.Priority > haGroupMaxPriority {
+		return fmt.Errorf("ha group node priority must be between 0 and %d", haGroupMaxPriority)
+	}
+	return nil
+}
+
+// HA group of the cluster (/cluster/ha/groups).
+type HaGroup struct {
+	Name    string        `json:"group"`
+	Nodes   []HaGroupNode `json:"nodes"`
+	Comment string        `json:"comment,omitempty"`
+	// Guests only run on the nodes of the group, otherwise they may fall back to any node.
+	Restricted bool `json:"restricted,omitempty"`
+	// Guests do not move back to a node with a higher priority when it comes online again.
+	NoFailback bool `json:"nofailback,omitempty"`
+}
+
+func (group HaGroup) mapToApiValues(create bool) map[string]interface{} {
+	nodes := make([]string, len(group.Nodes))
+	for i, e := range group.Nodes {
+		nodes[i] = e.String()
+	}
+	params := map[string]interface{}{
+		"nodes":      strings.Join(nodes, ","),
+		"restricted": boolToIntString(group.Restricted),
+		"nofailback": boolToIntString(group.NoFailback),
+	}
+	if create {
+		params["group"] = group.Name
+		params["type"] = "group"
+		if group.Comment != "" {
+			params["comment"] = group.Comment
+		}
+	} else if group.Comment != "" {
+		params["comment"] = group.Comment
+	} else {
+		params["delete"] = "comment"
+	}
+	return params
+}
+
+func (HaGroup) mapToStruct(params map[string]interface{}) *HaGroup {
+	group := HaGroup{Nodes: []HaGroupNode{}}
+	if _, isSet := params["group"]; isSet {
+		group.Name = params["group"].(string)
+	}
+	if nodes, isSet := params["nodes"]; isSet && nodes != "" {
+		for _, e := range strings.Split(nodes.(string), ",") {
+			node := HaGroupNode{}
+			name, priority, hasPriority := strings.Cut(e, ":")
+			node.Node = name
+			if hasPriority {
+				if tmpPriority, err := strconv.ParseUint(priority, 10, 0); err == nil {
+					node.Priority = PointerUint(uint(tmpPriority))
+				}
+			}
+			group.Nodes = append(group.Nodes, node)
+		}
+	}
+	if _, isSet := params["comment"]; isSet {
+		group.Comment = params["comment"].(string)
+	}
+	if _, isSet := params["restricted"]; isSet {
+		group.Restricted = Itob(int(params["restricted"].(float64)))
+	}
+	if _, isSet := params["nofailback"]; isSet {
+		group.NoFailback = Itob(int(params["nofailback"].(float64)))
+	}
+	return &group
+}
+
+func (group HaGroup) Validate() error {
+	if !rxHaGroupName.MatchString(group.Name) {
+		return fmt.Errorf("ha group name (%s) must start with a letter and only contain letters, digits, - and _", group.Name)
+	}
+	if len(group.Nodes) == 0 {
+		return errors.New("ha group must have at least one node")
+	}
+	for _, e := range group.Nodes {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (group HaGroup) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+	return client.Post(ctx, group.mapToApiValues(true), "/cluster/ha/groups")
+}
+
+func (group HaGroup) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+	return client.Put(ctx, group.mapToApiValues(false), "/cluster/ha/groups/"+group.Name)
+}
+
+// ListHaGroups returns all HA groups of the cluster.
+func (c *Client) ListHaGroups(ctx context.Context) ([]HaGroup, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/cluster/ha/groups")
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]HaGroup, len(list))
+	for i, e := range list {
+		groups[i] = *HaGroup{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return groups, nil
+}
+
+func (c *Client) GetHaGroup(ctx context.Context, name string) (*HaGroup, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/cluster/ha/groups/"+name, "ha group", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return HaGroup{}.mapToStruct(params), nil
+}
+
+// DeleteHaGroup removes the HA group, proxmox refuses this while resources are still in the group.
+func (c *Client) DeleteHaGroup(ctx context.Context, name string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.Delete(ctx, "/cluster/ha/groups/"+name)
+}
+
+// Requested state of an HA resource.
+type HaResourceState string
+
+const (
+	HaResourceState_Disabled HaResourceState = "disabled"
+	HaResourceState_Ignored  HaResourceState = "ignored"
+	HaResourceState_Started  HaResourceState = "started"
+	HaResourceState_Stopped  HaResourceState = "stopped"
+)
+
+func (state HaResourceState) Validate() error {
+	switch state {
+	case HaResourceState_Disabled, HaResourceState_Ignored, HaResourceState_Started, HaResourceState_Stopped:
+		return nil
+	}
+	return errors.New("ha resource state must be one of (disabled,ignored,started,stopped)")
+}
+
+// Guest managed by the HA manager (/cluster/ha/resources).
+type HaResource struct {
+	VmID uint `json:"vmid"`
+	// "vm" or "ct", only used to build the resource ID. Empty lets proxmox look up the type of the guest.
+	Type    string          `json:"type,omitempty"`
+	State   HaResourceState `json:"state,omitempty"`
+	Group   string          `json:"group,omitempty"`
+	Comment string          `json:"comment,omitempty"`
+	// Number of restart attempts on the same node, nil uses the proxmox default.
+	MaxRestart *uint `json:"max_restart,omitempty"`
+	// Number of attempts to relocate the guest to another node, nil uses the proxmox default.
+	MaxRelocate *uint `json:"max_relocate,omitempty"`
+}
+
+// Resource ID as used by the HA manager, e.g. "vm:100".
+func (resource HaResource) sid() string {
+	id := strconv.FormatUint(uint64(resource.VmID), 10)
+	if resource.Type == "" {
+		return id
+	}
+	return resource.Type + ":" + id
+}
+
+func (resource HaResource) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{}
+	if create {
+		params["sid"] = resource.sid()
+	}
+	deletions := []string{}
+	if resource.State != "" {
+		params["state"] = string(resource.State)
+	}
+	if resource.Group != "" {
+		params["group"] = resource.Group
+	} else if !create {
+		deletions = append(deletions, "group")
+	}
+	if resource.Comment != "" {
+		params["comment"] = resource.Comment
+	} else if !create {
+		deletions = append(deletions, "comment")
+	}
+	if resource.MaxRestart != nil {
+		params["max_restart"] = *resource.MaxRestart
+	}
+	if resource.MaxRelocate != nil {
+		params["max_relocate"] = *resource.MaxRelocate
+	}
+	if len(deletions) > 0 {
+		params["delete"] = strings.Join(deletions, ",")
+	}
+	return params
+}
+
+func (HaResource) mapToStruct(params map[string]interface{}) *HaResource {
+	resource := HaResource{}
+	if _, isSet := params["sid"]; isSet {
+		resourceType, id, hasType := strings.Cut(params["sid"].(string), ":")
+		if !hasType {
+			id = resourceType
+			resourceType = ""
+		}
+		resource.Type = resourceType
+		if tmpID, err := strconv.ParseUint(id, 10, 0); err == nil {
+			resource.VmID = uint(tmpID)
+		}
+	}
+	if _, isSet := params["state"]; isSet {
+		resource.State = HaResourceState(params["state"].(string))
+	}
+	if _, isSet := params["group"]; isSet {
+		resource.Group = params["group"].(string)
+	}
+	if _, isSet := params["comment"]; isSet {
+		resource.Comment = params["comment"].(string)
+	}
+	if _, isSet := params["max_restart"]; isSet {
+		resource.MaxRestart = PointerUint(uint(params["max_restart"].(float64)))
+	}
+	if _, isSet := params["max_relocate"]; isSet {
+		resource.MaxRelocate = PointerUint(uint(params["max_relocate"].(float64)))
+	}
+	return &resource
+}
+
+func (resource HaResource) Validate() error {
+	if resource.VmID < 100 {
+		return errors.New("ha resource vmid must be at least 100")
+	}
+	if resource.Type != "" && resource.Type != "vm" && resource.Type != "ct" {
+		return errors.New("ha resource type must be vm, ct or empty")
+	}
+	if resource.State != "" {
+		if err := resource.State.Validate(); err != nil {
+			return err
+		}
+	}
+	if resource.Group != "" && !rxHaGroupName.MatchString(resource.Group) {
+		return fmt.Errorf("ha group name (%s) must start with a letter and only contain letters, digits, - and _", resource.Group)
+	}
+	return nil
+}
+
+func (resource HaResource) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := resource.Validate(); err != nil {
+		return err
+	}
+	return client.Post(ctx, resource.mapToApiValues(true), "/cluster/ha/resources")
+}
+
+func (resource HaResource) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := resource.Validate(); err != nil {
+		return err
+	}
+	return client.Put(ctx, resource.mapToApiValues(false), "/cluster/ha/resources/"+resource.sid())
+}
+
+// ListHaResources returns all guests managed by the HA manager.
+func (c *Client) ListHaResources(ctx context.Context) ([]HaResource, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/cluster/ha/resources")
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]HaResource, len(list))
+	for i, e := range list {
+		resources[i] = *HaResource{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return resources, nil
+}
+
+func (c *Client) GetHaResource(ctx context.Context, vmid uint) (*HaResource, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/cluster/ha/resources/"+strconv.FormatUint(uint64(vmid), 10), "ha resource", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return HaResource{}.mapToStruct(params), nil
+}
+
+// DeleteHaResource removes the guest from the HA manager, the guest itself is kept.
+func (c *Client) DeleteHaResource(ctx context.Context, vmid uint) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.Delete(ctx, "/cluster/ha/resources/"+strconv.FormatUint(uint64(vmid), 10))
+}
+
+// SetGuestHaState changes the requested state of a guest that is already managed by the HA manager.
+func (c *Client) SetGuestHaState(ctx context.Context, vmid uint, state HaResourceState) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := state.Validate(); err != nil {
+		return err
+	}
+	return c.Put(ctx, map[string]interface{}{"state": string(state)}, "/cluster/ha/resources/"+strconv.FormatUint(uint64(vmid), 10))
+}
@@ -0,0 +1,99 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HaGroup_mapToApiValues(t *testing.T) {
+	group := HaGroup{
+		Name:       "prod",
+		Nodes:      []HaGroupNode{{Node: "pve1", Priority: PointerUint(2)}, {Node: "pve2"}},
+		Restricted: true,
+	}
+	require.Equal(t, map[string]interface{}{
+		"group":      "prod",
+		"type":       "group",
+		"nodes":      "pve1:2,pve2",
+		"restricted": "1",
+		"nofailback": "0",
+	}, group.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{
+		"nodes":      "pve1:2,pve2",
+		"restricted": "1",
+		"nofailback": "0",
+		"delete":     "comment",
+	}, group.mapToApiValues(false))
+}
+
+func Test_HaGroup_mapToStruct(t *testing.T) {
+	require.Equal(t, &HaGroup{
+		Name:       "prod",
+		Nodes:      []HaGroupNode{{Node: "pve1", Priority: PointerUint(2)}, {Node: "pve2"}},
+		Comment:    "production",
+		NoFailback: true,
+	}, HaGroup{}.mapToStruct(map[string]interface{}{
+		"group":      "prod",
+		"nodes":      "pve1:2,pve2",
+		"comment":    "production",
+		"restricted": float64(0),
+		"nofailback": float64(1),
+	}))
+}
+
+func Test_HaGroup_Validate(t *testing.T) {
+	testData := []struct {
+		input HaGroup
+		err   bool
+	}{
+		{input: HaGroup{Name: "prod", Nodes: []HaGroupNode{{Node: "pve1", Priority: PointerUint(1000)}}}},
+		{input: HaGroup{Name: "1prod", Nodes: []HaGroupNode{{Node: "pve1"}}}, err: true},
+		{input: HaGroup{Name: "prod"}, err: true},
+		{input: HaGroup{Name: "prod", Nodes: []HaGroupNode{{}}}, err: true},
+		{input: HaGroup{Name: "prod", Nodes: []HaGroupNode{{Node: "pve1", Priority: PointerUint(1001)}}}, err: true},
+	}
+	for _, e := range testData {
+		if e.err {
+			require.Error(t, e.input.Validate(), e.input)
+		} else {
+			require.NoError(t, e.input.Validate(), e.input)
+		}
+	}
+}
+
+func Test_HaResource_mapToApiValues(t *testing.T) {
+	resource := HaResource{VmID: 100, Type: "vm", State: HaResourceState_Started, Group: "prod", MaxRestart: PointerUint(2), MaxRelocate: PointerUint(0)}
+	require.Equal(t, map[string]interface{}{
+		"sid":          "vm:100",
+		"state":        "started",
+		"group":        "prod",
+		"max_restart":  uint(2),
+		"max_relocate": uint(0),
+	}, resource.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{
+		"state":  "stopped",
+		"delete": "group,comment",
+	}, HaResource{VmID: 100, State: HaResourceState_Stopped}.mapToApiValues(false))
+}
+
+func Test_HaResource_mapToStruct(t *testing.T) {
+	require.Equal(t, &HaResource{VmID: 101, Type: "ct", State: HaResourceState_Ignored, Group: "prod", MaxRestart: PointerUint(1), MaxRelocate: PointerUint(1)},
+		HaResource{}.mapToStruct(map[string]interface{}{
+			"sid":          "ct:101",
+			"state":        "ignored",
+			"group":        "prod",
+			"max_restart":  float64(1),
+			"max_relocate": float64(1),
+		}))
+	require.Equal(t, &HaResource{VmID: 102}, HaResource{}.mapToStruct(map[string]interface{}{"sid": "102"}))
+}
+
+func Test_HaResource_Validate(t *testing.T) {
+	require.NoError(t, HaResource{VmID: 100, Type: "ct", State: HaResourceState_Disabled}.Validate())
+	require.NoError(t, HaResource{VmID: 100}.Validate())
+	require.Error(t, HaResource{VmID: 99}.Validate())
+	require.Error(t, HaResource{VmID: 100, Type: "qemu"}.Validate())
+	require.Error(t, HaResource{VmID: 100, State: "running"}.Validate())
+	require.Error(t, HaResource{VmID: 100, Group: "-prod"}.Validate())
+}
@@ -3,6 +3,7 @@ package proxmox
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -19,10 +20,9 @@ type ConfigLxc struct {
 	CMode              string      `json:"cmode"`
 	Console            bool        `json:"console"`
 	Cores              int         `json:"cores,omitempty"`
-	CPULimit           int         `json:"cpulimit"`
+	CPULimit           float64     `json:"cpulimit"`
 	CPUUnits           int         `json:"cpuunits"`
 	Description        string      `json:"description,omitempty"`
-	Features           QemuDevice  `json:"features,omitempty"`
 	Force              bool        `json:"force,omitempty"`
 	Full               bool        `json:"full,omitempty"`
 	HaState            string      `json:"hastate,omitempty"`
@@ -32,7 +32,6 @@ type ConfigLxc struct {
 	IgnoreUnpackErrors bool        `json:"ignore-unpack-errors,omitempty"`
 	Lock               string      `json:"lock,omitempty"`
 	Memory             int         `json:"memory"`
-	Mountpoints        QemuDevices `json:"mountpoints,omitempty"`
 	Nameserver         string      `json:"nameserver,omitempty"`
 	Networks           QemuDevices `json:"networks,omitempty"`
 	OnBoot             bool        `json:"onboot"`
@@ -41,7 +40,7 @@ type ConfigLxc struct {
 	Pool               string      `json:"pool,omitempty"`
 	Protection         bool        `json:"protection"`
 	Restore            bool        `json:"restore,omitempty"`
-	RootFs             QemuDevice  `json:"rootfs,omitempty"`
+	RootFs             *LxcRootFs  `json:"rootfs,omitempty"`
 	SearchDomain       string      `json:"searchdomain,omitempty"`
 	Snapname           string      `json:"snapname,omitempty"`
 	SSHPublicKeys      string      `json:"ssh-public-keys,omitempty"`
@@ -55,6 +54,13 @@ type ConfigLxc struct {
 	Unprivileged       bool        `json:"unprivileged"`
 	Tags               string      `json:"tags"`
 	Unused             []string    `json:"unused,omitempty"`
+
+	Devices     []LxcDevice     `json:"devices,omitempty"`
+	Features    *LxcFeatures    `json:"features,omitempty"`
+	Mountpoints []LxcMountPoint `json:"mountpoints,omitempty"`
+	// startup order of the container, this takes precedence over Startup. Whether the container is started on boot is set by OnBoot.
+	// Reading the config only fills Startup, use StartupSettings for the parsed settings.
+	StartupConfig *StartupConfig `json:"startup_config,omitempty"`
 }
 
 func NewConfigLxc() ConfigLxc {
@@ -93,7 +99,11 @@ func NewConfigLxcFromApi(vmr *VmRef, client *Client) (config *ConfigLxc, err err
 	if err != nil {
 		return nil, err
 	}
+	return newConfigLxcFromApiConfig(ctx, vmr, client, lxcConfig)
+}
 
+// Maps the raw config of the container, which is either the active config or the config captured in a snapshot.
+func newConfigLxcFromApiConfig(ctx context.Context, vmr *VmRef, client *Client, lxcConfig map[string]interface{}) (config *ConfigLxc, err error) {
 	// prepare a new lxc config to store and return\
 	// the information from api
 	newConfig := NewConfigLxc()
@@ -103,7 +113,7 @@ func NewConfigLxcFromApi(vmr *VmRef, client *Client) (config *ConfigLxc, err err
 	if _, isSet := lxcConfig["arch"]; isSet {
 		arch = lxcConfig["arch"].(string)
 	}
-	cmode := ""
+	cmode := LxcCMode_Tty
 	if _, isSet := lxcConfig["cmode"]; isSet {
 		cmode = lxcConfig["cmode"].(string)
 	}
@@ -115,9 +125,9 @@ func NewConfigLxcFromApi(vmr *VmRef, client *Client) (config *ConfigLxc, err err
 	if _, isSet := lxcConfig["cores"]; isSet {
 		cores = int(lxcConfig["cores"].(float64))
 	}
-	cpulimit := 0
+	cpulimit := 0.0
 	if _, isSet := lxcConfig["cpulimit"]; isSet {
-		cpulimit, _ = strconv.Atoi(lxcConfig["cpulimit"].(string))
+		cpulimit = parseLxcCpuLimit(lxcConfig["cpulimit"])
 	}
 	cpuunits := 1024
 	if _, isSet := lxcConfig["cpuunits"]; isSet {
@@ -130,20 +140,7 @@ func NewConfigLxcFromApi(vmr *VmRef, client *Client) (config *ConfigLxc, err err
 
 	// add features, if any
 	if features, isSet := lxcConfig["features"]; isSet {
-		featureList := strings.Split(features.(string), ",")
-
-		// create new device map to store features
-		featureMap := QemuDevice{}
-		// add all features to device map
-		featureMap.readDeviceConfig(featureList)
-		// prepare empty feature map
-		if config.Features == nil {
-			config.Features = QemuDevice{}
-		}
-		// and device config to networks
-		if len(featureMap) > 0 {
-			config.Features = featureMap
-		}
+		config.Features = LxcFeatures{}.mapToStruct(features.(string))
 	}
 	hookscript := ""
 	if _, isSet := lxcConfig["hookscript"]; isSet {
@@ -163,45 +160,19 @@ func NewConfigLxcFromApi(vmr *VmRef, client *Client) (config *ConfigLxc, err err
 	}
 
 	// add rootfs
-	rootfs := QemuDevice{}
-	if rootfsStr, isSet := lxcConfig["rootfs"]; isSet {
-		rootfs = ParsePMConf(rootfsStr.(string), "volume")
+	var rootfs *LxcRootFs
+	if _, isSet := lxcConfig["rootfs"]; isSet {
+		rootfs = LxcRootFs{}.mapToStruct(lxcConfig["rootfs"].(string))
 	}
 
 	// add mountpoints
-	mpNames := []string{}
-
-	for k := range lxcConfig {
-		if mpName := rxMpName.FindStringSubmatch(k); len(mpName) > 0 {
-			mpNames = append(mpNames, mpName[0])
-		}
+	if mps := readLxcMountPoints(lxcConfig); len(mps) > 0 {
+		config.Mountpoints = mps
 	}
 
-	for _, mpName := range mpNames {
-		mpConfStr := lxcConfig[mpName].(string)
-		mpConfMap := ParseLxcDisk(mpConfStr)
-
-		// add mp id
-		id := rxDeviceID.FindStringSubmatch(mpName)
-		mpID, _ := strconv.Atoi(id[0])
-		mpConfMap["slot"] = mpID
-
-		// 5 potential boolean flags need to be converted
-		for _, key := range []string{"acl", "backup", "quota", "replicate", "shared"} {
-			// if flag is set, need to convert int to bool
-			if _, isSet := mpConfMap[key]; isSet {
-				mpConfMap[key] = Itob(mpConfMap[key].(int))
-			}
-		}
-
-		// prepare empty mountpoint map
-		if config.Mountpoints == nil {
-			config.Mountpoints = QemuDevices{}
-		}
-		// and device config to mountpoints
-		if len(mpConfMap) > 0 {
-			config.Mountpoints[mpID] = mpConfMap
-		}
+	// add devices
+	if devices := readLxcDevices(lxcConfig); len(devices) > 0 {
+		config.Devices = devices
 	}
 
 	nameserver := ""
@@ -336,6 +307,40 @@ func NewConfigLxcFromApi(vmr *VmRef, client *Client) (config *ConfigLxc, err err
 func (config ConfigLxc) CreateLxc(vmr *VmRef, client *Client) (err error) {
 	ctx := context.Background()
 	vmr.SetVmType("lxc")
+	err = config.validateConsole()
+	if err != nil {
+		return
+	}
+	err = config.validateResourcesOnClient(ctx, client)
+	if err != nil {
+		return
+	}
+	if config.RootFs != nil {
+		err = config.RootFs.Validate()
+		if err != nil {
+			return
+		}
+	}
+	if config.StartupConfig != nil {
+		err = config.StartupConfig.Validate()
+		if err != nil {
+			return
+		}
+	}
+	err = validateLxcMountPoints(config.Mountpoints)
+	if err != nil {
+		return
+	}
+	err = validateLxcDevices(config.Devices)
+	if err != nil {
+		return
+	}
+	if config.Features != nil {
+		err = config.Features.Validate(config.Unprivileged)
+		if err != nil {
+			return
+		}
+	}
 	paramMap := config.mapToApiValues()
 
 	// amend vmid
@@ -369,7 +374,7 @@ func (config ConfigLxc) CloneLxc(vmr *VmRef, client *Client) (err error) {
 	}
 
 	if config.BWLimit != 0 {
-		paramMap["bwlimit"] = config.Hostname
+		paramMap["bwlimit"] = config.BWLimit
 	}
 
 	if config.CloneStorage != "" {
@@ -406,9 +411,154 @@ func (config ConfigLxc) CloneLxc(vmr *VmRef, client *Client) (err error) {
 	return
 }
 
+// Options for cloning an LXC container.
+// When Full is false a linked clone is created, which requires the source to be a template.
+type ConfigLxcClone struct {
+	BwLimit     int    `json:"bwlimit,omitempty"` // KiB/s
+	Description string `json:"description,omitempty"`
+	Full        bool   `json:"full"`
+	Hostname    string `json:"hostname,omitempty"`
+	NewID       int    `json:"newid,omitempty"` // 0 uses the next free ID
+	Pool        string `json:"pool,omitempty"`
+	SnapName    string `json:"snapname,omitempty"`
+	Storage     string `json:"storage,omitempty"`
+	Target      string `json:"target,omitempty"`
+}
+
+func (clone ConfigLxcClone) mapToApiValues(newID int) map[string]interface{} {
+	params := map[string]interface{}{
+		"newid":       newID,
+		"full":        clone.Full,
+		"description": clone.Description,
+		"hostname":    clone.Hostname,
+		"pool":        clone.Pool,
+		"snapname":    clone.SnapName,
+		"storage":     clone.Storage,
+		"target":      clone.Target,
+	}
+	if clone.BwLimit != 0 {
+		params["bwlimit"] = clone.BwLimit
+	}
+	return params
+}
+
+// Validates the clone options against the source container.
+func (clone ConfigLxcClone) Validate(sourceIsTemplate bool) error {
+	if clone.BwLimit < 0 {
+		return errors.New("bwlimit may not be negative")
+	}
+	if clone.Full {
+		return nil
+	}
+	if !sourceIsTemplate {
+		return errors.New("a linked clone can only be created from a template, set full to clone a regular container")
+	}
+	if clone.Storage != "" {
+		return errors.New("storage can only be specified for a full clone")
+	}
+	return nil
+}
+
+func (clone ConfigLxcClone) startCloneLxc(ctx context.Context, client *Client, source *VmRef) (vmr *VmRef, taskResponse map[string]interface{}, err error) {
+	template, err := client.cloneSourceIsTemplate(ctx, source, "lxc")
+	if err != nil {
+		return
+	}
+	err = clone.Validate(template)
+	if err != nil {
+		return
+	}
+	newID := clone.NewID
+	if newID == 0 {
+		newID, err = client.GetNextID(ctx, 0)
+		if err != nil {
+			return
+		}
+	}
+	taskResponse, err = client.startCloneTask(ctx, source, clone.mapToApiValues(newID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error cloning LXC container: %v", err)
+	}
+	return cloneVmRef(newID, "lxc", source.node, clone.Target, clone.Pool), taskResponse, nil
+}
+
+// CloneLxc clones the source container with the options, the ID of the new container is taken from opts.NewID.
+// When opts.NewID is 0 the next free ID is used.
+// Returns the reference to the new container and the UPID of the clone task.
+func (c *Client) CloneLxc(ctx context.Context, source *VmRef, opts ConfigLxcClone) (vmr *VmRef, upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmr, taskResponse, err := opts.startCloneLxc(ctx, c, source)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	exitStatus, err := c.WaitForCompletion(ctx, taskResponse)
+	if err != nil {
+		jsonParams, _ := json.Marshal(opts.mapToApiValues(vmr.vmId))
+		return nil, upid, fmt.Errorf("error cloning LXC container: %v, error status: %s (params: %v)", err, exitStatus, string(jsonParams))
+	}
+	return
+}
+
+// StartCloneLxc is CloneLxc without waiting for the clone task, use WaitForTask to wait for the clone to complete.
+func (c *Client) StartCloneLxc(ctx context.Context, source *VmRef, opts ConfigLxcClone) (vmr *VmRef, upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmr, taskResponse, err := opts.startCloneLxc(ctx, c, source)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	return
+}
+
 func (config ConfigLxc) UpdateConfig(vmr *VmRef, client *Client) (err error) {
 	ctx := context.Background()
+	err = config.validateConsole()
+	if err != nil {
+		return
+	}
+	err = config.validateResourcesOnClient(ctx, client)
+	if err != nil {
+		return
+	}
+	if config.RootFs != nil {
+		err = config.RootFs.Validate()
+		if err != nil {
+			return
+		}
+	}
+	if config.StartupConfig != nil {
+		err = config.StartupConfig.Validate()
+		if err != nil {
+			return
+		}
+	}
+	err = validateLxcMountPoints(config.Mountpoints)
+	if err != nil {
+		return
+	}
+	err = validateLxcDevices(config.Devices)
+	if err != nil {
+		return
+	}
+	if config.Features != nil {
+		err = config.Features.validateUpdate(ctx, vmr, client)
+		if err != nil {
+			return
+		}
+	}
 	paramMap := config.mapToApiValues()
+	// an empty list of features can only be cleared by deleting them
+	if paramMap["features"] == "" {
+		delete(paramMap, "features")
+		if config.Features != nil {
+			paramMap["delete"] = "features"
+		}
+	}
 
 	// delete parameters which are not supported in updated operations
 	delete(paramMap, "pool")
@@ -439,7 +589,7 @@ func ParseLxcDisk(diskStr string) QemuDevice {
 	// add features, if any
 	if mountoptions, isSet := disk["mountoptions"]; isSet {
 		moList := strings.Split(mountoptions.(string), ";")
-		moMap := map[string]bool{}
+		moMap := map[string]interface{}{}
 		for _, mo := range moList {
 			moMap[mo] = true
 		}
@@ -459,26 +609,37 @@ func (config ConfigLxc) mapToApiValues() map[string]interface{} {
 	var paramMap map[string]interface{}
 	json.Unmarshal(params, &paramMap)
 
-	// build list of features
 	// add features as parameter list to lxc parameters
 	// this overwrites the original formatting with a
 	// comma separated list of "key=value" pairs
-	paramMap["features"] = formatDeviceParam(config.Features)
+	delete(paramMap, "features")
+	if config.Features != nil {
+		paramMap["features"] = config.Features.mapToApiValues()
+	}
+
+	delete(paramMap, "startup_config")
+	if config.StartupConfig != nil {
+		paramMap["startup"] = config.StartupConfig.mapToApiValues()
+	}
 
 	// format rootfs params as expected
-	if rootfs := config.RootFs; rootfs != nil {
-		paramMap["rootfs"] = FormatDiskParam(rootfs)
+	delete(paramMap, "rootfs")
+	if config.RootFs != nil {
+		paramMap["rootfs"] = config.RootFs.mapToApiValues()
 	}
 
 	// build list of mountpoints
 	// this does the same as for the feature list
 	// except that there can be multiple of these mountpoint sets
 	// and each mountpoint set comes with a new id
-	for _, mpConfMap := range config.Mountpoints {
+	for _, mp := range config.Mountpoints {
 		// add mp to lxc parameters
-		mpID := mpConfMap["slot"]
-		mpName := fmt.Sprintf("mp%v", mpID)
-		paramMap[mpName] = FormatDiskParam(mpConfMap)
+		paramMap[fmt.Sprintf("mp%d", mp.ID)] = mp.mapToApiValues()
+	}
+
+	// build list of devices, same as for the mountpoints
+	for _, device := range config.Devices {
+		paramMap[fmt.Sprintf("dev%d", device.ID)] = device.mapToApiValues()
 	}
 
 	// build list of network parameters
@@ -502,6 +663,7 @@ func (config ConfigLxc) mapToApiValues() map[string]interface{} {
 	// not know how to handle this key
 	delete(paramMap, "networks")
 	delete(paramMap, "mountpoints")
+	delete(paramMap, "devices")
 	delete(paramMap, "unused")
 
 	// also delete the hastate & hagroup key which is used elsewhere
@@ -0,0 +1,29 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Console modes of a container, decide what is attached when opening the console.
+const (
+	// Attach to /dev/console of the container.
+	LxcCMode_Console string = "console"
+	// Start a shell inside the container, without logging in.
+	LxcCMode_Shell string = "shell"
+	// Attach to one of the ttys of the container, the default.
+	LxcCMode_Tty string = "tty"
+)
+
+const lxcMaxTty = 6
+
+// Validates the console mode and the number of ttys.
+func (config ConfigLxc) validateConsole() error {
+	if config.CMode != "" && !inArray([]string{LxcCMode_Console, LxcCMode_Shell, LxcCMode_Tty}, config.CMode) {
+		return errors.New("cmode must be one of (" + LxcCMode_Console + "," + LxcCMode_Shell + "," + LxcCMode_Tty + ")")
+	}
+	if config.Tty < 0 || config.Tty > lxcMaxTty {
+		return fmt.Errorf("tty must be between 0 and %d", lxcMaxTty)
+	}
+	return nil
+}
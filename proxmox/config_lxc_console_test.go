@@ -0,0 +1,16 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigLxc_validateConsole(t *testing.T) {
+	require.NoError(t, NewConfigLxc().validateConsole())
+	require.NoError(t, ConfigLxc{CMode: LxcCMode_Shell, Tty: 0}.validateConsole())
+	require.NoError(t, ConfigLxc{CMode: LxcCMode_Console, Tty: 6}.validateConsole())
+	require.Error(t, ConfigLxc{CMode: "serial"}.validateConsole())
+	require.Error(t, ConfigLxc{CMode: LxcCMode_Tty, Tty: 7}.validateConsole())
+	require.Error(t, ConfigLxc{CMode: LxcCMode_Tty, Tty: -1}.validateConsole())
+}
@@ -0,0 +1,94 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ranges of cpuunits, cgroup v2 (the default since Proxmox VE 7) has a smaller range than cgroup v1.
+const (
+	lxcCpuUnitsMinV1 = 2
+	lxcCpuUnitsMaxV1 = 262144
+	lxcCpuUnitsMinV2 = 1
+	lxcCpuUnitsMaxV2 = 10000
+	lxcMaxCpuLimit   = 8192
+	lxcMinMemory     = 16
+)
+
+// Returns the cgroup version used by default by the Proxmox VE version (e.g. "7.4"), 0 when the version is unknown.
+func cgroupVersionFromPveVersion(version string) uint {
+	major, _, _ := strings.Cut(version, ".")
+	number, err := strconv.Atoi(major)
+	if err != nil {
+		return 0
+	}
+	if number >= 7 {
+		return 2
+	}
+	return 1
+}
+
+// Detects the cgroup version from the Proxmox VE version, 0 when it could not be detected.
+func (c *Client) detectCgroupVersion(ctx context.Context) uint {
+	version, err := c.GetVersion(ctx)
+	if err != nil {
+		return 0
+	}
+	data, _ := version["data"].(map[string]interface{})
+	release, _ := data["version"].(string)
+	return cgroupVersionFromPveVersion(release)
+}
+
+// Validates the resources, the cgroup version is only detected when cpuunits are set as the other limits don't depend on it.
+func (config ConfigLxc) validateResourcesOnClient(ctx context.Context, client *Client) error {
+	var cgroupVersion uint
+	if config.CPUUnits != 0 {
+		cgroupVersion = client.detectCgroupVersion(ctx)
+	}
+	return config.validateResources(cgroupVersion)
+}
+
+// Validates the cpu and memory limits, cpuunits are validated against the cgroup version.
+// When the cgroup version is 0 (unknown) cpuunits are validated against the combined range of both versions.
+func (config ConfigLxc) validateResources(cgroupVersion uint) error {
+	if config.Cores < 0 {
+		return fmt.Errorf("cores may not be negative")
+	}
+	if config.CPULimit < 0 || config.CPULimit > lxcMaxCpuLimit {
+		return fmt.Errorf("cpulimit must be between 0 and %d", lxcMaxCpuLimit)
+	}
+	// 0 keeps the default
+	if config.CPUUnits != 0 {
+		min, max := lxcCpuUnitsMinV2, lxcCpuUnitsMaxV1
+		switch cgroupVersion {
+		case 1:
+			min, max = lxcCpuUnitsMinV1, lxcCpuUnitsMaxV1
+		case 2:
+			min, max = lxcCpuUnitsMinV2, lxcCpuUnitsMaxV2
+		}
+		if config.CPUUnits < min || config.CPUUnits > max {
+			return fmt.Errorf("cpuunits must be between %d and %d", min, max)
+		}
+	}
+	if config.Memory != 0 && config.Memory < lxcMinMemory {
+		return fmt.Errorf("memory must be at least %d MiB", lxcMinMemory)
+	}
+	if config.Swap < 0 {
+		return fmt.Errorf("swap may not be negative")
+	}
+	return nil
+}
+
+// The API returns cpulimit as a string or as a number.
+func parseLxcCpuLimit(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		limit, _ := strconv.ParseFloat(v, 64)
+		return limit
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_cgroupVersionFromPveVersion(t *testing.T) {
+	require.Equal(t, uint(1), cgroupVersionFromPveVersion("6.4"))
+	require.Equal(t, uint(2), cgroupVersionFromPveVersion("7.4"))
+	require.Equal(t, uint(2), cgroupVersionFromPveVersion("8.0.3"))
+	require.Equal(t, uint(0), cgroupVersionFromPveVersion(""))
+}
+
+func Test_ConfigLxc_validateResources(t *testing.T) {
+	tests := []struct {
+		input         ConfigLxc
+		cgroupVersion uint
+		err           bool
+	}{
+		{input: NewConfigLxc()},
+		{input: NewConfigLxc(), cgroupVersion: 1},
+		{input: NewConfigLxc(), cgroupVersion: 2},
+		{input: ConfigLxc{CPULimit: 1.5, CPUUnits: 100, Cores: 2, Memory: 512}, cgroupVersion: 2},
+		{input: ConfigLxc{CPUUnits: 20000}, cgroupVersion: 1},
+		{input: ConfigLxc{CPUUnits: 20000}},
+		{input: ConfigLxc{CPUUnits: 20000}, cgroupVersion: 2, err: true},
+		{input: ConfigLxc{CPUUnits: 1}, cgroupVersion: 1, err: true},
+		{input: ConfigLxc{CPUUnits: 300000}, err: true},
+		{input: ConfigLxc{CPULimit: -0.5}, err: true},
+		{input: ConfigLxc{CPULimit: 9000}, err: true},
+		{input: ConfigLxc{Cores: -1}, err: true},
+		{input: ConfigLxc{Memory: 8}, err: true},
+		{input: ConfigLxc{Swap: -1}, err: true},
+	}
+	for _, test := range tests {
+		if test.err {
+			require.Error(t, test.input.validateResources(test.cgroupVersion), test.input)
+		} else {
+			require.NoError(t, test.input.validateResources(test.cgroupVersion), test.input)
+		}
+	}
+}
+
+func Test_parseLxcCpuLimit(t *testing.T) {
+	require.Equal(t, 1.5, parseLxcCpuLimit("1.5"))
+	require.Equal(t, float64(2), parseLxcCpuLimit(float64(2)))
+	require.Equal(t, float64(0), parseLxcCpuLimit(nil))
+}
@@ -0,0 +1,100 @@
+package proxmox
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	rxLxcDeviceName = regexp.MustCompile(`^dev\d+$`)
+	rxLxcDeviceMode = regexp.MustCompile(`^[0-7]{3,4}$`)
+)
+
+// Host device (devN) that is passed through to a container.
+type LxcDevice struct {
+	ID uint8 `json:"id"`
+	// Absolute path of the device on the host, it has the same path inside the container.
+	Path string `json:"path"`
+	// Octal access mode of the device node (e.g. "0666").
+	Mode string `json:"mode,omitempty"`
+	Uid  uint   `json:"uid,omitempty"`
+	Gid  uint   `json:"gid,omitempty"`
+}
+
+func (device LxcDevice) mapToApiValues() string {
+	settings := "path=" + device.Path
+	if device.Mode != "" {
+		settings += ",mode=" + device.Mode
+	}
+	if device.Uid != 0 {
+		settings += ",uid=" + strconv.FormatUint(uint64(device.Uid), 10)
+	}
+	if device.Gid != 0 {
+		settings += ",gid=" + strconv.FormatUint(uint64(device.Gid), 10)
+	}
+	return settings
+}
+
+func (LxcDevice) mapToStruct(id uint8, setting string) LxcDevice {
+	device := LxcDevice{ID: id}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, hasValue := strings.Cut(e, "=")
+		// the path is the default key
+		if !hasValue {
+			key, value = "path", key
+		}
+		switch key {
+		case "path":
+			device.Path = value
+		case "mode":
+			device.Mode = value
+		case "uid":
+			uid, _ := strconv.ParseUint(value, 10, 32)
+			device.Uid = uint(uid)
+		case "gid":
+			gid, _ := strconv.ParseUint(value, 10, 32)
+			device.Gid = uint(gid)
+		}
+	}
+	return device
+}
+
+func (device LxcDevice) Validate() error {
+	if !strings.HasPrefix(device.Path, "/") {
+		return fmt.Errorf("device (%d) path must be an absolute path", device.ID)
+	}
+	if device.Mode != "" && !rxLxcDeviceMode.MatchString(device.Mode) {
+		return fmt.Errorf("device (%d) mode must be an octal access mode (e.g. 0666)", device.ID)
+	}
+	return nil
+}
+
+// Parses all devices from the container config, sorted by ID.
+func readLxcDevices(lxcConfig map[string]interface{}) []LxcDevice {
+	devices := []LxcDevice{}
+	for k, v := range lxcConfig {
+		if rxLxcDeviceName.MatchString(k) {
+			id, _ := strconv.Atoi(strings.TrimPrefix(k, "dev"))
+			devices = append(devices, LxcDevice{}.mapToStruct(uint8(id), v.(string)))
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].ID < devices[j].ID })
+	return devices
+}
+
+func validateLxcDevices(devices []LxcDevice) error {
+	ids := map[uint8]bool{}
+	for _, device := range devices {
+		if err := device.Validate(); err != nil {
+			return err
+		}
+		if ids[device.ID] {
+			return fmt.Errorf("device id (%d) is used multiple times", device.ID)
+		}
+		ids[device.ID] = true
+	}
+	return nil
+}
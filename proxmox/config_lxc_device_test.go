@@ -0,0 +1,33 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LxcDevice_mapToApiValues(t *testing.T) {
+	require.Equal(t, "path=/dev/ttyUSB0", LxcDevice{Path: "/dev/ttyUSB0"}.mapToApiValues())
+	require.Equal(t, "path=/dev/ttyUSB0,mode=0666,uid=100000,gid=100020", LxcDevice{Path: "/dev/ttyUSB0", Mode: "0666", Uid: 100000, Gid: 100020}.mapToApiValues())
+}
+
+func Test_LxcDevice_mapToStruct(t *testing.T) {
+	require.Equal(t, LxcDevice{ID: 1, Path: "/dev/ttyUSB0", Mode: "0666"}, LxcDevice{}.mapToStruct(1, "/dev/ttyUSB0,mode=0666"))
+	require.Equal(t, LxcDevice{ID: 0, Path: "/dev/dri/renderD128", Uid: 1000, Gid: 44}, LxcDevice{}.mapToStruct(0, "path=/dev/dri/renderD128,uid=1000,gid=44"))
+}
+
+func Test_LxcDevice_Validate(t *testing.T) {
+	require.NoError(t, LxcDevice{Path: "/dev/ttyUSB0"}.Validate())
+	require.NoError(t, LxcDevice{Path: "/dev/ttyUSB0", Mode: "660"}.Validate())
+	require.Error(t, LxcDevice{Path: "dev/ttyUSB0"}.Validate())
+	require.Error(t, LxcDevice{Path: "/dev/ttyUSB0", Mode: "0888"}.Validate())
+	require.Error(t, LxcDevice{Path: "/dev/ttyUSB0", Mode: "rw"}.Validate())
+	require.Error(t, validateLxcDevices([]LxcDevice{{ID: 0, Path: "/dev/a"}, {ID: 0, Path: "/dev/b"}}))
+}
+
+func Test_readLxcDevices(t *testing.T) {
+	require.Equal(t, []LxcDevice{
+		{ID: 0, Path: "/dev/ttyUSB0"},
+		{ID: 2, Path: "/dev/ttyUSB1", Mode: "0666"},
+	}, readLxcDevices(map[string]interface{}{"dev2": "/dev/ttyUSB1,mode=0666", "dev0": "path=/dev/ttyUSB0", "hostname": "test"}))
+}
@@ -0,0 +1,106 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+const Error_LxcFeaturesRunning string = "features of a running container can not be changed, stop the container first"
+
+// Features of a container, nesting and keyctl are required to run Docker inside of an unprivileged container.
+type LxcFeatures struct {
+	Fuse   bool `json:"fuse,omitempty"`
+	Keyctl bool `json:"keyctl,omitempty"`
+	Mknod  bool `json:"mknod,omitempty"`
+	// Filesystem types that may be mounted inside the container (e.g. "nfs", "cifs").
+	Mount   []string `json:"mount,omitempty"`
+	Nesting bool     `json:"nesting,omitempty"`
+}
+
+func (features LxcFeatures) mapToApiValues() string {
+	settings := []string{}
+	if features.Fuse {
+		settings = append(settings, "fuse=1")
+	}
+	if features.Keyctl {
+		settings = append(settings, "keyctl=1")
+	}
+	if features.Mknod {
+		settings = append(settings, "mknod=1")
+	}
+	if len(features.Mount) > 0 {
+		mount := append([]string{}, features.Mount...)
+		sort.Strings(mount)
+		settings = append(settings, "mount="+strings.Join(mount, ";"))
+	}
+	if features.Nesting {
+		settings = append(settings, "nesting=1")
+	}
+	return strings.Join(settings, ",")
+}
+
+func (LxcFeatures) mapToStruct(setting string) *LxcFeatures {
+	features := LxcFeatures{}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, _ := strings.Cut(e, "=")
+		switch key {
+		case "fuse":
+			features.Fuse = value == "1"
+		case "keyctl":
+			features.Keyctl = value == "1"
+		case "mknod":
+			features.Mknod = value == "1"
+		case "mount":
+			features.Mount = strings.Split(value, ";")
+		case "nesting":
+			features.Nesting = value == "1"
+		}
+	}
+	return &features
+}
+
+func (features LxcFeatures) Validate(unprivileged bool) error {
+	if features.Keyctl && !unprivileged {
+		return errors.New("feature keyctl is only available for unprivileged containers")
+	}
+	for _, fs := range features.Mount {
+		if fs == "" || strings.ContainsAny(fs, ",;=") {
+			return errors.New("feature mount may only contain filesystem types")
+		}
+	}
+	return nil
+}
+
+// Validates that the features can be applied to the existing container.
+// Returns Error_LxcFeaturesRunning when the features would change while the container is running.
+func (features LxcFeatures) validateUpdate(ctx context.Context, vmr *VmRef, client *Client) error {
+	currentConfig, err := client.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return err
+	}
+	// unprivileged can't be changed, so the current value applies
+	unprivileged := false
+	if _, isSet := currentConfig["unprivileged"]; isSet {
+		unprivileged = Itob(int(currentConfig["unprivileged"].(float64)))
+	}
+	if err = features.Validate(unprivileged); err != nil {
+		return err
+	}
+	current := LxcFeatures{}
+	if _, isSet := currentConfig["features"]; isSet {
+		current = *LxcFeatures{}.mapToStruct(currentConfig["features"].(string))
+	}
+	if current.mapToApiValues() == features.mapToApiValues() {
+		return nil
+	}
+	vmState, err := client.GetVmState(ctx, vmr)
+	if err != nil {
+		return err
+	}
+	if vmState["status"] == "running" {
+		return errors.New(Error_LxcFeaturesRunning)
+	}
+	return nil
+}
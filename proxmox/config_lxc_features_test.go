@@ -0,0 +1,27 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LxcFeatures_mapToApiValues(t *testing.T) {
+	require.Equal(t, "", LxcFeatures{}.mapToApiValues())
+	require.Equal(t, "keyctl=1,nesting=1", LxcFeatures{Keyctl: true, Nesting: true}.mapToApiValues())
+	require.Equal(t, "fuse=1,mknod=1,mount=cifs;nfs", LxcFeatures{Fuse: true, Mknod: true, Mount: []string{"nfs", "cifs"}}.mapToApiValues())
+}
+
+func Test_LxcFeatures_mapToStruct(t *testing.T) {
+	require.Equal(t, &LxcFeatures{Keyctl: true, Nesting: true}, LxcFeatures{}.mapToStruct("nesting=1,keyctl=1"))
+	require.Equal(t, &LxcFeatures{Fuse: true, Mount: []string{"nfs", "cifs"}}, LxcFeatures{}.mapToStruct("fuse=1,mount=nfs;cifs,nesting=0"))
+}
+
+func Test_LxcFeatures_Validate(t *testing.T) {
+	require.NoError(t, LxcFeatures{Keyctl: true, Nesting: true}.Validate(true))
+	require.NoError(t, LxcFeatures{Nesting: true, Mount: []string{"nfs"}}.Validate(false))
+	require.Equal(t, errors.New("feature keyctl is only available for unprivileged containers"), LxcFeatures{Keyctl: true}.Validate(false))
+	require.Error(t, LxcFeatures{Mount: []string{"nfs;cifs"}}.Validate(true))
+	require.Error(t, LxcFeatures{Mount: []string{""}}.Validate(true))
+}
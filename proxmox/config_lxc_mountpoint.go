@@ -0,0 +1,165 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var lxcMountOptions = []string{"discard", "lazytime", "noatime", "nodev", "noexec", "nosuid"}
+
+// Mount point (mpN) of a container.
+// Either a bind mount of a host path (Volume starts with "/"), an existing volume (Volume is "storage:volume"),
+// or a new volume of Size that is allocated on Storage.
+type LxcMountPoint struct {
+	ID      uint8  `json:"id"`
+	Volume  string `json:"volume,omitempty"`
+	Storage string `json:"storage,omitempty"`
+	// Size of the volume in Proxmox format (e.g. "8G"), not applicable to bind mounts.
+	Size string `json:"size,omitempty"`
+	// Path inside the container.
+	Path string `json:"path"`
+	// nil uses the default of the filesystem.
+	ACL          *bool    `json:"acl,omitempty"`
+	Backup       bool     `json:"backup,omitempty"`
+	MountOptions []string `json:"mountoptions,omitempty"`
+	Quota        bool     `json:"quota,omitempty"`
+	ReadOnly     bool     `json:"ro,omitempty"`
+	// nil uses the default of Proxmox, which is to replicate the volume.
+	Replicate *bool `json:"replicate,omitempty"`
+	// Marks a volume that is available on all nodes, so the container can be migrated without moving it.
+	Shared bool `json:"shared,omitempty"`
+}
+
+func (mp LxcMountPoint) IsBindMount() bool {
+	return strings.HasPrefix(mp.Volume, "/")
+}
+
+func (mp LxcMountPoint) mapToApiValues() string {
+	var settings []string
+	if mp.Volume != "" {
+		settings = append(settings, mp.Volume)
+	} else {
+		// new volumes are allocated as storage:size, with the size in GiB
+		settings = append(settings, fmt.Sprintf("%s:%v", mp.Storage, DiskSizeGB(mp.Size)))
+	}
+	settings = append(settings, "mp="+mp.Path)
+	if mp.ACL != nil {
+		settings = append(settings, "acl="+boolToIntString(*mp.ACL))
+	}
+	if mp.Backup {
+		settings = append(settings, "backup=1")
+	}
+	if len(mp.MountOptions) > 0 {
+		settings = append(settings, "mountoptions="+strings.Join(mp.MountOptions, ";"))
+	}
+	if mp.Quota {
+		settings = append(settings, "quota=1")
+	}
+	if mp.Replicate != nil {
+		settings = append(settings, "replicate="+boolToIntString(*mp.Replicate))
+	}
+	if mp.ReadOnly {
+		settings = append(settings, "ro=1")
+	}
+	if mp.Shared {
+		settings = append(settings, "shared=1")
+	}
+	if mp.Volume != "" && !mp.IsBindMount() && mp.Size != "" {
+		settings = append(settings, "size="+mp.Size)
+	}
+	return strings.Join(settings, ",")
+}
+
+func (LxcMountPoint) mapToStruct(id uint8, setting string) LxcMountPoint {
+	mp := LxcMountPoint{ID: id}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, hasValue := strings.Cut(e, "=")
+		// the volume is the default key
+		if !hasValue {
+			key, value = "volume", key
+		}
+		switch key {
+		case "volume":
+			mp.Volume = value
+		case "mp":
+			mp.Path = value
+		case "acl":
+			acl := value == "1"
+			mp.ACL = &acl
+		case "backup":
+			mp.Backup = value == "1"
+		case "mountoptions":
+			mp.MountOptions = strings.Split(value, ";")
+		case "quota":
+			mp.Quota = value == "1"
+		case "replicate":
+			replicate := value == "1"
+			mp.Replicate = &replicate
+		case "ro":
+			mp.ReadOnly = value == "1"
+		case "shared":
+			mp.Shared = value == "1"
+		case "size":
+			mp.Size = value
+		}
+	}
+	if !mp.IsBindMount() {
+		mp.Storage, _, _ = strings.Cut(mp.Volume, ":")
+	}
+	return mp
+}
+
+func (mp LxcMountPoint) Validate() error {
+	if !strings.HasPrefix(mp.Path, "/") {
+		return fmt.Errorf("mount point (%d) path must be an absolute path inside the container", mp.ID)
+	}
+	if mp.Volume == "" && (mp.Storage == "" || mp.Size == "") {
+		return fmt.Errorf("mount point (%d) must have a volume, or a storage and size for a new volume", mp.ID)
+	}
+	if mp.IsBindMount() && (mp.Size != "" || mp.Quota) {
+		return fmt.Errorf("mount point (%d) is a bind mount, which has no size or quota", mp.ID)
+	}
+	for _, option := range mp.MountOptions {
+		if !inArray(lxcMountOptions, option) {
+			return errors.New("mount point mountoptions must be in (" + strings.Join(lxcMountOptions, ",") + ")")
+		}
+	}
+	return nil
+}
+
+func boolToIntString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// Parses all mount points from the container config, sorted by ID.
+func readLxcMountPoints(lxcConfig map[string]interface{}) []LxcMountPoint {
+	mps := []LxcMountPoint{}
+	for k, v := range lxcConfig {
+		if rxMpName.MatchString(k) {
+			var id uint8
+			fmt.Sscanf(k, "mp%d", &id)
+			mps = append(mps, LxcMountPoint{}.mapToStruct(id, v.(string)))
+		}
+	}
+	sort.Slice(mps, func(i, j int) bool { return mps[i].ID < mps[j].ID })
+	return mps
+}
+
+func validateLxcMountPoints(mps []LxcMountPoint) error {
+	ids := map[uint8]bool{}
+	for _, mp := range mps {
+		if err := mp.Validate(); err != nil {
+			return err
+		}
+		if ids[mp.ID] {
+			return fmt.Errorf("mount point id (%d) is used multiple times", mp.ID)
+		}
+		ids[mp.ID] = true
+	}
+	return nil
+}
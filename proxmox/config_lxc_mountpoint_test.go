@@ -0,0 +1,69 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LxcMountPoint_mapToApiValues(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		input  LxcMountPoint
+		output string
+	}{
+		{input: LxcMountPoint{Volume: "/mnt/data", Path: "/data"}, output: "/mnt/data,mp=/data"},
+		{input: LxcMountPoint{Storage: "local-lvm", Size: "8G", Path: "/data"}, output: "local-lvm:8,mp=/data"},
+		{input: LxcMountPoint{Volume: "local-lvm:vm-100-disk-1", Size: "8G", Path: "/data", ACL: &enabled, Backup: true, MountOptions: []string{"noatime", "nodev"}, Quota: true, Replicate: &disabled, ReadOnly: true, Shared: true},
+			output: "local-lvm:vm-100-disk-1,mp=/data,acl=1,backup=1,mountoptions=noatime;nodev,quota=1,replicate=0,ro=1,shared=1,size=8G"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.output, test.input.mapToApiValues())
+	}
+}
+
+func Test_LxcMountPoint_mapToStruct(t *testing.T) {
+	enabled, disabled := true, false
+	require.Equal(t, LxcMountPoint{ID: 1, Volume: "/mnt/data", Path: "/data", ReadOnly: true}, LxcMountPoint{}.mapToStruct(1, "/mnt/data,mp=/data,ro=1"))
+	require.Equal(t, LxcMountPoint{ID: 0, Volume: "local-lvm:vm-100-disk-1", Storage: "local-lvm", Size: "8G", Path: "/data", ACL: &enabled, Backup: true, MountOptions: []string{"noatime", "nodev"}, Quota: true, Replicate: &disabled, Shared: true},
+		LxcMountPoint{}.mapToStruct(0, "local-lvm:vm-100-disk-1,mp=/data,acl=1,backup=1,mountoptions=noatime;nodev,quota=1,replicate=0,shared=1,size=8G"))
+}
+
+func Test_LxcMountPoint_RoundTrip(t *testing.T) {
+	for _, setting := range []string{
+		"/mnt/data,mp=/data,ro=1",
+		"local-lvm:vm-100-disk-1,mp=/data,acl=0,backup=1,mountoptions=noatime,quota=1,replicate=0,shared=1,size=8G",
+	} {
+		require.Equal(t, setting, LxcMountPoint{}.mapToStruct(0, setting).mapToApiValues())
+	}
+}
+
+func Test_LxcMountPoint_Validate(t *testing.T) {
+	tests := []struct {
+		input LxcMountPoint
+		err   bool
+	}{
+		{input: LxcMountPoint{Volume: "/mnt/data", Path: "/data"}},
+		{input: LxcMountPoint{Storage: "local-lvm", Size: "8G", Path: "/data", MountOptions: []string{"noexec"}}},
+		{input: LxcMountPoint{Volume: "/mnt/data"}, err: true},
+		{input: LxcMountPoint{Volume: "/mnt/data", Path: "data"}, err: true},
+		{input: LxcMountPoint{Storage: "local-lvm", Path: "/data"}, err: true},
+		{input: LxcMountPoint{Volume: "/mnt/data", Path: "/data", Size: "8G"}, err: true},
+		{input: LxcMountPoint{Volume: "/mnt/data", Path: "/data", MountOptions: []string{"rw"}}, err: true},
+	}
+	for _, test := range tests {
+		if test.err {
+			require.Error(t, test.input.Validate(), test.input)
+		} else {
+			require.NoError(t, test.input.Validate(), test.input)
+		}
+	}
+	require.Error(t, validateLxcMountPoints([]LxcMountPoint{{ID: 1, Volume: "/a", Path: "/a"}, {ID: 1, Volume: "/b", Path: "/b"}}))
+}
+
+func Test_readLxcMountPoints(t *testing.T) {
+	require.Equal(t, []LxcMountPoint{
+		{ID: 0, Volume: "/mnt/a", Path: "/a"},
+		{ID: 10, Volume: "/mnt/b", Path: "/b"},
+	}, readLxcMountPoints(map[string]interface{}{"mp10": "/mnt/b,mp=/b", "mp0": "/mnt/a,mp=/a", "hostname": "test"}))
+}
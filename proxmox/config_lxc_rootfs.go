@@ -0,0 +1,122 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Root filesystem of a container.
+// Either an existing volume (Volume is "storage:volume"), or a new volume of Size that is allocated on Storage.
+type LxcRootFs struct {
+	Volume  string `json:"volume,omitempty"`
+	Storage string `json:"storage,omitempty"`
+	// Size of the volume in Proxmox format (e.g. "8G").
+	Size string `json:"size,omitempty"`
+	// nil uses the default of the filesystem.
+	ACL          *bool    `json:"acl,omitempty"`
+	MountOptions []string `json:"mountoptions,omitempty"`
+	Quota        bool     `json:"quota,omitempty"`
+	ReadOnly     bool     `json:"ro,omitempty"`
+	// nil uses the default of Proxmox, which is to replicate the volume.
+	Replicate *bool `json:"replicate,omitempty"`
+	Shared    bool  `json:"shared,omitempty"`
+}
+
+func (rootfs LxcRootFs) mapToApiValues() string {
+	var settings []string
+	if rootfs.Volume != "" {
+		settings = append(settings, rootfs.Volume)
+	} else {
+		// new volumes are allocated as storage:size, with the size in GiB
+		settings = append(settings, fmt.Sprintf("%s:%v", rootfs.Storage, DiskSizeGB(rootfs.Size)))
+	}
+	if rootfs.ACL != nil {
+		settings = append(settings, "acl="+boolToIntString(*rootfs.ACL))
+	}
+	if len(rootfs.MountOptions) > 0 {
+		settings = append(settings, "mountoptions="+strings.Join(rootfs.MountOptions, ";"))
+	}
+	if rootfs.Quota {
+		settings = append(settings, "quota=1")
+	}
+	if rootfs.Replicate != nil {
+		settings = append(settings, "replicate="+boolToIntString(*rootfs.Replicate))
+	}
+	if rootfs.ReadOnly {
+		settings = append(settings, "ro=1")
+	}
+	if rootfs.Shared {
+		settings = append(settings, "shared=1")
+	}
+	if rootfs.Volume != "" && rootfs.Size != "" {
+		settings = append(settings, "size="+rootfs.Size)
+	}
+	return strings.Join(settings, ",")
+}
+
+func (LxcRootFs) mapToStruct(setting string) *LxcRootFs {
+	rootfs := LxcRootFs{}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, hasValue := strings.Cut(e, "=")
+		// the volume is the default key
+		if !hasValue {
+			key, value = "volume", key
+		}
+		switch key {
+		case "volume":
+			rootfs.Volume = value
+		case "acl":
+			acl := value == "1"
+			rootfs.ACL = &acl
+		case "mountoptions":
+			rootfs.MountOptions = strings.Split(value, ";")
+		case "quota":
+			rootfs.Quota = value == "1"
+		case "replicate":
+			replicate := value == "1"
+			rootfs.Replicate = &replicate
+		case "ro":
+			rootfs.ReadOnly = value == "1"
+		case "shared":
+			rootfs.Shared = value == "1"
+		case "size":
+			rootfs.Size = value
+		}
+	}
+	rootfs.Storage, _, _ = strings.Cut(rootfs.Volume, ":")
+	return &rootfs
+}
+
+func (rootfs LxcRootFs) Validate() error {
+	if rootfs.Volume == "" && (rootfs.Storage == "" || rootfs.Size == "") {
+		return errors.New("rootfs must have a volume, or a storage and size for a new volume")
+	}
+	if strings.HasPrefix(rootfs.Volume, "/") {
+		return errors.New("rootfs can not be a bind mount")
+	}
+	for _, option := range rootfs.MountOptions {
+		if !inArray(lxcMountOptions, option) {
+			return errors.New("rootfs mountoptions must be in (" + strings.Join(lxcMountOptions, ",") + ")")
+		}
+	}
+	return nil
+}
+
+// ResizeLxcRootFs resizes the root filesystem of the container.
+// Size is in Proxmox format, prefix it with "+" to grow the volume by that amount (e.g. "+2G").
+// Shrinking the volume is not permitted.
+func (c *Client) ResizeLxcRootFs(ctx context.Context, vmr *VmRef, size string) (exitStatus interface{}, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	err = c.CheckVmRef(ctx, vmr)
+	if err != nil {
+		return
+	}
+	if vmr.vmType != "lxc" {
+		return nil, fmt.Errorf("guest %d is not an LXC container", vmr.vmId)
+	}
+	return c.ResizeQemuDiskRaw(ctx, vmr, "rootfs", size)
+}
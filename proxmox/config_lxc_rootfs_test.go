@@ -0,0 +1,57 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LxcRootFs_mapToApiValues(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		input  LxcRootFs
+		output string
+	}{
+		{input: LxcRootFs{Storage: "local-lvm", Size: "8G"}, output: "local-lvm:8"},
+		{input: LxcRootFs{Volume: "local-lvm:vm-100-disk-0", Size: "8G", ACL: &enabled, MountOptions: []string{"noatime", "nodev"}, Quota: true, Replicate: &disabled, ReadOnly: true, Shared: true},
+			output: "local-lvm:vm-100-disk-0,acl=1,mountoptions=noatime;nodev,quota=1,replicate=0,ro=1,shared=1,size=8G"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.output, test.input.mapToApiValues())
+	}
+}
+
+func Test_LxcRootFs_mapToStruct(t *testing.T) {
+	enabled := true
+	require.Equal(t, &LxcRootFs{Volume: "local-lvm:vm-100-disk-0", Storage: "local-lvm", Size: "8G", ACL: &enabled, MountOptions: []string{"noatime"}, Quota: true},
+		LxcRootFs{}.mapToStruct("local-lvm:vm-100-disk-0,acl=1,mountoptions=noatime,quota=1,size=8G"))
+}
+
+func Test_LxcRootFs_RoundTrip(t *testing.T) {
+	for _, setting := range []string{
+		"local-lvm:vm-100-disk-0,size=8G",
+		"local-lvm:vm-100-disk-0,acl=0,mountoptions=discard;noatime,quota=1,replicate=0,ro=1,shared=1,size=8G",
+	} {
+		require.Equal(t, setting, LxcRootFs{}.mapToStruct(setting).mapToApiValues())
+	}
+}
+
+func Test_LxcRootFs_Validate(t *testing.T) {
+	tests := []struct {
+		input LxcRootFs
+		err   bool
+	}{
+		{input: LxcRootFs{Volume: "local-lvm:vm-100-disk-0"}},
+		{input: LxcRootFs{Storage: "local-lvm", Size: "8G", MountOptions: []string{"noatime"}}},
+		{input: LxcRootFs{Storage: "local-lvm"}, err: true},
+		{input: LxcRootFs{Volume: "/mnt/data"}, err: true},
+		{input: LxcRootFs{Volume: "local-lvm:vm-100-disk-0", MountOptions: []string{"invalid"}}, err: true},
+	}
+	for _, test := range tests {
+		if test.err {
+			require.Error(t, test.input.Validate(), test.input)
+		} else {
+			require.NoError(t, test.input.Validate(), test.input)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test the validation of the clone options against the source container
+func Test_ConfigLxcClone_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ConfigLxcClone
+		template bool
+		err      error
+	}{
+		{name: "full clone of container", input: ConfigLxcClone{Full: true, Storage: "local-lvm"}},
+		{name: "full clone of template", input: ConfigLxcClone{Full: true}, template: true},
+		{name: "linked clone of template", input: ConfigLxcClone{}, template: true},
+		{name: "linked clone of container", input: ConfigLxcClone{},
+			err: errors.New("a linked clone can only be created from a template, set full to clone a regular container")},
+		{name: "linked clone with storage", input: ConfigLxcClone{Storage: "local-lvm"}, template: true,
+			err: errors.New("storage can only be specified for a full clone")},
+		{name: "negative bwlimit", input: ConfigLxcClone{Full: true, BwLimit: -1},
+			err: errors.New("bwlimit may not be negative")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(*testing.T) {
+			require.Equal(t, test.err, test.input.Validate(test.template))
+		})
+	}
+}
+
+// Test the mapping of the clone options to the API values
+func Test_ConfigLxcClone_mapToApiValues(t *testing.T) {
+	input := ConfigLxcClone{Full: true, Hostname: "ct01", Pool: "pool", SnapName: "snap", Storage: "local", Target: "pve2", BwLimit: 1024}
+	output := "bwlimit=1024&full=1&hostname=ct01&newid=150&pool=pool&snapname=snap&storage=local&target=pve2"
+	require.Equal(t, output, ParamsToValues(input.mapToApiValues(150)).Encode())
+	require.Equal(t, "full=0&newid=150", ParamsToValues(ConfigLxcClone{}.mapToApiValues(150)).Encode())
+}
+
+func Test_ConfigLxc_mapToApiValues_Startup(t *testing.T) {
+	config := NewConfigLxc()
+	config.OnBoot = true
+	config.Startup = "order=1"
+	require.Equal(t, "order=1", config.mapToApiValues()["startup"])
+	config.StartupConfig = &StartupConfig{Order: 2, UpDelay: 30}
+	params := config.mapToApiValues()
+	require.Equal(t, true, params["onboot"])
+	require.Equal(t, "order=2,up=30", params["startup"])
+	require.NotContains(t, params, "startup_config")
+	_, isSet := NewConfigLxc().mapToApiValues()["startup"]
+	require.False(t, isSet)
+}
@@ -81,6 +81,7 @@ func (config *ConfigMetrics) RemoveMetricsNestedStructs() {
 	}
 }
 
+// Validates the common settings and the settings of the backend matching the Type.
 func (config *ConfigMetrics) ValidateMetrics() (err error) {
 	err = ValidateStringInArray([]string{"graphite", "influxdb"}, config.Type, "type")
 	if err != nil {
@@ -90,28 +91,40 @@ func (config *ConfigMetrics) ValidateMetrics() (err error) {
 	if err != nil {
 		return
 	}
-	err = ValidateStringInArray([]string{"udp", "tcp"}, config.Graphite.Protocol, "graphite:{ protocol }")
+	err = ValidateIntInRange(1, 65536, config.Port, "port")
 	if err != nil {
 		return
 	}
-	err = ValidateStringInArray([]string{"udp", "http", "https"}, config.InfluxDB.Protocol, "influxdb:{ protocol }")
+	err = ValidateIntInRange(512, 65536, config.MTU, "mtu")
 	if err != nil {
 		return
 	}
-	err = ValidateIntInRange(1, 65536, config.Port, "port")
+	err = ValidateIntGreaterOrEquals(0, config.Timeout, "timeout")
 	if err != nil {
 		return
 	}
-	err = ValidateIntGreaterOrEquals(1, config.InfluxDB.MaxBodySize, "influxdb:{ max-body-size }")
-	if err != nil {
-		return
+	if config.Type == "graphite" {
+		return config.Graphite.validate()
 	}
-	err = ValidateIntInRange(512, 65536, config.MTU, "mtu")
+	return config.InfluxDB.validate()
+}
+
+func (graphite *ConfigMetricsGraphite) validate() error {
+	if graphite == nil {
+		return ErrorKeyEmpty("graphite")
+	}
+	return ValidateStringInArray([]string{"udp", "tcp"}, graphite.Protocol, "graphite:{ protocol }")
+}
+
+func (influxdb *ConfigMetricsInfluxDB) validate() (err error) {
+	if influxdb == nil {
+		return ErrorKeyEmpty("influxdb")
+	}
+	err = ValidateStringInArray([]string{"udp", "http", "https"}, influxdb.Protocol, "influxdb:{ protocol }")
 	if err != nil {
 		return
 	}
-	err = ValidateIntGreaterOrEquals(0, config.Timeout, "timeout")
-	return
+	return ValidateIntGreaterOrEquals(1, influxdb.MaxBodySize, "influxdb:{ max-body-size }")
 }
 
 func (config *ConfigMetrics) SetMetrics(metricsId string, client *Client) (err error) {
@@ -189,12 +202,41 @@ func NewConfigMetricsFromApi(metricsId string, client *Client) (config *ConfigMe
 	if err != nil {
 		return nil, err
 	}
+	return metricsFromApi(metricsId, rawConfig), nil
+}
+
+// ListMetricServers returns the metric servers of the cluster.
+// Proxmox only lists the common settings, use NewConfigMetricsFromApi for the backend settings.
+func (c *Client) ListMetricServers(ctx context.Context) ([]ConfigMetrics, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/cluster/metrics/server")
+	if err != nil {
+		return nil, err
+	}
+	servers := make([]ConfigMetrics, len(list))
+	for i, e := range list {
+		rawConfig := e.(map[string]interface{})
+		id, _ := rawConfig["id"].(string)
+		servers[i] = *metricsFromApi(id, rawConfig)
+	}
+	return servers, nil
+}
+
+func metricsFromApi(metricsId string, rawConfig map[string]interface{}) (config *ConfigMetrics) {
 	config = InstantiateConfigMetrics()
 
 	config.Name = metricsId
-	config.Port = int(rawConfig["port"].(float64))
-	config.Server = rawConfig["server"].(string)
-	config.Type = rawConfig["type"].(string)
+	if _, isSet := rawConfig["port"]; isSet {
+		config.Port = int(rawConfig["port"].(float64))
+	}
+	if _, isSet := rawConfig["server"]; isSet {
+		config.Server = rawConfig["server"].(string)
+	}
+	if _, isSet := rawConfig["type"]; isSet {
+		config.Type = rawConfig["type"].(string)
+	}
 
 	if _, isSet := rawConfig["disable"]; isSet {
 		config.Enable = BoolInvert(Itob(int(rawConfig["disable"].(float64))))
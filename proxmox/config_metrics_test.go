@@ -0,0 +1,76 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigMetrics_ValidateMetrics(t *testing.T) {
+	graphite := func() *ConfigMetrics {
+		config := InstantiateConfigMetrics()
+		config.Type = "graphite"
+		config.Server = "graphite.example.com"
+		config.Port = 2003
+		config.InfluxDB = nil
+		return config
+	}
+	influxdb := func(protocol string) *ConfigMetrics {
+		config := InstantiateConfigMetrics()
+		config.Type = "influxdb"
+		config.Server = "influx.example.com"
+		config.Port = 8086
+		config.InfluxDB.Protocol = protocol
+		config.Graphite = nil
+		return config
+	}
+	require.NoError(t, graphite().ValidateMetrics())
+	require.NoError(t, influxdb("udp").ValidateMetrics())
+	require.NoError(t, influxdb("https").ValidateMetrics())
+
+	testData := []struct {
+		name   string
+		config *ConfigMetrics
+		change func(*ConfigMetrics)
+	}{
+		{name: "invalid type", config: graphite(), change: func(c *ConfigMetrics) { c.Type = "prometheus" }},
+		{name: "empty server", config: graphite(), change: func(c *ConfigMetrics) { c.Server = "" }},
+		{name: "invalid port", config: graphite(), change: func(c *ConfigMetrics) { c.Port = 0 }},
+		{name: "invalid mtu", config: graphite(), change: func(c *ConfigMetrics) { c.MTU = 100 }},
+		{name: "negative timeout", config: graphite(), change: func(c *ConfigMetrics) { c.Timeout = -1 }},
+		{name: "graphite without settings", config: graphite(), change: func(c *ConfigMetrics) { c.Graphite = nil }},
+		{name: "graphite invalid protocol", config: graphite(), change: func(c *ConfigMetrics) { c.Graphite.Protocol = "http" }},
+		{name: "influxdb without settings", config: influxdb("udp"), change: func(c *ConfigMetrics) { c.InfluxDB = nil }},
+		{name: "influxdb invalid protocol", config: influxdb("tcp"), change: func(*ConfigMetrics) {}},
+		{name: "influxdb invalid max-body-size", config: influxdb("udp"), change: func(c *ConfigMetrics) { c.InfluxDB.MaxBodySize = 0 }},
+	}
+	for _, e := range testData {
+		t.Run(e.name, func(*testing.T) {
+			e.change(e.config)
+			require.Error(t, e.config.ValidateMetrics())
+		})
+	}
+	// Proxmox defaults the bucket and organization to "proxmox"
+	http := influxdb("http")
+	http.InfluxDB.Bucket = ""
+	http.InfluxDB.Organization = ""
+	require.NoError(t, http.ValidateMetrics())
+}
+
+func Test_metricsFromApi(t *testing.T) {
+	config := metricsFromApi("influx", map[string]interface{}{
+		"id":            "influx",
+		"type":          "influxdb",
+		"server":        "influx.example.com",
+		"port":          float64(8086),
+		"disable":       float64(1),
+		"influxdbproto": "https",
+		"bucket":        "pve",
+	})
+	require.Equal(t, "influx", config.Name)
+	require.Equal(t, 8086, config.Port)
+	require.False(t, config.Enable)
+	require.Nil(t, config.Graphite)
+	require.Equal(t, "https", config.InfluxDB.Protocol)
+	require.Equal(t, "pve", config.InfluxDB.Bucket)
+}
@@ -18,6 +18,7 @@ type ConfigNetwork struct {
 	BondMode           string `json:"bond_mode,omitempty"`
 	BondXmitHashPolicy string `json:"bond_xmit_hash_policy,omitempty"`
 	BridgePorts        string `json:"bridge_ports,omitempty"`
+	BridgeVids         string `json:"bridge_vids,omitempty"`
 	BridgeVlanAware    bool   `json:"bridge_vlan_aware,omitempty"`
 	CIDR               string `json:"cidr,omitempty"`
 	CIDR6              string `json:"cidr6,omitempty"`
@@ -84,3 +85,14 @@ func (config ConfigNetwork) UpdateNetwork(ctx context.Context, client *Client) (
 	}
 	return
 }
+
+// DeleteNetwork deletes the network interface with the stored name from the
+// Proxmox host.
+// It returns an error if deleting the network fails.
+func (config ConfigNetwork) DeleteNetwork(ctx context.Context, client *Client) (err error) {
+	exitStatus, err := client.DeleteNetwork(ctx, config.Node, config.Iface)
+	if err != nil {
+		return fmt.Errorf("error deleting network: %v\n\t\t api response: %s", err, exitStatus)
+	}
+	return
+}
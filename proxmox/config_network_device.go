@@ -0,0 +1,231 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Address settings shared by all types of node network interfaces.
+type NetworkInterfaceSettings struct {
+	Address   string `json:"address,omitempty"`
+	Address6  string `json:"address6,omitempty"`
+	Autostart bool   `json:"autostart,omitempty"`
+	CIDR      string `json:"cidr,omitempty"`
+	CIDR6     string `json:"cidr6,omitempty"`
+	Comments  string `json:"comments,omitempty"`
+	Gateway   string `json:"gateway,omitempty"`
+	Gateway6  string `json:"gateway6,omitempty"`
+	MTU       int    `json:"mtu,omitempty"`
+}
+
+func (settings NetworkInterfaceSettings) mapToConfig(node, iface, ifaceType string) ConfigNetwork {
+	return ConfigNetwork{
+		Iface:     iface,
+		Node:      node,
+		Type:      ifaceType,
+		Address:   settings.Address,
+		Address6:  settings.Address6,
+		Autostart: settings.Autostart,
+		CIDR:      settings.CIDR,
+		CIDR6:     settings.CIDR6,
+		Comments:  settings.Comments,
+		Gateway:   settings.Gateway,
+		Gateway6:  settings.Gateway6,
+		MTU:       settings.MTU,
+	}
+}
+
+func (settings NetworkInterfaceSettings) Validate() error {
+	if settings.MTU != 0 && (settings.MTU < 1280 || settings.MTU > 65520) {
+		return errors.New("mtu must be between 1280 and 65520")
+	}
+	return nil
+}
+
+const (
+	NetworkBondMode_8023ad       string = "802.3ad"
+	NetworkBondMode_ActiveBackup string = "active-backup"
+	NetworkBondMode_BalanceAlb   string = "balance-alb"
+	NetworkBondMode_BalanceRr    string = "balance-rr"
+	NetworkBondMode_BalanceTlb   string = "balance-tlb"
+	NetworkBondMode_BalanceXor   string = "balance-xor"
+	NetworkBondMode_Broadcast    string = "broadcast"
+)
+
+var (
+	networkBondModes          = []string{NetworkBondMode_8023ad, NetworkBondMode_ActiveBackup, NetworkBondMode_BalanceAlb, NetworkBondMode_BalanceRr, NetworkBondMode_BalanceTlb, NetworkBondMode_BalanceXor, NetworkBondMode_Broadcast}
+	networkBondXmitHashPolicy = []string{"layer2", "layer2+3", "layer3+4"}
+
+	rxNetworkBridgeName = regexp.MustCompile(`^vmbr\d+$`)
+	rxNetworkBondName   = regexp.MustCompile(`^bond\d+$`)
+	// vlanN needs the raw device, <device>.<tag> has it in its name.
+	rxNetworkVlanName = regexp.MustCompile(`^(vlan\d+|[a-zA-Z][a-zA-Z0-9_]*\.\d+)$`)
+)
+
+// Linux bridge on a node, e.g. vmbr0.
+type NetworkBridge struct {
+	Name      string   `json:"name"`
+	Ports     []string `json:"ports,omitempty"`
+	VlanAware bool     `json:"vlan_aware,omitempty"`
+	// VLAN IDs allowed on a vlan aware bridge, single IDs or ranges (e.g. "2-4094").
+	Vids []string `json:"vids,omitempty"`
+	NetworkInterfaceSettings
+}
+
+func (bridge NetworkBridge) mapToConfig(node string) ConfigNetwork {
+	config := bridge.NetworkInterfaceSettings.mapToConfig(node, bridge.Name, "bridge")
+	config.BridgePorts = strings.Join(bridge.Ports, " ")
+	config.BridgeVlanAware = bridge.VlanAware
+	config.BridgeVids = strings.Join(bridge.Vids, " ")
+	return config
+}
+
+func (bridge NetworkBridge) Validate() error {
+	if !rxNetworkBridgeName.MatchString(bridge.Name) {
+		return fmt.Errorf("bridge name (%s) must be in the format vmbrN", bridge.Name)
+	}
+	if len(bridge.Vids) > 0 && !bridge.VlanAware {
+		return errors.New("bridge vids require the bridge to be vlan aware")
+	}
+	for _, e := range bridge.Vids {
+		if err := validateNetworkVids(e); err != nil {
+			return err
+		}
+	}
+	return bridge.NetworkInterfaceSettings.Validate()
+}
+
+func (bridge NetworkBridge) Create(ctx context.Context, node string, client *Client) error {
+	if err := bridge.Validate(); err != nil {
+		return err
+	}
+	return bridge.mapToConfig(node).CreateNetwork(ctx, client)
+}
+
+func (bridge NetworkBridge) Update(ctx context.Context, node string, client *Client) error {
+	if err := bridge.Validate(); err != nil {
+		return err
+	}
+	return bridge.mapToConfig(node).UpdateNetwork(ctx, client)
+}
+
+// Single VLAN ID or a range of them.
+func validateNetworkVids(vids string) error {
+	for _, e := range strings.SplitN(vids, "-", 2) {
+		id, err := strconv.Atoi(e)
+		if err != nil || id < 1 || id > 4094 {
+			return fmt.Errorf("bridge vids (%s) must be a vlan id or a range of vlan ids between 1 and 4094", vids)
+		}
+	}
+	return nil
+}
+
+// Linux bond on a node, e.g. bond0.
+// The link monitoring interval (miimon) is not exposed by the Proxmox API, it is always 100ms.
+type NetworkBond struct {
+	Name   string   `json:"name"`
+	Slaves []string `json:"slaves"`
+	Mode   string   `json:"mode"`
+	// Only applicable to the active-backup mode.
+	Primary        string `json:"primary,omitempty"`
+	XmitHashPolicy string `json:"xmit_hash_policy,omitempty"`
+	NetworkInterfaceSettings
+}
+
+func (bond NetworkBond) mapToConfig(node string) ConfigNetwork {
+	config := bond.NetworkInterfaceSettings.mapToConfig(node, bond.Name, "bond")
+	config.Slaves = strings.Join(bond.Slaves, " ")
+	config.BondMode = bond.Mode
+	config.BondPrimary = bond.Primary
+	config.BondXmitHashPolicy = bond.XmitHashPolicy
+	return config
+}
+
+func (bond NetworkBond) Validate() error {
+	if !rxNetworkBondName.MatchString(bond.Name) {
+		return fmt.Errorf("bond name (%s) must be in the format bondN", bond.Name)
+	}
+	if len(bond.Slaves) == 0 {
+		return errors.New("bond must have at least one slave")
+	}
+	if !inArray(networkBondModes, bond.Mode) {
+		return errors.New("bond mode must be one of (" + strings.Join(networkBondModes, ",") + ")")
+	}
+	if bond.Primary != "" && bond.Mode != NetworkBondMode_ActiveBackup {
+		return errors.New("bond primary can only be set in the " + NetworkBondMode_ActiveBackup + " mode")
+	}
+	if bond.XmitHashPolicy != "" && !inArray(networkBondXmitHashPolicy, bond.XmitHashPolicy) {
+		return errors.New("bond xmit hash policy must be one of (" + strings.Join(networkBondXmitHashPolicy, ",") + ")")
+	}
+	return bond.NetworkInterfaceSettings.Validate()
+}
+
+func (bond NetworkBond) Create(ctx context.Context, node string, client *Client) error {
+	if err := bond.Validate(); err != nil {
+		return err
+	}
+	return bond.mapToConfig(node).CreateNetwork(ctx, client)
+}
+
+func (bond NetworkBond) Update(ctx context.Context, node string, client *Client) error {
+	if err := bond.Validate(); err != nil {
+		return err
+	}
+	return bond.mapToConfig(node).UpdateNetwork(ctx, client)
+}
+
+// VLAN interface on a node, either named vlanN with a RawDevice and Tag, or named <device>.<tag>.
+type NetworkVlan struct {
+	Name      string `json:"name"`
+	RawDevice string `json:"raw_device,omitempty"`
+	Tag       int    `json:"tag,omitempty"`
+	NetworkInterfaceSettings
+}
+
+func (vlan NetworkVlan) mapToConfig(node string) ConfigNetwork {
+	config := vlan.NetworkInterfaceSettings.mapToConfig(node, vlan.Name, "vlan")
+	config.VlanRawDevice = vlan.RawDevice
+	config.VlanID = vlan.Tag
+	return config
+}
+
+func (vlan NetworkVlan) Validate() error {
+	if !rxNetworkVlanName.MatchString(vlan.Name) {
+		return fmt.Errorf("vlan name (%s) must be in the format vlanN or <device>.<tag>", vlan.Name)
+	}
+	if strings.HasPrefix(vlan.Name, "vlan") && (vlan.RawDevice == "" || vlan.Tag == 0) {
+		return errors.New("vlan named vlanN must have a raw device and tag")
+	}
+	if vlan.Tag != 0 && (vlan.Tag < 1 || vlan.Tag > 4094) {
+		return errors.New("vlan tag must be between 1 and 4094")
+	}
+	return vlan.NetworkInterfaceSettings.Validate()
+}
+
+func (vlan NetworkVlan) Create(ctx context.Context, node string, client *Client) error {
+	if err := vlan.Validate(); err != nil {
+		return err
+	}
+	return vlan.mapToConfig(node).CreateNetwork(ctx, client)
+}
+
+func (vlan NetworkVlan) Update(ctx context.Context, node string, client *Client) error {
+	if err := vlan.Validate(); err != nil {
+		return err
+	}
+	return vlan.mapToConfig(node).UpdateNetwork(ctx, client)
+}
+
+// ApplyNetworkConfig commits the pending network changes of the node, like "ifreload -a".
+// Created, updated and deleted interfaces only take effect after this is called.
+func (c *Client) ApplyNetworkConfig(ctx context.Context, node string) error {
+	exitStatus, err := c.ApplyNetwork(ctx, node)
+	if err != nil {
+		return fmt.Errorf("error applying network config: %v, error status: %s", err, exitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NetworkBridge_mapToConfig(t *testing.T) {
+	input := NetworkBridge{Name: "vmbr1", Ports: []string{"eno1", "eno2"}, VlanAware: true, Vids: []string{"2-100", "200"},
+		NetworkInterfaceSettings: NetworkInterfaceSettings{CIDR: "10.0.0.2/24", Gateway: "10.0.0.1", Autostart: true}}
+	output := ConfigNetwork{Iface: "vmbr1", Node: "pve", Type: "bridge", Autostart: true, BridgePorts: "eno1 eno2", BridgeVlanAware: true, BridgeVids: "2-100 200", CIDR: "10.0.0.2/24", Gateway: "10.0.0.1"}
+	require.Equal(t, output, input.mapToConfig("pve"))
+	require.Equal(t, "autostart=1&bridge_ports=eno1+eno2&bridge_vids=2-100+200&bridge_vlan_aware=1&cidr=10.0.0.2%2F24&gateway=10.0.0.1&iface=vmbr1&node=pve&type=bridge",
+		ParamsToValues(input.mapToConfig("pve").mapToApiValues()).Encode())
+}
+
+func Test_NetworkBridge_Validate(t *testing.T) {
+	require.NoError(t, NetworkBridge{Name: "vmbr0"}.Validate())
+	require.NoError(t, NetworkBridge{Name: "vmbr0", VlanAware: true, Vids: []string{"2-4094"}}.Validate())
+	require.Error(t, NetworkBridge{Name: "br0"}.Validate())
+	require.Error(t, NetworkBridge{Name: "vmbr0", Vids: []string{"10"}}.Validate())
+	require.Error(t, NetworkBridge{Name: "vmbr0", VlanAware: true, Vids: []string{"0-4094"}}.Validate())
+	require.Error(t, NetworkBridge{Name: "vmbr0", VlanAware: true, Vids: []string{"abc"}}.Validate())
+	require.Error(t, NetworkBridge{Name: "vmbr0", NetworkInterfaceSettings: NetworkInterfaceSettings{MTU: 100}}.Validate())
+}
+
+func Test_NetworkBond_mapToConfig(t *testing.T) {
+	input := NetworkBond{Name: "bond0", Slaves: []string{"eno1", "eno2"}, Mode: NetworkBondMode_8023ad, XmitHashPolicy: "layer3+4"}
+	output := ConfigNetwork{Iface: "bond0", Node: "pve", Type: "bond", Slaves: "eno1 eno2", BondMode: "802.3ad", BondXmitHashPolicy: "layer3+4"}
+	require.Equal(t, output, input.mapToConfig("pve"))
+}
+
+func Test_NetworkBond_Validate(t *testing.T) {
+	require.NoError(t, NetworkBond{Name: "bond0", Slaves: []string{"eno1"}, Mode: NetworkBondMode_ActiveBackup, Primary: "eno1"}.Validate())
+	require.Error(t, NetworkBond{Name: "eth0", Slaves: []string{"eno1"}, Mode: NetworkBondMode_ActiveBackup}.Validate())
+	require.Error(t, NetworkBond{Name: "bond0", Mode: NetworkBondMode_ActiveBackup}.Validate())
+	require.Error(t, NetworkBond{Name: "bond0", Slaves: []string{"eno1"}, Mode: "invalid"}.Validate())
+	require.Error(t, NetworkBond{Name: "bond0", Slaves: []string{"eno1"}, Mode: NetworkBondMode_8023ad, Primary: "eno1"}.Validate())
+	require.Error(t, NetworkBond{Name: "bond0", Slaves: []string{"eno1"}, Mode: NetworkBondMode_8023ad, XmitHashPolicy: "layer4"}.Validate())
+}
+
+func Test_NetworkVlan_mapToConfig(t *testing.T) {
+	input := NetworkVlan{Name: "vlan10", RawDevice: "bond0", Tag: 10}
+	output := ConfigNetwork{Iface: "vlan10", Node: "pve", Type: "vlan", VlanRawDevice: "bond0", VlanID: 10}
+	require.Equal(t, output, input.mapToConfig("pve"))
+}
+
+func Test_NetworkVlan_Validate(t *testing.T) {
+	require.NoError(t, NetworkVlan{Name: "vlan10", RawDevice: "bond0", Tag: 10}.Validate())
+	require.NoError(t, NetworkVlan{Name: "eno1.20"}.Validate())
+	require.Error(t, NetworkVlan{Name: "vlan10"}.Validate())
+	require.Error(t, NetworkVlan{Name: "vlan10", RawDevice: "bond0", Tag: 5000}.Validate())
+	require.Error(t, NetworkVlan{Name: "eno1"}.Validate())
+}
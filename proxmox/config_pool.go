@@ -0,0 +1,255 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pools can be nested up to 3 levels deep, e.g. "dev/team/app".
+var rxPoolName = regexp.MustCompile(`^[A-Za-z0-9_\-]+(/[A-Za-z0-9_\-]+){0,2}$`)
+
+// Pool options for the Proxmox API
+type ConfigPool struct {
+	Name    PoolName `json:"name"`
+	Comment string   `json:"comment,omitempty"`
+	// Members of the pool, only used by EnsurePool. When nil the members are left as they are.
+	Guests   []uint   `json:"guests,omitempty"`
+	Storages []string `json:"storages,omitempty"`
+}
+
+// Creates the specified pool
+func (config ConfigPool) Create(ctx context.Context, client *Client) error {
+	if err := config.Name.Validate(); err != nil {
+		return err
+	}
+	return client.CreatePool(ctx, string(config.Name), config.Comment)
+}
+
+// Updates the comment of the specified pool, the members are not changed.
+func (config ConfigPool) Update(ctx context.Context, client *Client) error {
+	if err := config.Name.Validate(); err != nil {
+		return err
+	}
+	return client.UpdatePoolComment(ctx, string(config.Name), config.Comment)
+}
+
+func (ConfigPool) mapToStruct(params map[string]interface{}) *ConfigPool {
+	config := ConfigPool{}
+	if _, isSet := params["poolid"]; isSet {
+		config.Name = PoolName(params["poolid"].(string))
+	}
+	if _, isSet := params["comment"]; isSet {
+		config.Comment = params["comment"].(string)
+	}
+	return &config
+}
+
+// Pool with its members, sorted so two reads can be compared directly.
+type PoolInfo struct {
+	Name     PoolName `json:"name"`
+	Comment  string   `json:"comment,omitempty"`
+	Guests   []uint   `json:"guests"`
+	Storages []string `json:"storages"`
+}
+
+func (PoolInfo) mapToStruct(pool PoolName, params map[string]interface{}) *PoolInfo {
+	info := PoolInfo{Name: pool}
+	if _, isSet := params["comment"]; isSet {
+		info.Comment = params["comment"].(string)
+	}
+	info.Guests, info.Storages = poolMembersFromApi(params)
+	return &info
+}
+
+// ListPools returns the names and comments of all pools.
+func (c *Client) ListPools(ctx context.Context) ([]ConfigPool, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/pools")
+	if err != nil {
+		return nil, err
+	}
+	pools := make([]ConfigPool, len(list))
+	for i, e := range list {
+		pools[i] = *ConfigPool{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return pools, nil
+}
+
+// GetPool returns the pool with the guests and storages that are a member of it.
+func (c *Client) GetPool(ctx context.Context, pool PoolName) (*PoolInfo, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := pool.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := c.GetPoolInfo(ctx, string(pool))
+	if err != nil {
+		return nil, err
+	}
+	return PoolInfo{}.mapToStruct(pool, params), nil
+}
+
+type PoolName string
+
+// Adds the guests to the pool, guests that are already a member are skipped.
+// Proxmox refuses to add a guest that is a member of another pool, unless allowMove is set.
+func (pool PoolName) AddGuests(ctx context.Context, guests []uint, allowMove bool, client *Client) error {
+	current, _, err := pool.members(ctx, client)
+	if err != nil {
+		return err
+	}
+	add := filterGuests(guests, current, false)
+	if len(add) == 0 {
+		return nil
+	}
+	params := map[string]interface{}{"vms": guestsToCsv(add)}
+	if allowMove {
+		params["allow-move"] = true
+	}
+	return pool.update(ctx, params, client)
+}
+
+// Adds the storages to the pool, storages that are already a member are skipped.
+func (pool PoolName) AddStorage(ctx context.Context, storages []string, client *Client) error {
+	_, current, err := pool.members(ctx, client)
+	if err != nil {
+		return err
+	}
+	add := filterStorages(storages, current, false)
+	if len(add) == 0 {
+		return nil
+	}
+	return pool.update(ctx, map[string]interface{}{"storage": strings.Join(add, ",")}, client)
+}
+
+// Removes the guests from the pool, guests that are not a member are skipped.
+func (pool PoolName) RemoveGuests(ctx context.Context, guests []uint, client *Client) error {
+	current, _, err := pool.members(ctx, client)
+	if err != nil {
+		return err
+	}
+	remove := filterGuests(guests, current, true)
+	if len(remove) == 0 {
+		return nil
+	}
+	return pool.update(ctx, map[string]interface{}{"vms": guestsToCsv(remove), "delete": true}, client)
+}
+
+// Removes the storages from the pool, storages that are not a member are skipped.
+func (pool PoolName) RemoveStorage(ctx context.Context, storages []string, client *Client) error {
+	_, current, err := pool.members(ctx, client)
+	if err != nil {
+		return err
+	}
+	remove := filterStorages(storages, current, true)
+	if len(remove) == 0 {
+		return nil
+	}
+	return pool.update(ctx, map[string]interface{}{"storage": strings.Join(remove, ","), "delete": true}, client)
+}
+
+func (pool PoolName) members(ctx context.Context, client *Client) (guests []uint, storages []string, err error) {
+	if err = pool.Validate(); err != nil {
+		return
+	}
+	info, err := client.GetPoolInfo(ctx, string(pool))
+	if err != nil {
+		return
+	}
+	guests, storages = poolMembersFromApi(info)
+	return
+}
+
+func (pool PoolName) update(ctx context.Context, params map[string]interface{}, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return client.Put(ctx, params, "/pools/"+string(pool))
+}
+
+// Check if a pool name is valid.
+func (pool PoolName) Validate() error {
+	if pool == "" {
+		return errors.New("variable of type (PoolName) may not be empty")
+	}
+	if !rxPoolName.MatchString(string(pool)) {
+		return errors.New("pool name (" + string(pool) + ") may only contain letters, digits, '-' and '_', and be nested up to 3 levels with '/'")
+	}
+	return nil
+}
+
+// Map the members of a pool from the API to sorted lists of guest IDs and storages.
+func poolMembersFromApi(info map[string]interface{}) (guests []uint, storages []string) {
+	guests = []uint{}
+	storages = []string{}
+	if _, isSet := info["members"]; !isSet {
+		return
+	}
+	storageSet := map[string]bool{}
+	for _, m := range info["members"].([]interface{}) {
+		member := m.(map[string]interface{})
+		switch member["type"] {
+		case "qemu", "lxc":
+			guests = append(guests, uint(member["vmid"].(float64)))
+		case "storage":
+			// storages are listed once for every node
+			storage := member["storage"].(string)
+			if !storageSet[storage] {
+				storageSet[storage] = true
+				storages = append(storages, storage)
+			}
+		}
+	}
+	sort.Slice(guests, func(i, j int) bool { return guests[i] < guests[j] })
+	sort.Strings(storages)
+	return
+}
+
+// Returns the unique guests that are (isMember) or are not (!isMember) in members.
+func filterGuests(guests, members []uint, isMember bool) []uint {
+	memberSet := map[uint]bool{}
+	for _, e := range members {
+		memberSet[e] = true
+	}
+	seen := map[uint]bool{}
+	filtered := []uint{}
+	for _, e := range guests {
+		if memberSet[e] == isMember && !seen[e] {
+			seen[e] = true
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// Returns the unique storages that are (isMember) or are not (!isMember) in members.
+func filterStorages(storages, members []string, isMember bool) []string {
+	memberSet := map[string]bool{}
+	for _, e := range members {
+		memberSet[e] = true
+	}
+	seen := map[string]bool{}
+	filtered := []string{}
+	for _, e := range storages {
+		if memberSet[e] == isMember && !seen[e] {
+			seen[e] = true
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+func guestsToCsv(guests []uint) string {
+	ids := make([]string, len(guests))
+	for i, e := range guests {
+		ids[i] = strconv.FormatUint(uint64(e), 10)
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,65 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PoolName_Validate(t *testing.T) {
+	for _, e := range []PoolName{"prod", "dev_1-a", "dev/team", "dev/team/app"} {
+		require.NoError(t, e.Validate(), e)
+	}
+	for _, e := range []PoolName{"", "dev team", "dev/", "/dev", "a/b/c/d", "dev.team"} {
+		require.Error(t, e.Validate(), e)
+	}
+}
+
+func Test_poolMembersFromApi(t *testing.T) {
+	guests, storages := poolMembersFromApi(map[string]interface{}{
+		"members": []interface{}{
+			map[string]interface{}{"type": "lxc", "vmid": float64(105)},
+			map[string]interface{}{"type": "qemu", "vmid": float64(100)},
+			map[string]interface{}{"type": "storage", "storage": "nfs", "node": "pve2"},
+			map[string]interface{}{"type": "storage", "storage": "local", "node": "pve1"},
+			map[string]interface{}{"type": "storage", "storage": "nfs", "node": "pve1"},
+		},
+	})
+	require.Equal(t, []uint{100, 105}, guests)
+	require.Equal(t, []string{"local", "nfs"}, storages)
+	guests, storages = poolMembersFromApi(map[string]interface{}{})
+	require.Equal(t, []uint{}, guests)
+	require.Equal(t, []string{}, storages)
+}
+
+func Test_filterGuests(t *testing.T) {
+	require.Equal(t, []uint{102, 103}, filterGuests([]uint{100, 102, 103, 102}, []uint{100, 101}, false))
+	require.Equal(t, []uint{100}, filterGuests([]uint{100, 102, 100}, []uint{100, 101}, true))
+	require.Equal(t, []uint{}, filterGuests(nil, []uint{100}, true))
+}
+
+func Test_filterStorages(t *testing.T) {
+	require.Equal(t, []string{"nfs"}, filterStorages([]string{"local", "nfs", "nfs"}, []string{"local"}, false))
+	require.Equal(t, []string{"local"}, filterStorages([]string{"local", "nfs"}, []string{"local"}, true))
+}
+
+func Test_guestsToCsv(t *testing.T) {
+	require.Equal(t, "100,101", guestsToCsv([]uint{100, 101}))
+	require.Equal(t, "", guestsToCsv([]uint{}))
+}
+
+func Test_ConfigPool_mapToStruct(t *testing.T) {
+	require.Equal(t, &ConfigPool{Name: "prod", Comment: "production"}, ConfigPool{}.mapToStruct(map[string]interface{}{"poolid": "prod", "comment": "production"}))
+	require.Equal(t, &ConfigPool{Name: "dev"}, ConfigPool{}.mapToStruct(map[string]interface{}{"poolid": "dev"}))
+}
+
+func Test_PoolInfo_mapToStruct(t *testing.T) {
+	require.Equal(t, &PoolInfo{Name: "prod", Comment: "production", Guests: []uint{100}, Storages: []string{"local"}},
+		PoolInfo{}.mapToStruct("prod", map[string]interface{}{
+			"comment": "production",
+			"members": []interface{}{
+				map[string]interface{}{"type": "qemu", "vmid": float64(100)},
+				map[string]interface{}{"type": "storage", "storage": "local"},
+			},
+		}))
+}
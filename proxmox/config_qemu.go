@@ -27,9 +27,13 @@ type (
 	IpconfigMap     map[int]interface{}
 )
 
+// Network interface as seen by the guest agent.
+// MTU is 0 when the agent does not report it, Statistics holds the rx/tx counters (rx-bytes, tx-dropped, etc.).
+// The guest agent does not report the link state, see the "link_down" option of the network device instead.
 type AgentNetworkInterface struct {
 	MACAddress  string
 	IPAddresses []net.IP
+	MTU         int
 	Name        string
 	Statistics  map[string]int64
 }
@@ -44,11 +48,13 @@ type ConfigQemu struct {
 	EFIDisk         QemuDevice  `json:"efidisk,omitempty"`
 	Machine         string      `json:"machine,omitempty"`
 	Onboot          *bool       `json:"onboot,omitempty"`
+	Protection      *bool       `json:"protection,omitempty"`
 	Startup         string      `json:"startup,omitempty"`
 	Tablet          *bool       `json:"tablet,omitempty"`
+	Template        bool        `json:"template,omitempty"` // read only, set with Client.ConvertToTemplate
 	Agent           int         `json:"agent,omitempty"`
 	Memory          int         `json:"memory,omitempty"`
-	Balloon         int         `json:"balloon,omitempty"`
+	Balloon         *int        `json:"balloon,omitempty"` // 0 disables the balloon device
 	QemuOs          string      `json:"ostype,omitempty"`
 	QemuCores       int         `json:"cores,omitempty"`
 	QemuSockets     int         `json:"sockets,omitempty"`
@@ -68,49 +74,114 @@ type ConfigQemu struct {
 	QemuVga         QemuDevice  `json:"vga,omitempty"`
 	QemuNetworks    QemuDevices `json:"network,omitempty"`
 	QemuSerials     QemuDevices `json:"serial,omitempty"`
+	QemuParallels   QemuDevices `json:"parallel,omitempty"`
 	QemuUsbs        QemuDevices `json:"usb,omitempty"`
 	QemuPCIDevices  QemuDevices `json:"hostpci,omitempty"`
+	Rng             *QemuRng    `json:"rng,omitempty"`
 	Hookscript      string      `json:"hookscript,omitempty"`
+	VmStateStorage  string      `json:"vmstatestorage,omitempty"`
 	HaState         string      `json:"hastate,omitempty"`
 	HaGroup         string      `json:"hagroup,omitempty"`
 	Tags            string      `json:"tags,omitempty"`
 	Args            string      `json:"args,omitempty"`
 
+	// serial and parallel ports, these take precedence over the untyped QemuSerials and QemuParallels
+	Serials   []QemuSerialPort   `json:"serials,omitempty"`
+	Parallels []QemuParallelPort `json:"parallels,omitempty"`
+
+	// startup order of the guest, this takes precedence over Startup. Whether the guest is started on boot is set by Onboot.
+	// Reading the config only fills Startup, use StartupSettings for the parsed settings.
+	StartupConfig *StartupConfig `json:"startup_config,omitempty"`
+
+	Watchdog *QemuWatchdog `json:"watchdog,omitempty"`
+
+	// the display is set by QemuVga
+	Audio             *QemuAudio             `json:"audio,omitempty"`
+	SpiceEnhancements *QemuSpiceEnhancements `json:"spice_enhancements,omitempty"`
+
 	// cloud-init options
 	CIuser     string      `json:"ciuser,omitempty"`
 	CIpassword string      `json:"cipassword,omitempty"`
 	CIcustom   string      `json:"cicustom,omitempty"`
 	Ipconfig   IpconfigMap `json:"ipconfig,omitempty"`
+	// typed network settings, these take precedence over the untyped Ipconfig.
+	// Reading the config only fills Ipconfig, use IPConfigList for the typed settings.
+	IPConfigs []QemuIPConfig `json:"ipconfigs,omitempty"`
 
 	Searchdomain string `json:"searchdomain,omitempty"`
 	Nameserver   string `json:"nameserver,omitempty"`
 	Sshkeys      string `json:"sshkeys,omitempty"`
+	// one public key per entry, these take precedence over Sshkeys.
+	// Reading the config only fills Sshkeys, use SSHKeyList for the parsed keys.
+	SSHKeys []string `json:"ssh_keys,omitempty"`
+	// these take precedence over Searchdomain and Nameserver.
+	// Reading the config only fills Searchdomain and Nameserver, use SearchDomainList and NameserverList for the parsed lists.
+	SearchDomains []string `json:"searchdomains,omitempty"`
+	Nameservers   []string `json:"nameservers,omitempty"`
 }
 
 // CreateVm - Tell Proxmox API to make the VM
 func (config ConfigQemu) CreateVm(ctx context.Context, vmr *VmRef, client *Client) (err error) {
+	return config.createVm(ctx, vmr, client, false)
+}
+
+// CreateVmPartial is CreateVm, but only sends the fields that are set.
+// Zero numbers, empty strings and nil pointers are left out, so Proxmox applies its own defaults for them.
+func (config ConfigQemu) CreateVmPartial(ctx context.Context, vmr *VmRef, client *Client) (err error) {
+	return config.createVm(ctx, vmr, client, true)
+}
+
+func (config ConfigQemu) createVm(ctx context.Context, vmr *VmRef, client *Client, partial bool) (err error) {
 	if config.HasCloudInit() {
 		return fmt.Errorf("cloud-init parameters only supported on clones or updates")
 	}
 	vmr.SetVmType("qemu")
 
-	params := map[string]interface{}{
+	params, err := config.mapToApiCreateValues(vmr, partial)
+	if err != nil {
+		return
+	}
+
+	exitStatus, err := client.CreateQemuVm(ctx, vmr.node, params)
+	if err != nil {
+		return fmt.Errorf("error creating VM: %v, error status: %s (params: %v)", err, exitStatus, params)
+	}
+
+	_, err = client.UpdateVMHA(ctx, vmr, config.HaState, config.HaGroup)
+	if err != nil {
+		log.Printf("[ERROR] %q", err)
+	}
+
+	return
+}
+
+// Returns the params CreateVm sends, when partial the numbers that are not set are left out.
+// Empty strings are always left out when the params are sent.
+func (config ConfigQemu) mapToApiCreateValues(vmr *VmRef, partial bool) (params map[string]interface{}, err error) {
+	params = map[string]interface{}{
 		"vmid":        vmr.vmId,
 		"name":        config.Name,
 		"startup":     config.Startup,
-		"agent":       config.Agent,
 		"ostype":      config.QemuOs,
-		"sockets":     config.QemuSockets,
-		"cores":       config.QemuCores,
 		"cpu":         config.QemuCpu,
 		"hotplug":     config.Hotplug,
-		"memory":      config.Memory,
 		"boot":        config.Boot,
 		"description": config.Description,
 		"tags":        config.Tags,
 		"machine":     config.Machine,
 		"args":        config.Args,
 	}
+	numbers := map[string]int{
+		"agent":   config.Agent,
+		"sockets": config.QemuSockets,
+		"cores":   config.QemuCores,
+		"memory":  config.Memory,
+	}
+	for key, value := range numbers {
+		if value != 0 || !partial {
+			params[key] = value
+		}
+	}
 
 	if config.QemuNuma != nil {
 		params["numa"] = *config.QemuNuma
@@ -128,6 +199,10 @@ func (config ConfigQemu) CreateVm(ctx context.Context, vmr *VmRef, client *Clien
 		params["onboot"] = *config.Onboot
 	}
 
+	if config.Protection != nil {
+		params["protection"] = *config.Protection
+	}
+
 	if config.QemuIso != "" {
 		params["ide2"] = config.QemuIso + ",media=cdrom"
 	}
@@ -136,8 +211,8 @@ func (config ConfigQemu) CreateVm(ctx context.Context, vmr *VmRef, client *Clien
 		params["bios"] = config.Bios
 	}
 
-	if config.Balloon >= 1 {
-		params["balloon"] = config.Balloon
+	if config.Balloon != nil {
+		params["balloon"] = *config.Balloon
 	}
 
 	if config.QemuVcpus >= 1 {
@@ -158,6 +233,50 @@ func (config ConfigQemu) CreateVm(ctx context.Context, vmr *VmRef, client *Clien
 		params["scsihw"] = config.Scsihw
 	}
 
+	if config.VmStateStorage != "" {
+		params["vmstatestorage"] = config.VmStateStorage
+	}
+
+	if config.StartupConfig != nil {
+		if err = config.StartupConfig.Validate(); err != nil {
+			return
+		}
+		params["startup"] = config.StartupConfig.mapToApiValues()
+	}
+
+	if config.Rng != nil {
+		if err = config.Rng.Validate(); err != nil {
+			return
+		}
+		params["rng0"] = config.Rng.mapToApiValues()
+	}
+
+	if config.Watchdog != nil {
+		if err = config.Watchdog.Validate(); err != nil {
+			return
+		}
+		params["watchdog"] = config.Watchdog.mapToApiValues()
+	}
+
+	if config.Audio != nil {
+		if err = config.Audio.Validate(); err != nil {
+			return
+		}
+		params["audio0"] = config.Audio.mapToApiValues()
+	}
+
+	if config.SpiceEnhancements != nil {
+		if err = config.SpiceEnhancements.Validate(); err != nil {
+			return
+		}
+		params["spice_enhancements"] = config.SpiceEnhancements.mapToApiValues()
+	}
+
+	err = config.ValidateBootOrder()
+	if err != nil {
+		return
+	}
+
 	err = config.CreateQemuMachineParam(params)
 	if err != nil {
 		log.Printf("[ERROR] %q", err)
@@ -176,16 +295,18 @@ func (config ConfigQemu) CreateVm(ctx context.Context, vmr *VmRef, client *Clien
 	}
 
 	// Create vga config.
-	vgaParam := QemuDeviceParam{}
-	vgaParam = vgaParam.createDeviceParam(config.QemuVga, nil)
-	if len(vgaParam) > 0 {
-		params["vga"] = strings.Join(vgaParam, ",")
+	vgaParam, err := createQemuVgaParam(config.QemuVga)
+	if err != nil {
+		return
+	}
+	if vgaParam != "" {
+		params["vga"] = vgaParam
 	}
 
 	// Create networks config.
 	err = config.CreateQemuNetworksParams(vmr.vmId, params)
 	if err != nil {
-		log.Printf("[ERROR] %q", err)
+		return
 	}
 
 	// Create ipconfig.
@@ -200,28 +321,22 @@ func (config ConfigQemu) CreateVm(ctx context.Context, vmr *VmRef, client *Clien
 		log.Printf("[ERROR] %q", err)
 	}
 
-	err = config.CreateQemuPCIsParams(vmr.vmId, params)
+	err = config.CreateQemuPortsParams(params)
 	if err != nil {
-		log.Printf("[ERROR] %q", err)
+		return
 	}
 
-	// Create usb interfaces
-	err = config.CreateQemuUsbsParams(vmr.vmId, params)
+	err = config.CreateQemuPCIsParams(vmr.vmId, params)
 	if err != nil {
 		log.Printf("[ERROR] %q", err)
 	}
 
-	exitStatus, err := client.CreateQemuVm(ctx, vmr.node, params)
-	if err != nil {
-		return fmt.Errorf("error creating VM: %v, error status: %s (params: %v)", err, exitStatus, params)
-	}
-
-	_, err = client.UpdateVMHA(ctx, vmr, config.HaState, config.HaGroup)
+	// Create usb interfaces
+	err = config.CreateQemuUsbsParams(vmr.vmId, params)
 	if err != nil {
 		log.Printf("[ERROR] %q", err)
 	}
-
-	return
+	return params, nil
 }
 
 // HasCloudInit - are there cloud-init options?
@@ -231,7 +346,11 @@ func (config ConfigQemu) HasCloudInit() bool {
 			return true
 		}
 	}
-	return config.CIuser != "" ||
+	return len(config.IPConfigs) > 0 ||
+		len(config.SSHKeys) > 0 ||
+		len(config.SearchDomains) > 0 ||
+		len(config.Nameservers) > 0 ||
+		config.CIuser != "" ||
 		config.CIpassword != "" ||
 		config.Searchdomain != "" ||
 		config.Nameserver != "" ||
@@ -280,7 +399,63 @@ func (config ConfigQemu) CloneVm(ctx context.Context, sourceVmr *VmRef, vmr *VmR
 }
 
 func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *Client) (err error) {
-	configParams := map[string]interface{}{}
+	configParams, configParamsDisk, err := config.mapToApiUpdateValues(ctx, vmr, client)
+	if err != nil {
+		return
+	}
+	// TODO keep going if error=
+	_, err = client.createVMDisks(ctx, vmr.node, configParamsDisk)
+	if err != nil {
+		log.Printf("[ERROR] %q", err)
+	}
+	//Copy the disks to the global configParams
+	for key, value := range configParamsDisk {
+		//vmid is only required in createVMDisks
+		if key != "vmid" {
+			configParams[key] = value
+		}
+	}
+
+	_, err = client.SetVmConfig(ctx, vmr, configParams)
+	if err != nil {
+		log.Print(err)
+		return err
+	}
+
+	// the cloud-init drive is not rebuilt when its settings change
+	if config.HasCloudInit() {
+		err = client.RegenerateCloudInit(ctx, vmr)
+		if err != nil {
+			log.Printf("[ERROR] %q", err)
+		}
+	}
+
+	_, err = client.UpdateVMHA(ctx, vmr, config.HaState, config.HaGroup)
+	if err != nil {
+		log.Printf("[ERROR] %q", err)
+	}
+
+	_, err = client.UpdateVMPool(ctx, vmr, config.Pool)
+
+	return err
+}
+
+// Returns the config params UpdateConfig sends, the params of the disks are returned separately as they have to be created first.
+func (config ConfigQemu) mapToApiUpdateValues(ctx context.Context, vmr *VmRef, client *Client) (configParams, configParamsDisk map[string]interface{}, err error) {
+	var currentConfig map[string]interface{}
+	if len(config.QemuNetworks) > 0 {
+		currentConfig, err = client.GetVmConfig(ctx, vmr)
+		if err != nil {
+			return
+		}
+	}
+	return config.mapToApiUpdateValuesWithCurrent(vmr, currentConfig)
+}
+
+// Same as mapToApiUpdateValues, with the current config of the guest already read.
+// The current config is only used when the config has networks.
+func (config ConfigQemu) mapToApiUpdateValuesWithCurrent(vmr *VmRef, currentConfig map[string]interface{}) (configParams, configParamsDisk map[string]interface{}, err error) {
+	configParams = map[string]interface{}{}
 
 	//Array to list deleted parameters
 	//deleteParams := []string{}
@@ -314,6 +489,10 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 		configParams["onboot"] = *config.Onboot
 	}
 
+	if config.Protection != nil {
+		configParams["protection"] = *config.Protection
+	}
+
 	if config.Tablet != nil {
 		configParams["tablet"] = *config.Tablet
 	}
@@ -329,6 +508,12 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 	if config.Startup != "" {
 		configParams["startup"] = config.Startup
 	}
+	if config.StartupConfig != nil {
+		if err = config.StartupConfig.Validate(); err != nil {
+			return
+		}
+		configParams["startup"] = config.StartupConfig.mapToApiValues()
+	}
 
 	if config.QemuIso != "" {
 		configParams["ide2"] = config.QemuIso + ",media=cdrom"
@@ -350,8 +535,8 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 		configParams["description"] = config.Description
 	}
 
-	if config.Balloon >= 1 {
-		configParams["balloon"] = config.Balloon
+	if config.Balloon != nil {
+		configParams["balloon"] = *config.Balloon
 	}
 
 	if config.QemuVcpus >= 1 {
@@ -359,6 +544,9 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 	}
 
 	if config.Boot != "" {
+		if err = config.ValidateBootOrder(); err != nil {
+			return
+		}
 		configParams["boot"] = config.Boot
 	}
 
@@ -370,6 +558,10 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 		configParams["hookscript"] = config.Hookscript
 	}
 
+	if config.VmStateStorage != "" {
+		configParams["vmstatestorage"] = config.VmStateStorage
+	}
+
 	if config.QemuCpu != "" {
 		configParams["cpu"] = config.QemuCpu
 	}
@@ -378,13 +570,41 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 		configParams["scsihw"] = config.Scsihw
 	}
 
+	if config.Rng != nil {
+		if err = config.Rng.Validate(); err != nil {
+			return
+		}
+		configParams["rng0"] = config.Rng.mapToApiValues()
+	}
+
+	if config.Watchdog != nil {
+		if err = config.Watchdog.Validate(); err != nil {
+			return
+		}
+		configParams["watchdog"] = config.Watchdog.mapToApiValues()
+	}
+
+	if config.Audio != nil {
+		if err = config.Audio.Validate(); err != nil {
+			return
+		}
+		configParams["audio0"] = config.Audio.mapToApiValues()
+	}
+
+	if config.SpiceEnhancements != nil {
+		if err = config.SpiceEnhancements.Validate(); err != nil {
+			return
+		}
+		configParams["spice_enhancements"] = config.SpiceEnhancements.mapToApiValues()
+	}
+
 	err = config.CreateQemuMachineParam(configParams)
 	if err != nil {
 		log.Printf("[ERROR] %q", err)
 	}
 
 	// Create disks config.
-	configParamsDisk := map[string]interface{}{
+	configParamsDisk = map[string]interface{}{
 		"vmid": vmr.vmId,
 	}
 	// TODO keep going if error=
@@ -392,30 +612,23 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 	if err != nil {
 		log.Printf("[ERROR] %q", err)
 	}
-	// TODO keep going if error=
-	_, err = client.createVMDisks(ctx, vmr.node, configParamsDisk)
-	if err != nil {
-		log.Printf("[ERROR] %q", err)
-	}
-	//Copy the disks to the global configParams
-	for key, value := range configParamsDisk {
-		//vmid is only required in createVMDisks
-		if key != "vmid" {
-			configParams[key] = value
-		}
-	}
 
 	// Create networks config.
+	if len(config.QemuNetworks) > 0 {
+		config.QemuNetworks = config.preserveQemuNicLinkDown(currentConfig)
+	}
 	err = config.CreateQemuNetworksParams(vmr.vmId, configParams)
 	if err != nil {
-		log.Printf("[ERROR] %q", err)
+		return
 	}
 
 	// Create vga config.
-	vgaParam := QemuDeviceParam{}
-	vgaParam = vgaParam.createDeviceParam(config.QemuVga, nil)
-	if len(vgaParam) > 0 {
-		configParams["vga"] = strings.Join(vgaParam, ",")
+	vgaParam, err := createQemuVgaParam(config.QemuVga)
+	if err != nil {
+		return
+	}
+	if vgaParam != "" {
+		configParams["vga"] = vgaParam
 	}
 	// Create serial interfaces
 	err = config.CreateQemuSerialsParams(vmr.vmId, configParams)
@@ -423,6 +636,11 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 		log.Printf("[ERROR] %q", err)
 	}
 
+	err = config.CreateQemuPortsParams(configParams)
+	if err != nil {
+		return
+	}
+
 	// Create usb interfaces
 	err = config.CreateQemuUsbsParams(vmr.vmId, configParams)
 	if err != nil {
@@ -449,9 +667,18 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 	if config.Nameserver != "" {
 		configParams["nameserver"] = config.Nameserver
 	}
+	if len(config.SearchDomains) > 0 {
+		configParams["searchdomain"] = formatDNSList(config.SearchDomains)
+	}
+	if len(config.Nameservers) > 0 {
+		configParams["nameserver"] = formatDNSList(config.Nameservers)
+	}
 	if config.Sshkeys != "" {
 		configParams["sshkeys"] = sshKeyUrlEncode(config.Sshkeys)
 	}
+	if len(config.SSHKeys) > 0 {
+		configParams["sshkeys"] = formatSSHKeys(config.SSHKeys)
+	}
 	err = config.CreateIpconfigParams(vmr.vmId, configParams)
 	if err != nil {
 		log.Printf("[ERROR] %q", err)
@@ -460,21 +687,7 @@ func (config ConfigQemu) UpdateConfig(ctx context.Context, vmr *VmRef, client *C
 	// if len(deleteParams) > 0 {
 	// 	configParams["delete"] = strings.Join(deleteParams, ", ")
 	// }
-
-	_, err = client.SetVmConfig(ctx, vmr, configParams)
-	if err != nil {
-		log.Print(err)
-		return err
-	}
-
-	_, err = client.UpdateVMHA(ctx, vmr, config.HaState, config.HaGroup)
-	if err != nil {
-		log.Printf("[ERROR] %q", err)
-	}
-
-	_, err = client.UpdateVMPool(ctx, vmr, config.Pool)
-
-	return err
+	return configParams, configParamsDisk, nil
 }
 
 func NewConfigQemuFromJson(input []byte) (config *ConfigQemu, err error) {
@@ -521,7 +734,11 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 	if vmConfig["lock"] != nil {
 		return nil, fmt.Errorf("vm locked, could not obtain config")
 	}
+	return newConfigQemuFromApiConfig(ctx, vmr, client, vmConfig)
+}
 
+// Maps the raw config of the guest, which is either the active config or the config captured in a snapshot.
+func newConfigQemuFromApiConfig(ctx context.Context, vmr *VmRef, client *Client, vmConfig map[string]interface{}) (config *ConfigQemu, err error) {
 	// vmConfig Sample: map[ cpu:host
 	// net0:virtio=62:DF:XX:XX:XX:XX,bridge=vmbr0
 	// ide2:local:iso/xxx-xx.iso,media=cdrom memory:2048
@@ -556,14 +773,18 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 	if _, isSet := vmConfig["onboot"]; isSet {
 		onboot = Itob(int(vmConfig["onboot"].(float64)))
 	}
-	startup := ""
-	if _, isSet := vmConfig["startup"]; isSet {
-		startup = vmConfig["startup"].(string)
+	protection := false
+	if _, isSet := vmConfig["protection"]; isSet {
+		protection = Itob(int(vmConfig["protection"].(float64)))
 	}
 	tablet := true
 	if _, isSet := vmConfig["tablet"]; isSet {
 		tablet = Itob(int(vmConfig["tablet"].(float64)))
 	}
+	template := false
+	if _, isSet := vmConfig["template"]; isSet {
+		template = Itob(int(vmConfig["template"].(float64)))
+	}
 
 	agent := 0
 	if _, isSet := vmConfig["agent"]; isSet {
@@ -584,9 +805,9 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 	if _, isSet := vmConfig["memory"]; isSet {
 		memory = vmConfig["memory"].(float64)
 	}
-	balloon := 0.0
+	var balloon *int
 	if _, isSet := vmConfig["balloon"]; isSet {
-		balloon = vmConfig["balloon"].(float64)
+		balloon = PointerInt(int(vmConfig["balloon"].(float64)))
 	}
 	cores := 1.0
 	if _, isSet := vmConfig["cores"]; isSet {
@@ -643,8 +864,9 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 		Bios:            bios,
 		EFIDisk:         QemuDevice{},
 		Onboot:          &onboot,
-		Startup:         startup,
+		Protection:      &protection,
 		Tablet:          &tablet,
+		Template:        template,
 		Agent:           agent,
 		QemuOs:          ostype,
 		Memory:          int(memory),
@@ -663,18 +885,41 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 		QemuVga:         QemuDevice{},
 		QemuNetworks:    QemuDevices{},
 		QemuSerials:     QemuDevices{},
+		QemuParallels:   QemuDevices{},
 		QemuPCIDevices:  QemuDevices{},
 		QemuUsbs:        QemuDevices{},
 		Ipconfig:        IpconfigMap{},
 	}
 
-	if balloon >= 1 {
-		config.Balloon = int(balloon)
-	}
+	config.Balloon = balloon
 	if vcpus >= 1 {
 		config.QemuVcpus = int(vcpus)
 	}
 
+	if _, isSet := vmConfig["startup"]; isSet {
+		config.Startup = vmConfig["startup"].(string)
+	}
+
+	if _, isSet := vmConfig["vmstatestorage"]; isSet {
+		config.VmStateStorage = vmConfig["vmstatestorage"].(string)
+	}
+
+	if _, isSet := vmConfig["rng0"]; isSet {
+		config.Rng = QemuRng{}.mapToStruct(vmConfig["rng0"].(string))
+	}
+
+	if _, isSet := vmConfig["watchdog"]; isSet {
+		config.Watchdog = QemuWatchdog{}.mapToStruct(vmConfig["watchdog"].(string))
+	}
+
+	if _, isSet := vmConfig["audio0"]; isSet {
+		config.Audio = QemuAudio{}.mapToStruct(vmConfig["audio0"].(string))
+	}
+
+	if _, isSet := vmConfig["spice_enhancements"]; isSet {
+		config.SpiceEnhancements = QemuSpiceEnhancements{}.mapToStruct(vmConfig["spice_enhancements"].(string))
+	}
+
 	if vmConfig["ide2"] != nil {
 		isoMatch := rxIso.FindStringSubmatch(vmConfig["ide2"].(string))
 		config.QemuIso = isoMatch[1]
@@ -741,6 +986,8 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 		if diskConfMap["volume"].(string) == "none" {
 			continue
 		}
+		// only used when the disk is created
+		delete(diskConfMap, "import-from")
 
 		diskConfMap["slot"] = diskID
 		diskConfMap["type"] = diskType
@@ -835,14 +1082,7 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 
 	//Display
 	if vga, isSet := vmConfig["vga"]; isSet {
-		vgaList := strings.Split(vga.(string), ",")
-		vgaMap := QemuDevice{}
-
-		// TODO: keep going if error?
-		err = vgaMap.readDeviceConfig(vgaList)
-		if err != nil {
-			log.Printf("[ERROR] %q", err)
-		}
+		vgaMap := readQemuVga(vga.(string))
 		if len(vgaMap) > 0 {
 			config.QemuVga = vgaMap
 		}
@@ -889,6 +1129,7 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 		case 0:
 			nicConfMap["link_down"] = false
 		}
+		readQemuNicQueuesAndRate(nicConfMap)
 
 		// And device config to networks.
 		if len(nicConfMap) > 0 {
@@ -919,6 +1160,7 @@ func NewConfigQemuFromApi(ctx context.Context, vmr *VmRef, client *Client) (conf
 			config.QemuSerials[serialID] = serialConfMap
 		}
 	}
+	config.readQemuParallels(vmConfig)
 
 	// Add usbs
 	usbNames := []string{}
@@ -1157,6 +1399,10 @@ func FormatDiskParam(disk QemuDevice) string {
 	if volume, ok := disk["volume"]; ok && volume != "" {
 		diskConfParam = append(diskConfParam, volume.(string))
 		diskConfParam = append(diskConfParam, fmt.Sprintf("size=%v", disk["size"]))
+	} else if importFrom, ok := disk["import_from"]; ok && importFrom != "" {
+		// The new disk gets the size of the imported image, Proxmox requires the size to be 0.
+		diskConfParam = append(diskConfParam, fmt.Sprintf("%v:0", disk["storage"]))
+		diskConfParam = append(diskConfParam, fmt.Sprintf("import-from=%v", importFrom))
 	} else {
 		volumeInit := fmt.Sprintf("%v:%v", disk["storage"], DiskSizeGB(disk["size"]))
 		diskConfParam = append(diskConfParam, volumeInit)
@@ -1190,7 +1436,7 @@ func FormatDiskParam(disk QemuDevice) string {
 	}
 
 	// Keys that are not used as real/direct conf.
-	ignoredKeys := []string{"backup", "key", "slot", "type", "storage", "file", "size", "cache", "volume", "container", "vm", "mountoptions", "storage_type"}
+	ignoredKeys := []string{"backup", "key", "slot", "type", "storage", "file", "size", "cache", "volume", "container", "vm", "mountoptions", "storage_type", "import_from"}
 
 	// Rest of config.
 	diskConfParam = diskConfParam.createDeviceParam(disk, ignoredKeys)
@@ -1261,8 +1507,17 @@ func (c ConfigQemu) CreateQemuNetworksParams(vmID int, params map[string]interfa
 			nicConfParam = append(nicConfParam, bridge)
 		}
 
+		queuesAndRate, err := qemuNicQueuesAndRate(nicConfMap)
+		if err != nil {
+			return err
+		}
+		nicConfParam = append(nicConfParam, queuesAndRate...)
+		if linkDown := qemuNicLinkDown(nicConfMap); linkDown != "" {
+			nicConfParam = append(nicConfParam, linkDown)
+		}
+
 		// Keys that are not used as real/direct conf.
-		ignoredKeys := []string{"id", "bridge", "macaddr", "model"}
+		ignoredKeys := []string{"id", "bridge", "macaddr", "model", "queues", "rate", "link_down"}
 
 		// Rest of config.
 		nicConfParam = nicConfParam.createDeviceParam(nicConfMap, ignoredKeys)
@@ -1288,6 +1543,12 @@ func (c ConfigQemu) CreateIpconfigParams(vmID int, params map[string]interface{}
 		}
 	}
 
+	if err := validateQemuIPConfigs(c.IPConfigs); err != nil {
+		return err
+	}
+	for _, config := range c.IPConfigs {
+		params["ipconfig"+strconv.Itoa(int(config.ID))] = config.mapToApiValues()
+	}
 	return nil
 }
 
@@ -0,0 +1,88 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Returns the devices of the boot order in "order=scsi0;ide2;net0" format.
+// Returns nil for the legacy format (e.g. "cdn").
+func parseBootOrder(boot string) []string {
+	for _, e := range strings.Split(boot, ",") {
+		if strings.HasPrefix(e, "order=") {
+			order := strings.TrimPrefix(e, "order=")
+			if order == "" {
+				return []string{}
+			}
+			return strings.Split(order, ";")
+		}
+	}
+	return nil
+}
+
+// Classify all devices of the config that could be part of the boot order.
+// The value is empty when the device is bootable, otherwise it holds the reason why it is not.
+// Empty cdroms may be in the boot order as media may be inserted later, but they don't count as a bootable disk.
+func (config ConfigQemu) bootableDevices() (devices map[string]string, disks map[string]bool) {
+	devices = map[string]string{}
+	disks = map[string]bool{}
+	for _, disk := range config.QemuDisks {
+		diskType, _ := disk["type"].(string)
+		name := diskType + fmt.Sprintf("%v", disk["slot"])
+		volume := fmt.Sprintf("%v", disk["volume"]) + fmt.Sprintf("%v", disk["file"])
+		switch {
+		case strings.Contains(volume, "cloudinit"):
+			devices[name] = "it is a cloud-init drive"
+		case disk["media"] == "cdrom" && (disk["volume"] == nil || disk["volume"] == "" || disk["volume"] == "none"):
+			devices[name] = ""
+		default:
+			devices[name] = ""
+			disks[name] = true
+		}
+	}
+	// an iso of "none" creates an empty cdrom
+	if config.QemuIso != "" {
+		devices["ide2"] = ""
+		disks["ide2"] = config.QemuIso != "none"
+	}
+	for id := range config.QemuNetworks {
+		devices["net"+strconv.Itoa(id)] = ""
+	}
+	for id := range config.QemuPCIDevices {
+		devices["hostpci"+strconv.Itoa(id)] = ""
+	}
+	for id := range config.QemuUsbs {
+		devices["usb"+strconv.Itoa(id)] = ""
+	}
+	return
+}
+
+// ValidateBootOrder returns an error when the boot order contains a device that does not exist or can't be booted from,
+// or when it contains no disk while PXE boot is not enabled.
+// Only the "order=" format of the boot option is validated.
+func (config ConfigQemu) ValidateBootOrder() error {
+	order := parseBootOrder(config.Boot)
+	if order == nil {
+		return nil
+	}
+	devices, disks := config.bootableDevices()
+	var hasDisk bool
+	for _, device := range order {
+		reason, exists := devices[device]
+		if !exists {
+			return fmt.Errorf("boot order contains device (%s) which does not exist", device)
+		}
+		if reason != "" {
+			return fmt.Errorf("boot order contains device (%s) which is not bootable, %s", device, reason)
+		}
+		if disks[device] {
+			hasDisk = true
+		}
+	}
+	if !hasDisk && !config.QemuPxe {
+		return errors.New("boot order does not contain any bootable disk")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseBootOrder(t *testing.T) {
+	require.Nil(t, parseBootOrder(""))
+	require.Nil(t, parseBootOrder("cdn"))
+	require.Equal(t, []string{}, parseBootOrder("order="))
+	require.Equal(t, []string{"scsi0", "ide2", "net0"}, parseBootOrder("order=scsi0;ide2;net0"))
+}
+
+func Test_ConfigQemu_ValidateBootOrder(t *testing.T) {
+	config := ConfigQemu{
+		QemuDisks: QemuDevices{
+			0: {"type": "scsi", "slot": 0, "storage": "local-lvm", "size": "10G"},
+			1: {"type": "ide", "slot": 1, "volume": "local-lvm:vm-100-cloudinit", "file": "vm-100-cloudinit", "media": "cdrom"},
+			3: {"type": "ide", "slot": 3, "media": "cdrom"},
+		},
+		QemuNetworks: QemuDevices{0: {"model": "virtio", "bridge": "vmbr0"}},
+	}
+	tests := []struct {
+		name string
+		boot string
+		iso  string
+		pxe  bool
+		err  error
+	}{
+		{name: "legacy format", boot: "cdn"},
+		{name: "disk and net", boot: "order=scsi0;net0"},
+		{name: "iso", boot: "order=ide2;scsi0", iso: "local:iso/debian.iso"},
+		{name: "cloud-init drive", boot: "order=ide1",
+			err: errors.New("boot order contains device (ide1) which is not bootable, it is a cloud-init drive")},
+		{name: "empty cdrom with disk", boot: "order=scsi0;ide3"},
+		{name: "empty cdrom only", boot: "order=ide3;net0",
+			err: errors.New("boot order does not contain any bootable disk")},
+		{name: "iso none", boot: "order=ide2;net0", iso: "none",
+			err: errors.New("boot order does not contain any bootable disk")},
+		{name: "iso none with disk", boot: "order=ide2;scsi0", iso: "none"},
+		{name: "missing device", boot: "order=virtio0",
+			err: errors.New("boot order contains device (virtio0) which does not exist")},
+		{name: "no disk", boot: "order=net0",
+			err: errors.New("boot order does not contain any bootable disk")},
+		{name: "pxe only", boot: "order=net0", pxe: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(*testing.T) {
+			config.Boot = test.boot
+			config.QemuIso = test.iso
+			config.QemuPxe = test.pxe
+			require.Equal(t, test.err, config.ValidateBootOrder())
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Options for cloning a qemu guest.
+type ConfigQemuClone struct {
+	BwLimit     int    `json:"bwlimit,omitempty"` // KiB/s
+	Description string `json:"description,omitempty"`
+	// Format of the disks of a full clone, one of (raw,qcow2,vmdk).
+	Format   string `json:"format,omitempty"`
+	Full     bool   `json:"full"`
+	Name     string `json:"name,omitempty"`
+	NewID    int    `json:"newid,omitempty"` // 0 uses the next free ID
+	Pool     string `json:"pool,omitempty"`
+	SnapName string `json:"snapname,omitempty"`
+	Storage  string `json:"storage,omitempty"`
+	Target   string `json:"target,omitempty"`
+}
+
+func (clone ConfigQemuClone) mapToApiValues(newID int) map[string]interface{} {
+	params := map[string]interface{}{
+		"newid":       newID,
+		"full":        clone.Full,
+		"description": clone.Description,
+		"format":      clone.Format,
+		"name":        clone.Name,
+		"pool":        clone.Pool,
+		"snapname":    clone.SnapName,
+		"storage":     clone.Storage,
+		"target":      clone.Target,
+	}
+	if clone.BwLimit != 0 {
+		params["bwlimit"] = clone.BwLimit
+	}
+	return params
+}
+
+// Validates the clone options against the source guest.
+func (clone ConfigQemuClone) Validate(sourceIsTemplate bool) error {
+	if clone.BwLimit < 0 {
+		return errors.New("bwlimit may not be negative")
+	}
+	if clone.Full {
+		if clone.Format != "" && !inArray([]string{"raw", "qcow2", "vmdk"}, clone.Format) {
+			return errors.New("format must be one of (raw,qcow2,vmdk)")
+		}
+		return nil
+	}
+	if !sourceIsTemplate {
+		return errors.New("a linked clone can only be created from a template, set full to clone a regular guest")
+	}
+	if clone.Storage != "" || clone.Format != "" {
+		return errors.New("storage and format can only be specified for a full clone")
+	}
+	return nil
+}
+
+func (clone ConfigQemuClone) startCloneQemu(ctx context.Context, client *Client, source *VmRef) (vmr *VmRef, taskResponse map[string]interface{}, err error) {
+	template, err := client.cloneSourceIsTemplate(ctx, source, "qemu")
+	if err != nil {
+		return
+	}
+	err = clone.Validate(template)
+	if err != nil {
+		return
+	}
+	newID := clone.NewID
+	if newID == 0 {
+		newID, err = client.GetNextID(ctx, 0)
+		if err != nil {
+			return
+		}
+	}
+	taskResponse, err = client.startCloneTask(ctx, source, clone.mapToApiValues(newID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error cloning qemu guest: %v", err)
+	}
+	return cloneVmRef(newID, "qemu", source.node, clone.Target, clone.Pool), taskResponse, nil
+}
+
+// CloneQemu clones the source guest with the options, the ID of the new guest is taken from opts.NewID.
+// When opts.NewID is 0 the next free ID is used.
+// Returns the reference to the new guest and the UPID of the clone task.
+func (c *Client) CloneQemu(ctx context.Context, source *VmRef, opts ConfigQemuClone) (vmr *VmRef, upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmr, taskResponse, err := opts.startCloneQemu(ctx, c, source)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	exitStatus, err := c.WaitForCompletion(ctx, taskResponse)
+	if err != nil {
+		jsonParams, _ := json.Marshal(opts.mapToApiValues(vmr.vmId))
+		return nil, upid, fmt.Errorf("error cloning qemu guest: %v, error status: %s (params: %v)", err, exitStatus, string(jsonParams))
+	}
+	return
+}
+
+// StartCloneQemu is CloneQemu without waiting for the clone task, use WaitForTask to wait for the clone to complete.
+func (c *Client) StartCloneQemu(ctx context.Context, source *VmRef, opts ConfigQemuClone) (vmr *VmRef, upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmr, taskResponse, err := opts.startCloneQemu(ctx, c, source)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	return
+}
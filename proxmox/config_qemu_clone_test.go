@@ -0,0 +1,47 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigQemuClone_Validate(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    ConfigQemuClone
+		template bool
+		err      error
+	}{
+		{name: "full clone of a regular guest", input: ConfigQemuClone{Full: true, Storage: "local-lvm", Format: "qcow2"}},
+		{name: "linked clone of a template", input: ConfigQemuClone{}, template: true},
+		{name: "linked clone of a regular guest", input: ConfigQemuClone{},
+			err: errors.New("a linked clone can only be created from a template, set full to clone a regular guest")},
+		{name: "linked clone with storage", input: ConfigQemuClone{Storage: "local-lvm"}, template: true,
+			err: errors.New("storage and format can only be specified for a full clone")},
+		{name: "invalid format", input: ConfigQemuClone{Full: true, Format: "vdi"},
+			err: errors.New("format must be one of (raw,qcow2,vmdk)")},
+		{name: "negative bwlimit", input: ConfigQemuClone{Full: true, BwLimit: -1},
+			err: errors.New("bwlimit may not be negative")},
+	}
+	for _, test := range testData {
+		t.Run(test.name, func(*testing.T) {
+			require.Equal(t, test.err, test.input.Validate(test.template))
+		})
+	}
+}
+
+func Test_ConfigQemuClone_mapToApiValues(t *testing.T) {
+	input := ConfigQemuClone{Full: true, Name: "vm01", Pool: "pool", SnapName: "snap", Storage: "local", Format: "raw", Target: "pve2", BwLimit: 1024}
+	output := "bwlimit=1024&format=raw&full=1&name=vm01&newid=150&pool=pool&snapname=snap&storage=local&target=pve2"
+	require.Equal(t, output, ParamsToValues(input.mapToApiValues(150)).Encode())
+	require.Equal(t, "full=0&newid=150", ParamsToValues(ConfigQemuClone{}.mapToApiValues(150)).Encode())
+}
+
+func Test_cloneVmRef(t *testing.T) {
+	vmr := cloneVmRef(150, "qemu", "pve1", "", "pool")
+	require.Equal(t, "pve1", vmr.node)
+	require.Equal(t, "pool", vmr.pool)
+	require.Equal(t, "pve2", cloneVmRef(150, "lxc", "pve1", "pve2", "").node)
+}
@@ -0,0 +1,148 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	QemuAudioDevice_AC97         string = "AC97"
+	QemuAudioDevice_Ich9IntelHda string = "ich9-intel-hda"
+	QemuAudioDevice_IntelHda     string = "intel-hda"
+)
+
+const (
+	QemuAudioDriver_None  string = "none"
+	QemuAudioDriver_Spice string = "spice"
+)
+
+// Audio device of the guest, use the spice driver to pass the audio to a SPICE client.
+type QemuAudio struct {
+	Device string `json:"device"`
+	Driver string `json:"driver,omitempty"`
+}
+
+func (audio QemuAudio) mapToApiValues() string {
+	settings := "device=" + audio.Device
+	if audio.Driver != "" {
+		settings += ",driver=" + audio.Driver
+	}
+	return settings
+}
+
+func (QemuAudio) mapToStruct(setting string) *QemuAudio {
+	audio := QemuAudio{}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, _ := strings.Cut(e, "=")
+		switch key {
+		case "device":
+			audio.Device = value
+		case "driver":
+			audio.Driver = value
+		}
+	}
+	return &audio
+}
+
+func (audio QemuAudio) Validate() error {
+	if !inArray([]string{QemuAudioDevice_AC97, QemuAudioDevice_Ich9IntelHda, QemuAudioDevice_IntelHda}, audio.Device) {
+		return errors.New("audio device must be one of (" + QemuAudioDevice_AC97 + "," + QemuAudioDevice_Ich9IntelHda + "," + QemuAudioDevice_IntelHda + ")")
+	}
+	if audio.Driver != "" && !inArray([]string{QemuAudioDriver_None, QemuAudioDriver_Spice}, audio.Driver) {
+		return errors.New("audio driver must be one of (" + QemuAudioDriver_None + "," + QemuAudioDriver_Spice + ")")
+	}
+	return nil
+}
+
+const (
+	QemuSpiceVideoStreaming_All    string = "all"
+	QemuSpiceVideoStreaming_Filter string = "filter"
+	QemuSpiceVideoStreaming_Off    string = "off"
+)
+
+// Additional SPICE features, only take effect when the display is a qxl device.
+type QemuSpiceEnhancements struct {
+	FolderSharing  bool   `json:"foldersharing,omitempty"`
+	VideoStreaming string `json:"videostreaming,omitempty"`
+}
+
+func (spice QemuSpiceEnhancements) mapToApiValues() string {
+	settings := "foldersharing=0"
+	if spice.FolderSharing {
+		settings = "foldersharing=1"
+	}
+	if spice.VideoStreaming != "" {
+		settings += ",videostreaming=" + spice.VideoStreaming
+	}
+	return settings
+}
+
+func (QemuSpiceEnhancements) mapToStruct(setting string) *QemuSpiceEnhancements {
+	spice := QemuSpiceEnhancements{}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, _ := strings.Cut(e, "=")
+		switch key {
+		case "foldersharing":
+			spice.FolderSharing = value == "1"
+		case "videostreaming":
+			spice.VideoStreaming = value
+		}
+	}
+	return &spice
+}
+
+func (spice QemuSpiceEnhancements) Validate() error {
+	if spice.VideoStreaming != "" && !inArray([]string{QemuSpiceVideoStreaming_All, QemuSpiceVideoStreaming_Filter, QemuSpiceVideoStreaming_Off}, spice.VideoStreaming) {
+		return errors.New("spice videostreaming must be one of (" + QemuSpiceVideoStreaming_All + "," + QemuSpiceVideoStreaming_Filter + "," + QemuSpiceVideoStreaming_Off + ")")
+	}
+	return nil
+}
+
+const (
+	qemuVgaMinMemory = 4
+	qemuVgaMaxMemory = 512
+)
+
+// Returns the vga= setting of the display, memory is in MiB and clipboard can only be "vnc".
+func createQemuVgaParam(vga QemuDevice) (string, error) {
+	settings := QemuDeviceParam{}
+	if vgaType, isSet := vga["type"]; isSet {
+		settings = append(settings, fmt.Sprintf("type=%v", vgaType))
+	}
+	if value, isSet := vga["memory"]; isSet {
+		memory, ok := qemuDeviceNumber(value)
+		// 0 keeps the default amount of memory
+		if !ok || memory != math.Trunc(memory) || memory != 0 && (memory < qemuVgaMinMemory || memory > qemuVgaMaxMemory) {
+			return "", fmt.Errorf("vga memory (%v) must be an integer between %d and %d", value, qemuVgaMinMemory, qemuVgaMaxMemory)
+		}
+		if memory != 0 {
+			settings = append(settings, "memory="+strconv.Itoa(int(memory)))
+		}
+	}
+	if clipboard, isSet := vga["clipboard"]; isSet && clipboard != "" {
+		if clipboard != "vnc" {
+			return "", fmt.Errorf("vga clipboard (%v) must be vnc", clipboard)
+		}
+		settings = append(settings, "clipboard=vnc")
+	}
+	settings = settings.createDeviceParam(vga, []string{"type", "memory", "clipboard"})
+	return strings.Join(settings, ","), nil
+}
+
+// Parses the vga= setting, the type may be given without its key (e.g. "qxl,memory=32").
+func readQemuVga(vga string) QemuDevice {
+	vgaMap := QemuDevice{}
+	vgaList := strings.Split(vga, ",")
+	if !strings.Contains(vgaList[0], "=") {
+		vgaMap["type"] = vgaList[0]
+		vgaList = vgaList[1:]
+	}
+	if err := vgaMap.readDeviceConfig(vgaList); err != nil {
+		log.Printf("[ERROR] %q", err)
+	}
+	return vgaMap
+}
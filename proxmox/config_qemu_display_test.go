@@ -0,0 +1,70 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QemuAudio_mapToApiValues(t *testing.T) {
+	require.Equal(t, "device=AC97", QemuAudio{Device: QemuAudioDevice_AC97}.mapToApiValues())
+	require.Equal(t, "device=ich9-intel-hda,driver=spice", QemuAudio{Device: QemuAudioDevice_Ich9IntelHda, Driver: QemuAudioDriver_Spice}.mapToApiValues())
+}
+
+func Test_QemuAudio_mapToStruct(t *testing.T) {
+	require.Equal(t, &QemuAudio{Device: QemuAudioDevice_Ich9IntelHda, Driver: QemuAudioDriver_Spice}, QemuAudio{}.mapToStruct("device=ich9-intel-hda,driver=spice"))
+}
+
+func Test_QemuAudio_Validate(t *testing.T) {
+	tests := []struct {
+		input QemuAudio
+		err   error
+	}{
+		{input: QemuAudio{Device: QemuAudioDevice_IntelHda}},
+		{input: QemuAudio{Device: QemuAudioDevice_Ich9IntelHda, Driver: QemuAudioDriver_Spice}},
+		{input: QemuAudio{}, err: errors.New("audio device must be one of (AC97,ich9-intel-hda,intel-hda)")},
+		{input: QemuAudio{Device: QemuAudioDevice_AC97, Driver: "alsa"}, err: errors.New("audio driver must be one of (none,spice)")},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.err, test.input.Validate())
+	}
+}
+
+func Test_QemuSpiceEnhancements(t *testing.T) {
+	spice := QemuSpiceEnhancements{FolderSharing: true, VideoStreaming: QemuSpiceVideoStreaming_Filter}
+	require.Equal(t, "foldersharing=1,videostreaming=filter", spice.mapToApiValues())
+	require.Equal(t, &spice, QemuSpiceEnhancements{}.mapToStruct("foldersharing=1,videostreaming=filter"))
+	require.Equal(t, "foldersharing=0", QemuSpiceEnhancements{}.mapToApiValues())
+	require.NoError(t, spice.Validate())
+	require.Error(t, QemuSpiceEnhancements{VideoStreaming: "on"}.Validate())
+}
+
+func Test_createQemuVgaParam(t *testing.T) {
+	tests := []struct {
+		input  QemuDevice
+		output string
+		err    bool
+	}{
+		{input: QemuDevice{}, output: ""},
+		{input: QemuDevice{"type": "qxl"}, output: "type=qxl"},
+		{input: QemuDevice{"type": "qxl", "memory": float64(32), "clipboard": "vnc"}, output: "type=qxl,memory=32,clipboard=vnc"},
+		{input: QemuDevice{"type": "std", "memory": 0}, output: "type=std"},
+		{input: QemuDevice{"type": "std", "memory": 1024}, err: true},
+		{input: QemuDevice{"type": "std", "clipboard": "spice"}, err: true},
+	}
+	for _, test := range tests {
+		output, err := createQemuVgaParam(test.input)
+		if test.err {
+			require.Error(t, err, test.input)
+		} else {
+			require.NoError(t, err, test.input)
+			require.Equal(t, test.output, output, test.input)
+		}
+	}
+}
+
+func Test_readQemuVga(t *testing.T) {
+	require.Equal(t, QemuDevice{"type": "qxl", "memory": 32}, readQemuVga("qxl,memory=32"))
+	require.Equal(t, QemuDevice{"type": "std", "clipboard": "vnc"}, readQemuVga("type=std,clipboard=vnc"))
+}
@@ -0,0 +1,50 @@
+package proxmox
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Proxmox stores the cloud-init nameservers and search domains as a single space separated setting.
+func formatDNSList(list []string) string {
+	return strings.Join(list, " ")
+}
+
+func parseDNSList(setting string) []string {
+	return strings.Fields(setting)
+}
+
+// NameserverList returns the Nameservers when they are set, otherwise the parsed Nameserver.
+func (config ConfigQemu) NameserverList() []string {
+	if len(config.Nameservers) > 0 {
+		return config.Nameservers
+	}
+	return parseDNSList(config.Nameserver)
+}
+
+// SearchDomainList returns the SearchDomains when they are set, otherwise the parsed Searchdomain.
+func (config ConfigQemu) SearchDomainList() []string {
+	if len(config.SearchDomains) > 0 {
+		return config.SearchDomains
+	}
+	return parseDNSList(config.Searchdomain)
+}
+
+func validateQemuNameservers(nameservers []string) error {
+	for _, e := range nameservers {
+		if net.ParseIP(e) == nil {
+			return fmt.Errorf("nameserver (%s) is not a valid IP address", e)
+		}
+	}
+	return nil
+}
+
+func validateQemuSearchDomains(domains []string) error {
+	for _, e := range domains {
+		if e == "" || strings.ContainsAny(e, " \t\n") {
+			return fmt.Errorf("search domain (%s) may not be empty or contain whitespace", e)
+		}
+	}
+	return nil
+}
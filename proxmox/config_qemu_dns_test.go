@@ -0,0 +1,34 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DNSList(t *testing.T) {
+	require.Equal(t, "1.1.1.1 2606:4700::1111", formatDNSList([]string{"1.1.1.1", "2606:4700::1111"}))
+	require.Equal(t, []string{"1.1.1.1", "8.8.8.8"}, parseDNSList(" 1.1.1.1  8.8.8.8 "))
+	require.Empty(t, parseDNSList(""))
+	require.NoError(t, validateQemuNameservers([]string{"1.1.1.1", "2606:4700::1111"}))
+	require.Error(t, validateQemuNameservers([]string{"dns.example.com"}))
+	require.NoError(t, validateQemuSearchDomains([]string{"example.com", "lan"}))
+	require.Error(t, validateQemuSearchDomains([]string{"example.com lan"}))
+	require.Error(t, validateQemuSearchDomains([]string{""}))
+}
+
+func Test_ConfigQemu_DNS(t *testing.T) {
+	config := ConfigQemu{Nameservers: []string{"1.1.1.1"}}
+	require.True(t, config.HasCloudInit())
+	config.Nameservers = []string{"one.one.one.one"}
+	require.Error(t, config.Validate())
+	config = ConfigQemu{SearchDomains: []string{"example.com", "lan"}}
+	require.True(t, config.HasCloudInit())
+	require.NoError(t, config.Validate())
+
+	config = ConfigQemu{Nameserver: "1.1.1.1 8.8.8.8", Searchdomain: "example.com"}
+	require.Equal(t, []string{"1.1.1.1", "8.8.8.8"}, config.NameserverList())
+	require.Equal(t, []string{"example.com"}, config.SearchDomainList())
+	config.Nameservers = []string{"9.9.9.9"}
+	require.Equal(t, []string{"9.9.9.9"}, config.NameserverList())
+}
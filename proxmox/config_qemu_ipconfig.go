@@ -0,0 +1,115 @@
+package proxmox
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+const qemuIPConfigMaxID = 15
+
+// Cloud-init network settings of a network interface, ID 0 configures net0.
+type QemuIPConfig struct {
+	ID          uint8  `json:"id"`
+	IPv4        string `json:"ip,omitempty"` // dhcp or an address in CIDR notation
+	GatewayIPv4 string `json:"gw,omitempty"`
+	IPv6        string `json:"ip6,omitempty"` // dhcp, auto (SLAAC) or an address in CIDR notation
+	GatewayIPv6 string `json:"gw6,omitempty"`
+}
+
+func (config QemuIPConfig) mapToApiValues() string {
+	settings := []string{}
+	if config.IPv4 != "" {
+		settings = append(settings, "ip="+config.IPv4)
+	}
+	if config.GatewayIPv4 != "" {
+		settings = append(settings, "gw="+config.GatewayIPv4)
+	}
+	if config.IPv6 != "" {
+		settings = append(settings, "ip6="+config.IPv6)
+	}
+	if config.GatewayIPv6 != "" {
+		settings = append(settings, "gw6="+config.GatewayIPv6)
+	}
+	return strings.Join(settings, ",")
+}
+
+func (QemuIPConfig) mapToStruct(id uint8, setting string) QemuIPConfig {
+	config := QemuIPConfig{ID: id}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, _ := strings.Cut(e, "=")
+		switch key {
+		case "ip":
+			config.IPv4 = value
+		case "gw":
+			config.GatewayIPv4 = value
+		case "ip6":
+			config.IPv6 = value
+		case "gw6":
+			config.GatewayIPv6 = value
+		}
+	}
+	return config
+}
+
+func (config QemuIPConfig) Validate() error {
+	if config.ID > qemuIPConfigMaxID {
+		return fmt.Errorf("ipconfig id (%d) must be between 0 and %d", config.ID, qemuIPConfigMaxID)
+	}
+	if config == (QemuIPConfig{ID: config.ID}) {
+		return fmt.Errorf("ipconfig (%d) may not be empty", config.ID)
+	}
+	if config.IPv4 != "" && config.IPv4 != "dhcp" {
+		if ip, _, err := net.ParseCIDR(config.IPv4); err != nil || ip.To4() == nil {
+			return fmt.Errorf("ipconfig (%d) ip must be dhcp or an IPv4 address in CIDR notation", config.ID)
+		}
+	}
+	if config.GatewayIPv4 != "" {
+		if ip := net.ParseIP(config.GatewayIPv4); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("ipconfig (%d) gw must be an IPv4 address", config.ID)
+		}
+	}
+	if config.IPv6 != "" && config.IPv6 != "dhcp" && config.IPv6 != "auto" {
+		if ip, _, err := net.ParseCIDR(config.IPv6); err != nil || ip.To4() != nil {
+			return fmt.Errorf("ipconfig (%d) ip6 must be dhcp, auto or an IPv6 address in CIDR notation", config.ID)
+		}
+	}
+	if config.GatewayIPv6 != "" {
+		if ip := net.ParseIP(config.GatewayIPv6); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("ipconfig (%d) gw6 must be an IPv6 address", config.ID)
+		}
+	}
+	return nil
+}
+
+// Validates the ipconfigs and checks that every id is used once.
+func validateQemuIPConfigs(configs []QemuIPConfig) error {
+	ids := map[uint8]bool{}
+	for _, e := range configs {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+		if ids[e.ID] {
+			return fmt.Errorf("ipconfig id (%d) is used multiple times", e.ID)
+		}
+		ids[e.ID] = true
+	}
+	return nil
+}
+
+// IPConfigList returns the IPConfigs when they are set, otherwise the parsed untyped Ipconfig.
+func (config ConfigQemu) IPConfigList() []QemuIPConfig {
+	if len(config.IPConfigs) > 0 {
+		return config.IPConfigs
+	}
+	configs := []QemuIPConfig{}
+	for id, setting := range config.Ipconfig {
+		if setting, isString := setting.(string); isString && id >= 0 && id <= 255 {
+			configs = append(configs, QemuIPConfig{}.mapToStruct(uint8(id), setting))
+		}
+	}
+	// map iteration is random, sort for a stable output
+	sort.Slice(configs, func(i, j int) bool { return configs[i].ID < configs[j].ID })
+	return configs
+}
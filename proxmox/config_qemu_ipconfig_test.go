@@ -0,0 +1,52 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QemuIPConfig_RoundTrip(t *testing.T) {
+	for _, setting := range []string{
+		"ip=dhcp",
+		"ip=192.168.1.10/24,gw=192.168.1.1",
+		"ip6=auto",
+		"ip=10.0.0.5/8,gw=10.0.0.1,ip6=2001:db8::5/64,gw6=2001:db8::1",
+		"ip=dhcp,ip6=dhcp",
+	} {
+		require.Equal(t, setting, QemuIPConfig{}.mapToStruct(1, setting).mapToApiValues())
+	}
+	require.Equal(t, QemuIPConfig{ID: 2, IPv4: "10.0.0.5/8", GatewayIPv4: "10.0.0.1", IPv6: "2001:db8::5/64", GatewayIPv6: "2001:db8::1"},
+		QemuIPConfig{}.mapToStruct(2, "ip=10.0.0.5/8,gw=10.0.0.1,ip6=2001:db8::5/64,gw6=2001:db8::1"))
+}
+
+func Test_QemuIPConfig_Validate(t *testing.T) {
+	require.NoError(t, QemuIPConfig{IPv4: "dhcp", IPv6: "auto"}.Validate())
+	require.NoError(t, QemuIPConfig{ID: 15, IPv4: "192.168.1.10/24", GatewayIPv4: "192.168.1.1", IPv6: "2001:db8::5/64", GatewayIPv6: "2001:db8::1"}.Validate())
+	require.Error(t, QemuIPConfig{ID: 16, IPv4: "dhcp"}.Validate())
+	require.Error(t, QemuIPConfig{ID: 1}.Validate())
+	require.Error(t, QemuIPConfig{IPv4: "192.168.1.10"}.Validate())
+	require.Error(t, QemuIPConfig{IPv4: "2001:db8::5/64"}.Validate())
+	require.Error(t, QemuIPConfig{IPv4: "dhcp", GatewayIPv4: "2001:db8::1"}.Validate())
+	require.Error(t, QemuIPConfig{IPv6: "slaac"}.Validate())
+	require.Error(t, QemuIPConfig{IPv6: "192.168.1.10/24"}.Validate())
+	require.Error(t, QemuIPConfig{IPv6: "auto", GatewayIPv6: "192.168.1.1"}.Validate())
+	require.Error(t, validateQemuIPConfigs([]QemuIPConfig{{ID: 0, IPv4: "dhcp"}, {ID: 0, IPv6: "auto"}}))
+}
+
+func Test_ConfigQemu_IPConfigList(t *testing.T) {
+	config := ConfigQemu{Ipconfig: IpconfigMap{1: "ip6=auto", 0: "ip=dhcp"}}
+	require.Equal(t, []QemuIPConfig{{ID: 0, IPv4: "dhcp"}, {ID: 1, IPv6: "auto"}}, config.IPConfigList())
+
+	// the untyped settings are kept when the typed ones are not set
+	config.Ipconfig[0] = "ip=10.0.0.2/24,gw=10.0.0.1"
+	params := map[string]interface{}{}
+	require.NoError(t, config.CreateIpconfigParams(100, params))
+	require.Equal(t, map[string]interface{}{"ipconfig0": "ip=10.0.0.2/24,gw=10.0.0.1", "ipconfig1": "ip6=auto"}, params)
+
+	config.IPConfigs = []QemuIPConfig{{ID: 0, IPv4: "dhcp"}}
+	require.Equal(t, config.IPConfigs, config.IPConfigList())
+	params = map[string]interface{}{}
+	require.NoError(t, config.CreateIpconfigParams(100, params))
+	require.Equal(t, "ip=dhcp", params["ipconfig0"])
+}
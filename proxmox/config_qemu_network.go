@@ -0,0 +1,121 @@
+package proxmox
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const qemuNicMaxQueues = 64
+
+// Converts the value of a numeric device setting, which is a float64 when decoded from JSON, an int when parsed from the API, or a string.
+func qemuDeviceNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// Returns the queues= and rate= settings of the nic.
+// Queues is the number of multiqueue virtio queues, Rate the egress rate limit in MB/s.
+func qemuNicQueuesAndRate(nic QemuDevice) (settings []string, err error) {
+	if value, isSet := nic["queues"]; isSet {
+		queues, ok := qemuDeviceNumber(value)
+		if !ok || queues != math.Trunc(queues) || queues < 0 || queues > qemuNicMaxQueues {
+			return nil, fmt.Errorf("nic queues (%v) must be an integer between 0 and %d", value, qemuNicMaxQueues)
+		}
+		if queues > 0 {
+			settings = append(settings, "queues="+strconv.Itoa(int(queues)))
+		}
+	}
+	if value, isSet := nic["rate"]; isSet {
+		rate, ok := qemuDeviceNumber(value)
+		if !ok || rate < 0 {
+			return nil, fmt.Errorf("nic rate (%v) must be a non-negative number in MB/s", value)
+		}
+		if rate > 0 {
+			settings = append(settings, "rate="+strconv.FormatFloat(rate, 'f', -1, 64))
+		}
+	}
+	return
+}
+
+// Normalizes queues to an int and rate to a float64, as the API returns "rate=10" and "rate=12.5" as different types.
+func readQemuNicQueuesAndRate(nic QemuDevice) {
+	if value, isSet := nic["queues"]; isSet {
+		if queues, ok := qemuDeviceNumber(value); ok {
+			nic["queues"] = int(queues)
+		}
+	}
+	if value, isSet := nic["rate"]; isSet {
+		if rate, ok := qemuDeviceNumber(value); ok {
+			nic["rate"] = rate
+		}
+	}
+}
+
+// Returns the link_down= setting of the nic, the value may be a bool, a number or a string.
+func qemuNicLinkDown(nic QemuDevice) string {
+	switch v := nic["link_down"].(type) {
+	case bool:
+		if v {
+			return "link_down=1"
+		}
+	case string:
+		if v == "1" || v == "true" {
+			return "link_down=1"
+		}
+	default:
+		if number, ok := qemuDeviceNumber(v); ok && number == 1 {
+			return "link_down=1"
+		}
+	}
+	return ""
+}
+
+func copyQemuDevice(device QemuDevice) QemuDevice {
+	copied := make(QemuDevice, len(device))
+	for key, value := range device {
+		copied[key] = value
+	}
+	return copied
+}
+
+func copyQemuDevices(devices QemuDevices) QemuDevices {
+	if devices == nil {
+		return nil
+	}
+	copied := make(QemuDevices, len(devices))
+	for id, device := range devices {
+		copied[id] = copyQemuDevice(device)
+	}
+	return copied
+}
+
+// Returns the nics with the link_down state of the current nics copied to the nics that don't set it explicitly,
+// so updating other settings of a nic doesn't bring its link back up.
+// The nics of the config are not changed, a nic that gets the link_down state is copied.
+func (c ConfigQemu) preserveQemuNicLinkDown(currentConfig map[string]interface{}) QemuDevices {
+	nics := make(QemuDevices, len(c.QemuNetworks))
+	for nicID, nic := range c.QemuNetworks {
+		nics[nicID] = nic
+		if _, isSet := nic["link_down"]; isSet {
+			continue
+		}
+		current, _ := currentConfig["net"+strconv.Itoa(nicID)].(string)
+		for _, e := range strings.Split(current, ",") {
+			if key, value, _ := strings.Cut(e, "="); key == "link_down" {
+				nics[nicID] = copyQemuDevice(nic)
+				nics[nicID]["link_down"] = value == "1"
+			}
+		}
+	}
+	return nics
+}
@@ -0,0 +1,96 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_qemuNicQueuesAndRate(t *testing.T) {
+	tests := []struct {
+		input  QemuDevice
+		output []string
+		err    bool
+	}{
+		{input: QemuDevice{}},
+		{input: QemuDevice{"queues": 4, "rate": 12.5}, output: []string{"queues=4", "rate=12.5"}},
+		// JSON numbers are decoded as float64
+		{input: QemuDevice{"queues": float64(8), "rate": float64(100)}, output: []string{"queues=8", "rate=100"}},
+		{input: QemuDevice{"queues": "2", "rate": "0.5"}, output: []string{"queues=2", "rate=0.5"}},
+		{input: QemuDevice{"queues": 0, "rate": 0}},
+		{input: QemuDevice{"queues": 65}, err: true},
+		{input: QemuDevice{"queues": 1.5}, err: true},
+		{input: QemuDevice{"queues": -1}, err: true},
+		{input: QemuDevice{"rate": -1}, err: true},
+		{input: QemuDevice{"rate": "fast"}, err: true},
+	}
+	for _, test := range tests {
+		settings, err := qemuNicQueuesAndRate(test.input)
+		if test.err {
+			require.Error(t, err, test.input)
+		} else {
+			require.NoError(t, err, test.input)
+			require.Equal(t, test.output, settings, test.input)
+		}
+	}
+}
+
+func Test_readQemuNicQueuesAndRate(t *testing.T) {
+	nic := QemuDevice{"queues": 4, "rate": "12.5"}
+	readQemuNicQueuesAndRate(nic)
+	require.Equal(t, QemuDevice{"queues": 4, "rate": 12.5}, nic)
+
+	nic = QemuDevice{"rate": 10}
+	readQemuNicQueuesAndRate(nic)
+	require.Equal(t, QemuDevice{"rate": float64(10)}, nic)
+}
+
+func Test_ConfigQemu_CreateQemuNetworksParams_QueuesAndRate(t *testing.T) {
+	config := ConfigQemu{QemuNetworks: QemuDevices{0: QemuDevice{"model": "virtio", "macaddr": "AA:BB:CC:DD:EE:FF", "bridge": "vmbr0", "queues": float64(4), "rate": 12.5}}}
+	params := map[string]interface{}{}
+	require.NoError(t, config.CreateQemuNetworksParams(100, params))
+	require.Equal(t, "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,queues=4,rate=12.5", params["net0"])
+}
+
+func Test_qemuNicLinkDown(t *testing.T) {
+	for _, value := range []interface{}{true, 1, float64(1), "1", "true"} {
+		require.Equal(t, "link_down=1", qemuNicLinkDown(QemuDevice{"link_down": value}), value)
+	}
+	for _, value := range []interface{}{false, 0, float64(0), "0", nil} {
+		require.Equal(t, "", qemuNicLinkDown(QemuDevice{"link_down": value}), value)
+	}
+	require.Equal(t, "", qemuNicLinkDown(QemuDevice{}))
+}
+
+func Test_ConfigQemu_preserveQemuNicLinkDown(t *testing.T) {
+	config := ConfigQemu{QemuNetworks: QemuDevices{
+		0: QemuDevice{"model": "virtio"},
+		1: QemuDevice{"model": "virtio", "link_down": false},
+		2: QemuDevice{"model": "virtio"},
+	}}
+	nics := config.preserveQemuNicLinkDown(map[string]interface{}{
+		"net0": "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,link_down=1",
+		"net1": "virtio=AA:BB:CC:DD:EE:FE,bridge=vmbr0,link_down=1",
+		"net2": "virtio=AA:BB:CC:DD:EE:FD,bridge=vmbr0",
+	})
+	require.Equal(t, true, nics[0]["link_down"])
+	require.Equal(t, false, nics[1]["link_down"])
+	require.NotContains(t, nics[2], "link_down")
+	// the nics of the config are left as they are
+	require.NotContains(t, config.QemuNetworks[0], "link_down")
+	config.QemuNetworks = nics
+
+	params := map[string]interface{}{}
+	config.QemuNetworks[0]["macaddr"] = "AA:BB:CC:DD:EE:FF"
+	config.QemuNetworks[0]["bridge"] = "vmbr0"
+	require.NoError(t, ConfigQemu{QemuNetworks: QemuDevices{0: config.QemuNetworks[0]}}.CreateQemuNetworksParams(100, params))
+	require.Equal(t, "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,link_down=1", params["net0"])
+}
+
+func Test_copyQemuDevices(t *testing.T) {
+	require.Nil(t, copyQemuDevices(nil))
+	devices := QemuDevices{0: QemuDevice{"model": "virtio"}}
+	copied := copyQemuDevices(devices)
+	copied[0]["macaddr"] = "AA:BB:CC:DD:EE:FF"
+	require.Equal(t, QemuDevices{0: QemuDevice{"model": "virtio"}}, devices)
+}
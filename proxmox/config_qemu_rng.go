@@ -0,0 +1,62 @@
+package proxmox
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	QemuRngSource_HwRng   string = "/dev/hwrng"
+	QemuRngSource_Random  string = "/dev/random"
+	QemuRngSource_URandom string = "/dev/urandom"
+)
+
+// VirtIO random number generator, passes entropy from the host to the guest.
+type QemuRng struct {
+	Source string `json:"source"`
+	// Maximum bytes of entropy allowed to get injected into the guest every Period, 0 disables the limit.
+	MaxBytes int `json:"max_bytes,omitempty"`
+	// Period in milliseconds.
+	Period int `json:"period,omitempty"`
+}
+
+func (rng QemuRng) mapToApiValues() string {
+	settings := "source=" + rng.Source
+	if rng.MaxBytes != 0 {
+		settings += ",max_bytes=" + strconv.Itoa(rng.MaxBytes)
+	}
+	if rng.Period != 0 {
+		settings += ",period=" + strconv.Itoa(rng.Period)
+	}
+	return settings
+}
+
+func (QemuRng) mapToStruct(setting string) *QemuRng {
+	rng := QemuRng{}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, _ := strings.Cut(e, "=")
+		switch key {
+		case "source":
+			rng.Source = value
+		case "max_bytes":
+			rng.MaxBytes, _ = strconv.Atoi(value)
+		case "period":
+			rng.Period, _ = strconv.Atoi(value)
+		}
+	}
+	return &rng
+}
+
+func (rng QemuRng) Validate() error {
+	if !inArray([]string{QemuRngSource_HwRng, QemuRngSource_Random, QemuRngSource_URandom}, rng.Source) {
+		return errors.New("rng source must be one of (" + QemuRngSource_HwRng + "," + QemuRngSource_Random + "," + QemuRngSource_URandom + ")")
+	}
+	if rng.MaxBytes < 0 {
+		return errors.New("rng max_bytes may not be negative")
+	}
+	if rng.Period < 0 {
+		return errors.New("rng period may not be negative")
+	}
+	return nil
+}
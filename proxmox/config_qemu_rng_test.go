@@ -0,0 +1,36 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QemuRng_mapToApiValues(t *testing.T) {
+	require.Equal(t, "source=/dev/urandom", QemuRng{Source: QemuRngSource_URandom}.mapToApiValues())
+	require.Equal(t, "source=/dev/hwrng,max_bytes=1024,period=1000", QemuRng{Source: QemuRngSource_HwRng, MaxBytes: 1024, Period: 1000}.mapToApiValues())
+}
+
+func Test_QemuRng_mapToStruct(t *testing.T) {
+	require.Equal(t, &QemuRng{Source: QemuRngSource_URandom}, QemuRng{}.mapToStruct("source=/dev/urandom"))
+	require.Equal(t, &QemuRng{Source: QemuRngSource_Random, MaxBytes: 1024, Period: 1000}, QemuRng{}.mapToStruct("max_bytes=1024,period=1000,source=/dev/random"))
+}
+
+func Test_QemuRng_Validate(t *testing.T) {
+	errSource := errors.New("rng source must be one of (/dev/hwrng,/dev/random,/dev/urandom)")
+	tests := []struct {
+		input QemuRng
+		err   error
+	}{
+		{input: QemuRng{Source: QemuRngSource_URandom}},
+		{input: QemuRng{Source: QemuRngSource_HwRng, MaxBytes: 1024, Period: 1000}},
+		{input: QemuRng{Source: "/dev/zero"}, err: errSource},
+		{input: QemuRng{}, err: errSource},
+		{input: QemuRng{Source: QemuRngSource_Random, MaxBytes: -1}, err: errors.New("rng max_bytes may not be negative")},
+		{input: QemuRng{Source: QemuRngSource_Random, Period: -1}, err: errors.New("rng period may not be negative")},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.err, test.input.Validate())
+	}
+}
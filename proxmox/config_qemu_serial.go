@@ -0,0 +1,138 @@
+package proxmox
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const qemuPortMaxID = 3
+
+var (
+	rxParallelName   = regexp.MustCompile(`^parallel\d+$`)
+	rxParallelDevice = regexp.MustCompile(`^(/dev/parport\d+|/dev/usb/lp\d+)$`)
+	rxSerialDevice   = regexp.MustCompile(`^/dev/.+$`)
+)
+
+// Serial port of a guest, either a unix socket on the host (Socket) or a passed through host device (Path).
+type QemuSerialPort struct {
+	ID     uint8  `json:"id"`
+	Socket bool   `json:"socket,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+func (port QemuSerialPort) mapToApiValues() string {
+	if port.Socket {
+		return "socket"
+	}
+	return port.Path
+}
+
+func (QemuSerialPort) mapToStruct(id uint8, setting string) QemuSerialPort {
+	if setting == "socket" {
+		return QemuSerialPort{ID: id, Socket: true}
+	}
+	return QemuSerialPort{ID: id, Path: setting}
+}
+
+func (port QemuSerialPort) Validate() error {
+	if port.ID > qemuPortMaxID {
+		return fmt.Errorf("serial port id (%d) must be between 0 and %d", port.ID, qemuPortMaxID)
+	}
+	if port.Socket == (port.Path != "") {
+		return fmt.Errorf("serial port (%d) must either be a socket or have a path", port.ID)
+	}
+	if !port.Socket && !rxSerialDevice.MatchString(port.Path) {
+		return fmt.Errorf("serial port (%d) path must be a host device in /dev/", port.ID)
+	}
+	return nil
+}
+
+// Parallel port of a guest, always a passed through host device.
+type QemuParallelPort struct {
+	ID   uint8  `json:"id"`
+	Path string `json:"path"`
+}
+
+func (port QemuParallelPort) Validate() error {
+	if port.ID > qemuPortMaxID {
+		return fmt.Errorf("parallel port id (%d) must be between 0 and %d", port.ID, qemuPortMaxID)
+	}
+	if !rxParallelDevice.MatchString(port.Path) {
+		return fmt.Errorf("parallel port (%d) path must be /dev/parportN or /dev/usb/lpN", port.ID)
+	}
+	return nil
+}
+
+// Create parameters for the serial and parallel ports, the typed ports are added after the untyped parallel ports.
+func (c ConfigQemu) CreateQemuPortsParams(params map[string]interface{}) error {
+	for id, device := range c.QemuParallels {
+		params["parallel"+strconv.Itoa(id)] = device["type"]
+	}
+	serialIDs := map[uint8]bool{}
+	for _, port := range c.Serials {
+		if err := port.Validate(); err != nil {
+			return err
+		}
+		if serialIDs[port.ID] {
+			return fmt.Errorf("serial port id (%d) is used multiple times", port.ID)
+		}
+		serialIDs[port.ID] = true
+		params["serial"+strconv.Itoa(int(port.ID))] = port.mapToApiValues()
+	}
+	parallelIDs := map[uint8]bool{}
+	for _, port := range c.Parallels {
+		if err := port.Validate(); err != nil {
+			return err
+		}
+		if parallelIDs[port.ID] {
+			return fmt.Errorf("parallel port id (%d) is used multiple times", port.ID)
+		}
+		parallelIDs[port.ID] = true
+		params["parallel"+strconv.Itoa(int(port.ID))] = port.Path
+	}
+	return nil
+}
+
+// Read the parallel ports from the guest config, in the same format as QemuSerials.
+// The typed ports are not filled, they would take precedence over changes to the untyped ports on update.
+func (c *ConfigQemu) readQemuParallels(vmConfig map[string]interface{}) {
+	for k, v := range vmConfig {
+		if rxParallelName.MatchString(k) {
+			id, _ := strconv.Atoi(rxDeviceID.FindString(k))
+			c.QemuParallels[id] = QemuDevice{"id": id, "type": v}
+		}
+	}
+}
+
+// SerialList returns the Serials when they are set, otherwise the parsed untyped QemuSerials.
+func (c ConfigQemu) SerialList() []QemuSerialPort {
+	if len(c.Serials) > 0 {
+		return c.Serials
+	}
+	ports := []QemuSerialPort{}
+	for id, device := range c.QemuSerials {
+		if setting, isString := device["type"].(string); isString && id >= 0 && id <= qemuPortMaxID {
+			ports = append(ports, QemuSerialPort{}.mapToStruct(uint8(id), setting))
+		}
+	}
+	// map iteration is random, sort for a stable output
+	sort.Slice(ports, func(i, j int) bool { return ports[i].ID < ports[j].ID })
+	return ports
+}
+
+// ParallelList returns the Parallels when they are set, otherwise the parsed untyped QemuParallels.
+func (c ConfigQemu) ParallelList() []QemuParallelPort {
+	if len(c.Parallels) > 0 {
+		return c.Parallels
+	}
+	ports := []QemuParallelPort{}
+	for id, device := range c.QemuParallels {
+		if path, isString := device["type"].(string); isString && id >= 0 && id <= qemuPortMaxID {
+			ports = append(ports, QemuParallelPort{ID: uint8(id), Path: path})
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i].ID < ports[j].ID })
+	return ports
+}
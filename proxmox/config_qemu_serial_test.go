@@ -0,0 +1,102 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QemuSerialPort_Validate(t *testing.T) {
+	testData := []struct {
+		input QemuSerialPort
+		err   bool
+	}{
+		{input: QemuSerialPort{ID: 0, Socket: true}},
+		{input: QemuSerialPort{ID: 3, Path: "/dev/ttyS0"}},
+		{input: QemuSerialPort{ID: 4, Socket: true}, err: true},
+		{input: QemuSerialPort{ID: 1}, err: true},
+		{input: QemuSerialPort{ID: 1, Socket: true, Path: "/dev/ttyS0"}, err: true},
+		{input: QemuSerialPort{ID: 1, Path: "ttyS0"}, err: true},
+	}
+	for _, e := range testData {
+		if e.err {
+			require.Error(t, e.input.Validate())
+		} else {
+			require.NoError(t, e.input.Validate())
+		}
+	}
+}
+
+func Test_QemuParallelPort_Validate(t *testing.T) {
+	testData := []struct {
+		input QemuParallelPort
+		err   bool
+	}{
+		{input: QemuParallelPort{ID: 0, Path: "/dev/parport0"}},
+		{input: QemuParallelPort{ID: 3, Path: "/dev/usb/lp1"}},
+		{input: QemuParallelPort{ID: 4, Path: "/dev/parport0"}, err: true},
+		{input: QemuParallelPort{ID: 0, Path: "/dev/ttyS0"}, err: true},
+		{input: QemuParallelPort{ID: 0}, err: true},
+	}
+	for _, e := range testData {
+		if e.err {
+			require.Error(t, e.input.Validate())
+		} else {
+			require.NoError(t, e.input.Validate())
+		}
+	}
+}
+
+func Test_ConfigQemu_CreateQemuPortsParams(t *testing.T) {
+	config := ConfigQemu{
+		Serials:   []QemuSerialPort{{ID: 0, Socket: true}, {ID: 2, Path: "/dev/ttyS1"}},
+		Parallels: []QemuParallelPort{{ID: 1, Path: "/dev/parport0"}},
+	}
+	params := map[string]interface{}{}
+	require.NoError(t, config.CreateQemuPortsParams(params))
+	require.Equal(t, map[string]interface{}{
+		"serial0":   "socket",
+		"serial2":   "/dev/ttyS1",
+		"parallel1": "/dev/parport0",
+	}, params)
+
+	config = ConfigQemu{Serials: []QemuSerialPort{{ID: 0, Socket: true}, {ID: 0, Path: "/dev/ttyS1"}}}
+	require.Error(t, config.CreateQemuPortsParams(map[string]interface{}{}))
+}
+
+func Test_ConfigQemu_SerialList(t *testing.T) {
+	config := ConfigQemu{QemuSerials: QemuDevices{
+		3: {"id": 3, "type": "/dev/ttyS0"},
+		0: {"id": 0, "type": "socket"},
+	}}
+	require.Equal(t, []QemuSerialPort{{ID: 0, Socket: true}, {ID: 3, Path: "/dev/ttyS0"}}, config.SerialList())
+	config.Serials = []QemuSerialPort{{ID: 1, Socket: true}}
+	require.Equal(t, []QemuSerialPort{{ID: 1, Socket: true}}, config.SerialList())
+}
+
+func Test_ConfigQemu_ParallelList(t *testing.T) {
+	config := ConfigQemu{QemuParallels: QemuDevices{}}
+	config.readQemuParallels(map[string]interface{}{
+		"parallel2": "/dev/usb/lp0",
+		"parallel0": "/dev/parport0",
+		"serial0":   "socket",
+		"name":      "test",
+	})
+	require.Empty(t, config.Parallels)
+	require.Equal(t, []QemuParallelPort{{ID: 0, Path: "/dev/parport0"}, {ID: 2, Path: "/dev/usb/lp0"}}, config.ParallelList())
+	config.Parallels = []QemuParallelPort{{ID: 1, Path: "/dev/parport1"}}
+	require.Equal(t, []QemuParallelPort{{ID: 1, Path: "/dev/parport1"}}, config.ParallelList())
+}
+
+// Changes to the ports of a config that was read from the guest must end up in the params.
+func Test_ConfigQemu_ports_roundTrip(t *testing.T) {
+	vmConfig := map[string]interface{}{"serial0": "socket", "parallel1": "/dev/parport0"}
+	config := ConfigQemu{QemuSerials: QemuDevices{0: {"id": 0, "type": "socket"}}, QemuParallels: QemuDevices{}}
+	config.readQemuParallels(vmConfig)
+	config.QemuSerials[0]["type"] = "/dev/ttyS1"
+	config.QemuParallels[1]["type"] = "/dev/parport1"
+	params := map[string]interface{}{}
+	require.NoError(t, config.CreateQemuSerialsParams(0, params))
+	require.NoError(t, config.CreateQemuPortsParams(params))
+	require.Equal(t, map[string]interface{}{"serial0": "/dev/ttyS1", "parallel1": "/dev/parport1"}, params)
+}
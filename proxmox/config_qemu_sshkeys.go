@@ -0,0 +1,102 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Trims the keys and removes empty and duplicate keys, the order is kept.
+func normalizeSSHKeys(keys []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, key)
+	}
+	return normalized
+}
+
+// Parses the decoded sshkeys setting, which holds one key per line.
+func parseSSHKeys(keys string) []string {
+	return normalizeSSHKeys(strings.Split(keys, "\n"))
+}
+
+// Returns the keys as the url encoded sshkeys setting.
+func formatSSHKeys(keys []string) string {
+	return sshKeyUrlEncode(strings.Join(normalizeSSHKeys(keys), "\n"))
+}
+
+// SSHKeyList returns the SSHKeys when they are set, otherwise the parsed Sshkeys.
+func (config ConfigQemu) SSHKeyList() []string {
+	if len(config.SSHKeys) > 0 {
+		return normalizeSSHKeys(config.SSHKeys)
+	}
+	return parseSSHKeys(config.Sshkeys)
+}
+
+// Appends the keys that are not in current yet.
+func addSSHKeys(current, keys []string) []string {
+	return normalizeSSHKeys(append(append([]string{}, current...), keys...))
+}
+
+func removeSSHKeys(current, keys []string) []string {
+	remove := map[string]bool{}
+	for _, key := range keys {
+		remove[strings.TrimSpace(key)] = true
+	}
+	kept := []string{}
+	for _, key := range normalizeSSHKeys(current) {
+		if !remove[key] {
+			kept = append(kept, key)
+		}
+	}
+	return kept
+}
+
+// AddSSHKey adds the public keys to the cloud-init keys of the virtual machine, keys that are already set are skipped.
+// The existing keys are kept, unlike setting SSHKeys with UpdateConfig.
+func (c *Client) AddSSHKey(ctx context.Context, vmr *VmRef, keys ...string) error {
+	return c.updateSSHKeys(ctx, vmr, func(current []string) []string { return addSSHKeys(current, keys) })
+}
+
+// RemoveSSHKey removes the public keys from the cloud-init keys of the virtual machine, keys that are not set are skipped.
+func (c *Client) RemoveSSHKey(ctx context.Context, vmr *VmRef, keys ...string) error {
+	return c.updateSSHKeys(ctx, vmr, func(current []string) []string { return removeSSHKeys(current, keys) })
+}
+
+func (c *Client) updateSSHKeys(ctx context.Context, vmr *VmRef, change func([]string) []string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return err
+	}
+	if vmr.vmType != "qemu" {
+		return fmt.Errorf("guest %d is not a virtual machine", vmr.vmId)
+	}
+	var current []string
+	if setting, isSet := vmConfig["sshkeys"].(string); isSet {
+		decoded, err := url.PathUnescape(setting)
+		if err != nil {
+			return err
+		}
+		current = parseSSHKeys(decoded)
+	}
+	keys := change(current)
+	if strings.Join(keys, "\n") == strings.Join(current, "\n") {
+		return nil
+	}
+	params := map[string]interface{}{"sshkeys": formatSSHKeys(keys)}
+	if len(keys) == 0 {
+		params = map[string]interface{}{"delete": "sshkeys"}
+	}
+	_, err = c.SetVmConfig(ctx, vmr, params)
+	return err
+}
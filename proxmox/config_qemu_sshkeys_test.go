@@ -0,0 +1,30 @@
+package proxmox
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SSHKeys(t *testing.T) {
+	rsa := "ssh-rsa AAAAB3NzaC1yc2E+/= user@host"
+	ed25519 := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5 other@host"
+	require.Equal(t, []string{rsa, ed25519}, parseSSHKeys(rsa+"\n\n "+ed25519+" \n"+rsa+"\n"))
+
+	encoded := formatSSHKeys([]string{rsa, ed25519, rsa})
+	decoded, err := url.PathUnescape(encoded)
+	require.NoError(t, err)
+	require.Equal(t, rsa+"\n"+ed25519+"\n", decoded)
+	require.Equal(t, []string{rsa, ed25519}, parseSSHKeys(decoded))
+
+	require.Equal(t, []string{rsa, ed25519}, addSSHKeys([]string{rsa}, []string{ed25519, rsa}))
+	require.Equal(t, []string{ed25519}, addSSHKeys(nil, []string{ed25519}))
+	require.Equal(t, []string{ed25519}, removeSSHKeys([]string{rsa, ed25519}, []string{" " + rsa}))
+	require.Equal(t, []string{}, removeSSHKeys([]string{rsa}, []string{rsa}))
+
+	config := ConfigQemu{Sshkeys: rsa + "\n" + ed25519}
+	require.Equal(t, []string{rsa, ed25519}, config.SSHKeyList())
+	config.SSHKeys = []string{ed25519}
+	require.Equal(t, []string{ed25519}, config.SSHKeyList())
+}
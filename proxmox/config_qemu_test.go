@@ -32,3 +32,24 @@ func test_sshKeyUrlEncode_Output() string {
 		"ssh-ed25519%20AAAAC3NzaC1lZDI1NTE5AAAAIEY5T2JQgiL5Z5Yuy4yXuUYglVJlpsokHFXR1hvnCVYW%20cardno%3A18%20228%20342"}
 	return strings.Join(encodedKeys, "%0A") + "%0A"
 }
+
+func Test_ConfigQemu_mapToApiCreateValues(t *testing.T) {
+	vmr := NewVmRef(100)
+	config := ConfigQemu{Name: "test", Memory: 2048, Balloon: PointerInt(0), Onboot: PointerBool(false)}
+	params, err := config.mapToApiCreateValues(vmr, false)
+	require.NoError(t, err)
+	require.Equal(t, "agent=0&balloon=0&cores=0&memory=2048&name=test&onboot=0&sockets=0&vmid=100", ParamsToValues(params).Encode())
+	params, err = config.mapToApiCreateValues(vmr, true)
+	require.NoError(t, err)
+	require.Equal(t, "balloon=0&memory=2048&name=test&onboot=0&vmid=100", ParamsToValues(params).Encode())
+	params, err = ConfigQemu{}.mapToApiCreateValues(vmr, true)
+	require.NoError(t, err)
+	require.Equal(t, "vmid=100", ParamsToValues(params).Encode())
+}
+
+func Test_FormatDiskParam_ImportFrom(t *testing.T) {
+	disk := QemuDevice{"type": "scsi", "storage": "local-lvm", "size": "8G", "import_from": "local:import/debian.qcow2", "discard": "on"}
+	require.Equal(t, "local-lvm:0,import-from=local:import/debian.qcow2,discard=on", FormatDiskParam(disk))
+	delete(disk, "import_from")
+	require.Equal(t, "local-lvm:8,discard=on", FormatDiskParam(disk))
+}
@@ -0,0 +1,96 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var rxDeviceKey = regexp.MustCompile(`^[a-z]+[0-9]+$`)
+
+// Param of the guest config that would be changed by UpdateConfig.
+type ConfigChange struct {
+	Key     string `json:"key"`
+	Current string `json:"current,omitempty"` // empty when the param is not set yet
+	New     string `json:"new"`
+}
+
+// Compares the params as they would be sent against the current config, sorted by key.
+func configChanges(current map[string]interface{}, params url.Values) []ConfigChange {
+	changes := make([]ConfigChange, 0)
+	for key, values := range params {
+		newValue := strings.Join(values, ",")
+		var currentValue string
+		if value, isSet := current[key]; isSet {
+			currentValue = configValueString(value)
+		}
+		if !sameConfigValue(key, currentValue, newValue) {
+			changes = append(changes, ConfigChange{Key: key, Current: currentValue, New: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// Settings of devices (e.g. net0 or scsi0) are compared regardless of the order of their options.
+func sameConfigValue(key, a, b string) bool {
+	if a == b {
+		return true
+	}
+	if !rxDeviceKey.MatchString(key) {
+		return false
+	}
+	optionsA, optionsB := strings.Split(a, ","), strings.Split(b, ",")
+	sort.Strings(optionsA)
+	sort.Strings(optionsB)
+	return strings.Join(optionsA, ",") == strings.Join(optionsB, ",")
+}
+
+// Numbers arrive as float64 and have to be formatted without an exponent.
+func configValueString(value interface{}) string {
+	if number, isFloat := value.(float64); isFloat {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// UpdatePlan returns the params UpdateConfig would change compared to the current config of the guest, without changing anything.
+// New disks are listed in the form they are created with, HA and pool membership are not compared.
+func (config ConfigQemu) UpdatePlan(ctx context.Context, vmr *VmRef, client *Client) ([]ConfigChange, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// creating the params fills generated settings like mac addresses into the devices
+	config.QemuDisks = copyQemuDevices(config.QemuDisks)
+	config.QemuNetworks = copyQemuDevices(config.QemuNetworks)
+	if err := client.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	current, err := client.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return nil, err
+	}
+	params, err := config.mapToApiUpdateParams(vmr, current)
+	if err != nil {
+		return nil, err
+	}
+	return configChanges(current, ParamsToValues(params)), nil
+}
+
+// Same as mapToApiUpdateValuesWithCurrent, with the params of the disks included.
+func (config ConfigQemu) mapToApiUpdateParams(vmr *VmRef, currentConfig map[string]interface{}) (map[string]interface{}, error) {
+	params, diskParams, err := config.mapToApiUpdateValuesWithCurrent(vmr, currentConfig)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range diskParams {
+		if key != "vmid" {
+			params[key] = value
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,34 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_configChanges(t *testing.T) {
+	current := map[string]interface{}{
+		"memory": float64(1048576),
+		"cores":  float64(2),
+		"onboot": float64(1),
+		"name":   "test",
+	}
+	params := map[string]interface{}{
+		"memory":      1048576,
+		"cores":       4,
+		"onboot":      true,
+		"name":        "test",
+		"description": "new",
+	}
+	require.Equal(t, []ConfigChange{
+		{Key: "cores", Current: "2", New: "4"},
+		{Key: "description", New: "new"},
+	}, configChanges(current, ParamsToValues(params)))
+	require.Equal(t, []ConfigChange{}, configChanges(current, ParamsToValues(map[string]interface{}{"name": "test"})))
+}
+
+func Test_sameConfigValue(t *testing.T) {
+	require.True(t, sameConfigValue("net0", "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,firewall=1", "virtio=AA:BB:CC:DD:EE:FF,firewall=1,bridge=vmbr0"))
+	require.False(t, sameConfigValue("net0", "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0", "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr1"))
+	require.False(t, sameConfigValue("description", "a,b", "b,a"))
+}
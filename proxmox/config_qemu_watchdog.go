@@ -0,0 +1,63 @@
+package proxmox
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	QemuWatchdogModel_I6300esb string = "i6300esb"
+	QemuWatchdogModel_Ib700    string = "ib700"
+)
+
+const (
+	QemuWatchdogAction_Debug    string = "debug"
+	QemuWatchdogAction_None     string = "none"
+	QemuWatchdogAction_Pause    string = "pause"
+	QemuWatchdogAction_Poweroff string = "poweroff"
+	QemuWatchdogAction_Reset    string = "reset"
+	QemuWatchdogAction_Shutdown string = "shutdown"
+)
+
+// Virtual hardware watchdog, the Action is taken when the guest stops feeding the watchdog.
+type QemuWatchdog struct {
+	Model  string `json:"model"`
+	Action string `json:"action,omitempty"`
+}
+
+func (watchdog QemuWatchdog) mapToApiValues() string {
+	settings := "model=" + watchdog.Model
+	if watchdog.Action != "" {
+		settings += ",action=" + watchdog.Action
+	}
+	return settings
+}
+
+func (QemuWatchdog) mapToStruct(setting string) *QemuWatchdog {
+	watchdog := QemuWatchdog{}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, hasValue := strings.Cut(e, "=")
+		// the model is the default key
+		if !hasValue {
+			key, value = "model", key
+		}
+		switch key {
+		case "model":
+			watchdog.Model = value
+		case "action":
+			watchdog.Action = value
+		}
+	}
+	return &watchdog
+}
+
+func (watchdog QemuWatchdog) Validate() error {
+	if !inArray([]string{QemuWatchdogModel_I6300esb, QemuWatchdogModel_Ib700}, watchdog.Model) {
+		return errors.New("watchdog model must be one of (" + QemuWatchdogModel_I6300esb + "," + QemuWatchdogModel_Ib700 + ")")
+	}
+	actions := []string{QemuWatchdogAction_Debug, QemuWatchdogAction_None, QemuWatchdogAction_Pause, QemuWatchdogAction_Poweroff, QemuWatchdogAction_Reset, QemuWatchdogAction_Shutdown}
+	if watchdog.Action != "" && !inArray(actions, watchdog.Action) {
+		return errors.New("watchdog action must be one of (" + strings.Join(actions, ",") + ")")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QemuWatchdog_mapToApiValues(t *testing.T) {
+	require.Equal(t, "model=i6300esb", QemuWatchdog{Model: QemuWatchdogModel_I6300esb}.mapToApiValues())
+	require.Equal(t, "model=ib700,action=poweroff", QemuWatchdog{Model: QemuWatchdogModel_Ib700, Action: QemuWatchdogAction_Poweroff}.mapToApiValues())
+}
+
+func Test_QemuWatchdog_mapToStruct(t *testing.T) {
+	require.Equal(t, &QemuWatchdog{Model: QemuWatchdogModel_I6300esb}, QemuWatchdog{}.mapToStruct("model=i6300esb"))
+	require.Equal(t, &QemuWatchdog{Model: QemuWatchdogModel_Ib700, Action: QemuWatchdogAction_Reset}, QemuWatchdog{}.mapToStruct("ib700,action=reset"))
+}
+
+func Test_QemuWatchdog_Validate(t *testing.T) {
+	errModel := errors.New("watchdog model must be one of (i6300esb,ib700)")
+	tests := []struct {
+		input QemuWatchdog
+		err   error
+	}{
+		{input: QemuWatchdog{Model: QemuWatchdogModel_I6300esb}},
+		{input: QemuWatchdog{Model: QemuWatchdogModel_Ib700, Action: QemuWatchdogAction_Shutdown}},
+		{input: QemuWatchdog{}, err: errModel},
+		{input: QemuWatchdog{Model: "virtio"}, err: errModel},
+		{input: QemuWatchdog{Model: QemuWatchdogModel_Ib700, Action: "halt"}, err: errors.New("watchdog action must be one of (debug,none,pause,poweroff,reset,shutdown)")},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.err, test.input.Validate())
+	}
+}
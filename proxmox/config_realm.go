@@ -0,0 +1,456 @@
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	RealmType_AD     string = "ad"
+	RealmType_LDAP   string = "ldap"
+	RealmType_OpenID string = "openid"
+	RealmType_PAM    string = "pam"
+	RealmType_PVE    string = "pve"
+)
+
+var (
+	realmLdapModes = []string{"ldap", "ldaps", "ldap+starttls"}
+	// pam and pve always exist and can only be updated.
+	realmTypesBuiltin = []string{RealmType_PAM, RealmType_PVE}
+	realmSyncScopes   = []string{"users", "groups", "both"}
+	// Which parts of vanished users and groups are removed by a sync.
+	realmSyncRemoveVanished = []string{"acl", "entry", "properties"}
+)
+
+// Connection settings shared by LDAP and Active Directory realms.
+type ConfigRealmDirectory struct {
+	Server1 string `json:"server1"`
+	Server2 string `json:"server2,omitempty"`
+	Port    int    `json:"port,omitempty"`
+	// One of ldap, ldaps or ldap+starttls.
+	Mode   string `json:"mode,omitempty"`
+	Verify bool   `json:"verify,omitempty"`
+	BindDN string `json:"bind_dn,omitempty"`
+	// Password of the BindDN, always empty when getting information from Proxmox.
+	Password string `json:"-"`
+}
+
+func (directory ConfigRealmDirectory) mapToApiValues(params map[string]interface{}) {
+	setRealmValue(params, "server1", directory.Server1)
+	setRealmValue(params, "server2", directory.Server2)
+	setRealmValue(params, "mode", directory.Mode)
+	setRealmValue(params, "bind_dn", directory.BindDN)
+	setRealmValue(params, "password", directory.Password)
+	if directory.Port != 0 {
+		params["port"] = directory.Port
+	}
+	params["verify"] = directory.Verify
+}
+
+func (ConfigRealmDirectory) mapToStruct(params map[string]interface{}) ConfigRealmDirectory {
+	directory := ConfigRealmDirectory{}
+	if _, isSet := params["server1"]; isSet {
+		directory.Server1 = params["server1"].(string)
+	}
+	if _, isSet := params["server2"]; isSet {
+		directory.Server2 = params["server2"].(string)
+	}
+	if _, isSet := params["port"]; isSet {
+		directory.Port = int(params["port"].(float64))
+	}
+	if _, isSet := params["mode"]; isSet {
+		directory.Mode = params["mode"].(string)
+	}
+	if _, isSet := params["verify"]; isSet {
+		directory.Verify = Itob(int(params["verify"].(float64)))
+	}
+	if _, isSet := params["bind_dn"]; isSet {
+		directory.BindDN = params["bind_dn"].(string)
+	}
+	return directory
+}
+
+func (directory ConfigRealmDirectory) Validate() error {
+	if directory.Server1 == "" {
+		return errors.New("realm server1 is required")
+	}
+	if directory.Port != 0 {
+		if err := ValidateIntInRange(1, 65535, directory.Port, "port"); err != nil {
+			return err
+		}
+	}
+	if directory.Mode != "" && !inArray(realmLdapModes, directory.Mode) {
+		return errors.New("realm mode must be one of (" + strings.Join(realmLdapModes, ",") + ")")
+	}
+	return nil
+}
+
+type ConfigRealmLDAP struct {
+	ConfigRealmDirectory
+	BaseDN string `json:"base_dn"`
+	// Attribute holding the username, e.g. uid. Can not be changed after the realm is created.
+	UserAttribute string `json:"user_attr"`
+}
+
+func (ConfigRealmLDAP) mapToStruct(params map[string]interface{}) *ConfigRealmLDAP {
+	ldap := ConfigRealmLDAP{ConfigRealmDirectory: ConfigRealmDirectory{}.mapToStruct(params)}
+	if _, isSet := params["base_dn"]; isSet {
+		ldap.BaseDN = params["base_dn"].(string)
+	}
+	if _, isSet := params["user_attr"]; isSet {
+		ldap.UserAttribute = params["user_attr"].(string)
+	}
+	return &ldap
+}
+
+func (ldap ConfigRealmLDAP) Validate() error {
+	if ldap.BaseDN == "" {
+		return errors.New("ldap realm base_dn is required")
+	}
+	if ldap.UserAttribute == "" {
+		return errors.New("ldap realm user_attr is required")
+	}
+	return ldap.ConfigRealmDirectory.Validate()
+}
+
+type ConfigRealmAD struct {
+	ConfigRealmDirectory
+	// e.g. example.com
+	Domain string `json:"domain"`
+	BaseDN string `json:"base_dn,omitempty"`
+}
+
+func (ConfigRealmAD) mapToStruct(params map[string]interface{}) *ConfigRealmAD {
+	ad := ConfigRealmAD{ConfigRealmDirectory: ConfigRealmDirectory{}.mapToStruct(params)}
+	if _, isSet := params["domain"]; isSet {
+		ad.Domain = params["domain"].(string)
+	}
+	if _, isSet := params["base_dn"]; isSet {
+		ad.BaseDN = params["base_dn"].(string)
+	}
+	return &ad
+}
+
+func (ad ConfigRealmAD) Validate() error {
+	if ad.Domain == "" {
+		return errors.New("ad realm domain is required")
+	}
+	return ad.ConfigRealmDirectory.Validate()
+}
+
+type ConfigRealmOpenID struct {
+	IssuerURL string `json:"issuer-url"`
+	ClientID  string `json:"client-id"`
+	// Always empty when getting information from Proxmox.
+	ClientKey string `json:"-"`
+	// Create users that do not exist yet on their first login.
+	Autocreate bool `json:"autocreate,omitempty"`
+	// Claim used as the username, e.g. email. Can not be changed after the realm is created.
+	UsernameClaim string `json:"username-claim,omitempty"`
+	// Space separated, proxmox defaults to "email profile".
+	Scopes string `json:"scopes,omitempty"`
+}
+
+func (ConfigRealmOpenID) mapToStruct(params map[string]interface{}) *ConfigRealmOpenID {
+	openid := ConfigRealmOpenID{}
+	if _, isSet := params["issuer-url"]; isSet {
+		openid.IssuerURL = params["issuer-url"].(string)
+	}
+	if _, isSet := params["client-id"]; isSet {
+		openid.ClientID = params["client-id"].(string)
+	}
+	if _, isSet := params["autocreate"]; isSet {
+		openid.Autocreate = Itob(int(params["autocreate"].(float64)))
+	}
+	if _, isSet := params["username-claim"]; isSet {
+		openid.UsernameClaim = params["username-claim"].(string)
+	}
+	if _, isSet := params["scopes"]; isSet {
+		openid.Scopes = params["scopes"].(string)
+	}
+	return &openid
+}
+
+func (openid ConfigRealmOpenID) Validate() error {
+	if !strings.HasPrefix(openid.IssuerURL, "https://") && !strings.HasPrefix(openid.IssuerURL, "http://") {
+		return errors.New("openid realm issuer-url must be a http(s) url")
+	}
+	if openid.ClientID == "" {
+		return errors.New("openid realm client-id is required")
+	}
+	return nil
+}
+
+// Realm (authentication domain) options for the Proxmox API.
+// Only the settings matching the Type are used.
+type ConfigRealm struct {
+	Name    string             `json:"realm"`
+	Type    string             `json:"type"`
+	Comment string             `json:"comment,omitempty"`
+	Default bool               `json:"default,omitempty"`
+	LDAP    *ConfigRealmLDAP   `json:"ldap,omitempty"`
+	AD      *ConfigRealmAD     `json:"ad,omitempty"`
+	OpenID  *ConfigRealmOpenID `json:"openid,omitempty"`
+}
+
+// Empty values are left out, on update this keeps the current value.
+func setRealmValue(params map[string]interface{}, key, value string) {
+	if value != "" {
+		params[key] = value
+	}
+}
+
+// Maps the struct to the API values proxmox understands
+func (config ConfigRealm) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"default": config.Default,
+	}
+	if create {
+		params["realm"] = config.Name
+		params["type"] = config.Type
+	}
+	setRealmValue(params, "comment", config.Comment)
+	switch config.Type {
+	case RealmType_LDAP:
+		if config.LDAP != nil {
+			config.LDAP.ConfigRealmDirectory.mapToApiValues(params)
+			setRealmValue(params, "base_dn", config.LDAP.BaseDN)
+			if create {
+				params["user_attr"] = config.LDAP.UserAttribute
+			}
+		}
+	case RealmType_AD:
+		if config.AD != nil {
+			config.AD.ConfigRealmDirectory.mapToApiValues(params)
+			setRealmValue(params, "domain", config.AD.Domain)
+			setRealmValue(params, "base_dn", config.AD.BaseDN)
+		}
+	case RealmType_OpenID:
+		if config.OpenID != nil {
+			setRealmValue(params, "issuer-url", config.OpenID.IssuerURL)
+			setRealmValue(params, "client-id", config.OpenID.ClientID)
+			setRealmValue(params, "client-key", config.OpenID.ClientKey)
+			setRealmValue(params, "scopes", config.OpenID.Scopes)
+			params["autocreate"] = config.OpenID.Autocreate
+			if create {
+				setRealmValue(params, "username-claim", config.OpenID.UsernameClaim)
+			}
+		}
+	}
+	return params
+}
+
+// Maps the API values from proxmox to a struct
+func (config ConfigRealm) mapToStruct(params map[string]interface{}) *ConfigRealm {
+	if _, isSet := params["realm"]; isSet {
+		config.Name = params["realm"].(string)
+	}
+	if _, isSet := params["type"]; isSet {
+		config.Type = params["type"].(string)
+	}
+	if _, isSet := params["comment"]; isSet {
+		config.Comment = params["comment"].(string)
+	}
+	if _, isSet := params["default"]; isSet {
+		config.Default = Itob(int(params["default"].(float64)))
+	}
+	switch config.Type {
+	case RealmType_LDAP:
+		config.LDAP = ConfigRealmLDAP{}.mapToStruct(params)
+	case RealmType_AD:
+		config.AD = ConfigRealmAD{}.mapToStruct(params)
+	case RealmType_OpenID:
+		config.OpenID = ConfigRealmOpenID{}.mapToStruct(params)
+	}
+	return &config
+}
+
+// Validates the realm and the settings matching its type
+func (config ConfigRealm) Validate() error {
+	if err := ValidateRealmName(config.Name); err != nil {
+		return err
+	}
+	switch config.Type {
+	case RealmType_PAM, RealmType_PVE:
+		return nil
+	case RealmType_LDAP:
+		if config.LDAP == nil {
+			return errors.New("ldap realm requires the ldap settings")
+		}
+		return config.LDAP.Validate()
+	case RealmType_AD:
+		if config.AD == nil {
+			return errors.New("ad realm requires the ad settings")
+		}
+		return config.AD.Validate()
+	case RealmType_OpenID:
+		if config.OpenID == nil {
+			return errors.New("openid realm requires the openid settings")
+		}
+		return config.OpenID.Validate()
+	}
+	return errors.New("realm type must be one of (ad,ldap,openid,pam,pve)")
+}
+
+// Creates the realm, pam and pve realms always exist and can not be created.
+func (config ConfigRealm) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if inArray(realmTypesBuiltin, config.Type) {
+		return fmt.Errorf("realms of type %s can not be created", config.Type)
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if err := client.Post(ctx, config.mapToApiValues(true), "/access/domains"); err != nil {
+		return fmt.Errorf("error creating Realm: %v, (params: %v)", err, config.paramsWithoutSecrets(true))
+	}
+	return nil
+}
+
+// Updates the realm, the type can not be changed.
+func (config ConfigRealm) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if err := client.Put(ctx, config.mapToApiValues(false), "/access/domains/"+config.Name); err != nil {
+		return fmt.Errorf("error updating Realm: %v, (params: %v)", err, config.paramsWithoutSecrets(false))
+	}
+	return nil
+}
+
+// The params as json for error messages, without the bind password and client key.
+func (config ConfigRealm) paramsWithoutSecrets(create bool) string {
+	params := config.mapToApiValues(create)
+	delete(params, "password")
+	delete(params, "client-key")
+	paramsJson, _ := json.Marshal(&params)
+	return string(paramsJson)
+}
+
+// Realm names must start with a letter and may only contain letters, digits, '.', '-' and '_'
+func ValidateRealmName(realm string) error {
+	if !rxRealmName.MatchString(realm) {
+		return fmt.Errorf("invalid realm name (%s), must start with a letter and may only contain letters, digits, '.', '-' and '_'", realm)
+	}
+	return nil
+}
+
+// Returns a list of all realms, without their type specific settings
+func ListRealms(ctx context.Context, client *Client) (*[]ConfigRealm, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	paramArray, err := client.GetItemListInterfaceArray(ctx, "/access/domains")
+	if err != nil {
+		return nil, err
+	}
+	realms := make([]ConfigRealm, len(paramArray))
+	for i, e := range paramArray {
+		realms[i] = ConfigRealm{}.listItemToStruct(e.(map[string]interface{}))
+	}
+	return &realms, nil
+}
+
+// The realm list does not include the type specific settings.
+func (ConfigRealm) listItemToStruct(params map[string]interface{}) ConfigRealm {
+	config := ConfigRealm{}.mapToStruct(params)
+	config.LDAP = nil
+	config.AD = nil
+	config.OpenID = nil
+	return *config
+}
+
+func NewConfigRealmFromApi(ctx context.Context, realm string, client *Client) (*ConfigRealm, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	config, err := client.GetItemConfigMapStringInterface(ctx, "/access/domains/"+realm, "realm", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return ConfigRealm{Name: realm}.mapToStruct(config), nil
+}
+
+// Deletes the realm, the users of the realm are kept.
+func DeleteRealm(ctx context.Context, realm string, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ValidateRealmName(realm); err != nil {
+		return err
+	}
+	return client.Delete(ctx, "/access/domains/"+realm)
+}
+
+// Options for SyncRealm, empty values use the defaults configured on the realm.
+type RealmSyncOptions struct {
+	// One of users, groups or both.
+	Scope string
+	// Any of acl, entry and properties.
+	RemoveVanished []string
+	EnableNew      *bool
+	// Only report what would change.
+	DryRun bool
+}
+
+func (opts RealmSyncOptions) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{
+		"scope": opts.Scope,
+	}
+	if len(opts.RemoveVanished) > 0 {
+		params["remove-vanished"] = strings.Join(opts.RemoveVanished, ";")
+	}
+	if opts.EnableNew != nil {
+		params["enable-new"] = *opts.EnableNew
+	}
+	if opts.DryRun {
+		params["dry-run"] = true
+	}
+	return params
+}
+
+func (opts RealmSyncOptions) Validate() error {
+	if opts.Scope != "" && !inArray(realmSyncScopes, opts.Scope) {
+		return errors.New("realm sync scope must be one of (" + strings.Join(realmSyncScopes, ",") + ")")
+	}
+	for _, e := range opts.RemoveVanished {
+		if !inArray(realmSyncRemoveVanished, e) {
+			return errors.New("realm sync remove-vanished must only contain (" + strings.Join(realmSyncRemoveVanished, ",") + ")")
+		}
+	}
+	return nil
+}
+
+// SyncRealm syncs the users and groups of an LDAP or AD realm into proxmox.
+// Returns the UPID of the sync task.
+func (c *Client) SyncRealm(ctx context.Context, realm string, opts RealmSyncOptions) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = ValidateRealmName(realm); err != nil {
+		return
+	}
+	if err = opts.Validate(); err != nil {
+		return
+	}
+	reqbody := ParamsToBody(opts.mapToApiValues())
+	resp, err := c.session.Post(ctx, "/access/domains/"+realm+"/sync", nil, nil, &reqbody)
+	if err != nil {
+		return "", fmt.Errorf("error syncing realm: %v, error status: %s", err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
@@ -0,0 +1,110 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigRealm_mapToApiValues(t *testing.T) {
+	ldap := ConfigRealm{
+		Name: "corp",
+		Type: RealmType_LDAP,
+		LDAP: &ConfigRealmLDAP{
+			ConfigRealmDirectory: ConfigRealmDirectory{Server1: "ldap1.example.com", Port: 636, Mode: "ldaps", Verify: true, BindDN: "cn=pve,dc=example,dc=com", Password: "secret"},
+			BaseDN:               "dc=example,dc=com",
+			UserAttribute:        "uid",
+		},
+	}
+	require.Equal(t, map[string]interface{}{
+		"realm":     "corp",
+		"type":      "ldap",
+		"default":   false,
+		"server1":   "ldap1.example.com",
+		"port":      636,
+		"mode":      "ldaps",
+		"verify":    true,
+		"bind_dn":   "cn=pve,dc=example,dc=com",
+		"password":  "secret",
+		"base_dn":   "dc=example,dc=com",
+		"user_attr": "uid",
+	}, ldap.mapToApiValues(true))
+	openid := ConfigRealm{
+		Name:    "sso",
+		Type:    RealmType_OpenID,
+		Comment: "single sign on",
+		OpenID:  &ConfigRealmOpenID{IssuerURL: "https://sso.example.com", ClientID: "pve", ClientKey: "key", Autocreate: true, UsernameClaim: "email"},
+	}
+	require.Equal(t, map[string]interface{}{
+		"default":    false,
+		"comment":    "single sign on",
+		"issuer-url": "https://sso.example.com",
+		"client-id":  "pve",
+		"client-key": "key",
+		"autocreate": true,
+	}, openid.mapToApiValues(false))
+}
+
+func Test_ConfigRealm_mapToStruct(t *testing.T) {
+	require.Equal(t, &ConfigRealm{
+		Name:    "ad",
+		Type:    RealmType_AD,
+		Default: true,
+		AD: &ConfigRealmAD{
+			ConfigRealmDirectory: ConfigRealmDirectory{Server1: "dc1.example.com", Server2: "dc2.example.com", Mode: "ldap+starttls"},
+			Domain:               "example.com",
+		},
+	}, ConfigRealm{Name: "ad"}.mapToStruct(map[string]interface{}{
+		"type":    "ad",
+		"default": float64(1),
+		"server1": "dc1.example.com",
+		"server2": "dc2.example.com",
+		"mode":    "ldap+starttls",
+		"domain":  "example.com",
+		"digest":  "abc",
+	}))
+	require.Equal(t, ConfigRealm{Name: "corp", Type: RealmType_LDAP, Comment: "corp"},
+		ConfigRealm{}.listItemToStruct(map[string]interface{}{"realm": "corp", "type": "ldap", "comment": "corp"}))
+}
+
+func Test_ConfigRealm_Validate(t *testing.T) {
+	directory := ConfigRealmDirectory{Server1: "ldap.example.com"}
+	testData := []struct {
+		name  string
+		input ConfigRealm
+		err   bool
+	}{
+		{name: "pam", input: ConfigRealm{Name: "pam", Type: RealmType_PAM}},
+		{name: "ldap", input: ConfigRealm{Name: "corp", Type: RealmType_LDAP, LDAP: &ConfigRealmLDAP{ConfigRealmDirectory: directory, BaseDN: "dc=example", UserAttribute: "uid"}}},
+		{name: "ad", input: ConfigRealm{Name: "ad", Type: RealmType_AD, AD: &ConfigRealmAD{ConfigRealmDirectory: directory, Domain: "example.com"}}},
+		{name: "openid", input: ConfigRealm{Name: "sso", Type: RealmType_OpenID, OpenID: &ConfigRealmOpenID{IssuerURL: "https://sso.example.com", ClientID: "pve"}}},
+		{name: "invalid name", input: ConfigRealm{Name: "1corp", Type: RealmType_PVE}, err: true},
+		{name: "invalid type", input: ConfigRealm{Name: "corp", Type: "kerberos"}, err: true},
+		{name: "ldap without settings", input: ConfigRealm{Name: "corp", Type: RealmType_LDAP}, err: true},
+		{name: "ldap without server", input: ConfigRealm{Name: "corp", Type: RealmType_LDAP, LDAP: &ConfigRealmLDAP{BaseDN: "dc=example", UserAttribute: "uid"}}, err: true},
+		{name: "ldap without base_dn", input: ConfigRealm{Name: "corp", Type: RealmType_LDAP, LDAP: &ConfigRealmLDAP{ConfigRealmDirectory: directory, UserAttribute: "uid"}}, err: true},
+		{name: "ldap without user_attr", input: ConfigRealm{Name: "corp", Type: RealmType_LDAP, LDAP: &ConfigRealmLDAP{ConfigRealmDirectory: directory, BaseDN: "dc=example"}}, err: true},
+		{name: "ldap invalid mode", input: ConfigRealm{Name: "corp", Type: RealmType_LDAP, LDAP: &ConfigRealmLDAP{ConfigRealmDirectory: ConfigRealmDirectory{Server1: "ldap", Mode: "tls"}, BaseDN: "dc=example", UserAttribute: "uid"}}, err: true},
+		{name: "ldap invalid port", input: ConfigRealm{Name: "corp", Type: RealmType_LDAP, LDAP: &ConfigRealmLDAP{ConfigRealmDirectory: ConfigRealmDirectory{Server1: "ldap", Port: 70000}, BaseDN: "dc=example", UserAttribute: "uid"}}, err: true},
+		{name: "ad without domain", input: ConfigRealm{Name: "ad", Type: RealmType_AD, AD: &ConfigRealmAD{ConfigRealmDirectory: directory}}, err: true},
+		{name: "openid without issuer", input: ConfigRealm{Name: "sso", Type: RealmType_OpenID, OpenID: &ConfigRealmOpenID{ClientID: "pve"}}, err: true},
+		{name: "openid without client", input: ConfigRealm{Name: "sso", Type: RealmType_OpenID, OpenID: &ConfigRealmOpenID{IssuerURL: "https://sso.example.com"}}, err: true},
+	}
+	for _, e := range testData {
+		t.Run(e.name, func(*testing.T) {
+			if e.err {
+				require.Error(t, e.input.Validate())
+			} else {
+				require.NoError(t, e.input.Validate())
+			}
+		})
+	}
+}
+
+func Test_RealmSyncOptions(t *testing.T) {
+	opts := RealmSyncOptions{Scope: "both", RemoveVanished: []string{"acl", "entry"}, EnableNew: PointerBool(false), DryRun: true}
+	require.NoError(t, opts.Validate())
+	require.Equal(t, map[string]interface{}{"scope": "both", "remove-vanished": "acl;entry", "enable-new": false, "dry-run": true}, opts.mapToApiValues())
+	require.Error(t, RealmSyncOptions{Scope: "all"}.Validate())
+	require.Error(t, RealmSyncOptions{RemoveVanished: []string{"users"}}.Validate())
+}
@@ -0,0 +1,220 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Replication job IDs are <vmid>-<job number>, e.g. 100-0.
+var rxReplicationJobID = regexp.MustCompile(`^([1-9]\d{2,8})-(\d{1,9})$`)
+
+// Storage replication job of a guest (/cluster/replication), only supported for guests on ZFS.
+type ReplicationJob struct {
+	ID     string `json:"id"`
+	Target string `json:"target"`
+	// Calendar event, proxmox defaults to "*/15" (every 15 minutes) when empty.
+	Schedule string `json:"schedule,omitempty"`
+	// Bandwidth limit in MB/s, 0 means unlimited.
+	Rate    float64 `json:"rate,omitempty"`
+	Comment string  `json:"comment,omitempty"`
+	Disable bool    `json:"disable,omitempty"`
+}
+
+// VmID returns the guest the job replicates, taken from the job ID.
+func (job ReplicationJob) VmID() uint {
+	matches := rxReplicationJobID.FindStringSubmatch(job.ID)
+	if matches == nil {
+		return 0
+	}
+	vmid, _ := strconv.ParseUint(matches[1], 10, 0)
+	return uint(vmid)
+}
+
+func (job ReplicationJob) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"disable": boolToIntString(job.Disable),
+	}
+	deletions := ""
+	if create {
+		params["id"] = job.ID
+		params["type"] = "local"
+		params["target"] = job.Target
+	}
+	if job.Schedule != "" {
+		params["schedule"] = job.Schedule
+	} else if !create {
+		deletions = AddToList(deletions, "schedule")
+	}
+	if job.Rate != 0 {
+		params["rate"] = job.Rate
+	} else if !create {
+		deletions = AddToList(deletions, "rate")
+	}
+	if job.Comment != "" {
+		params["comment"] = job.Comment
+	} else if !create {
+		deletions = AddToList(deletions, "comment")
+	}
+	if deletions != "" {
+		params["delete"] = deletions
+	}
+	return params
+}
+
+func (ReplicationJob) mapToStruct(params map[string]interface{}) *ReplicationJob {
+	job := ReplicationJob{}
+	if _, isSet := params["id"]; isSet {
+		job.ID = params["id"].(string)
+	}
+	if _, isSet := params["target"]; isSet {
+		job.Target = params["target"].(string)
+	}
+	if _, isSet := params["schedule"]; isSet {
+		job.Schedule = params["schedule"].(string)
+	}
+	if _, isSet := params["rate"]; isSet {
+		job.Rate = params["rate"].(float64)
+	}
+	if _, isSet := params["comment"]; isSet {
+		job.Comment = params["comment"].(string)
+	}
+	if _, isSet := params["disable"]; isSet {
+		job.Disable = Itob(int(params["disable"].(float64)))
+	}
+	return &job
+}
+
+func (job ReplicationJob) Validate() error {
+	if !rxReplicationJobID.MatchString(job.ID) {
+		return fmt.Errorf("replication job id (%s) must be in the format <vmid>-<number>", job.ID)
+	}
+	if job.Target == "" {
+		return errors.New("replication job target node may not be empty")
+	}
+	if job.Rate < 0 {
+		return errors.New("replication job rate may not be negative")
+	}
+	return nil
+}
+
+func (job ReplicationJob) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	return client.Post(ctx, job.mapToApiValues(true), "/cluster/replication")
+}
+
+// Update changes the job, the target node can not be changed.
+func (job ReplicationJob) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	return client.Put(ctx, job.mapToApiValues(false), "/cluster/replication/"+job.ID)
+}
+
+// ListReplicationJobs returns all replication jobs of the cluster.
+func (c *Client) ListReplicationJobs(ctx context.Context) ([]ReplicationJob, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/cluster/replication")
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]ReplicationJob, len(list))
+	for i, e := range list {
+		jobs[i] = *ReplicationJob{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return jobs, nil
+}
+
+func (c *Client) GetReplicationJob(ctx context.Context, id string) (*ReplicationJob, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/cluster/replication/"+id, "replication job", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return ReplicationJob{}.mapToStruct(params), nil
+}
+
+// DeleteReplicationJob marks the job for removal, proxmox also removes the replicated volumes on the target.
+func (c *Client) DeleteReplicationJob(ctx context.Context, id string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.Delete(ctx, "/cluster/replication/"+id)
+}
+
+// Status of a replication job as seen by the source node.
+type ReplicationJobStatus struct {
+	ID        string        `json:"id"`
+	Target    string        `json:"target"`
+	LastSync  time.Time     `json:"last_sync"`
+	LastTry   time.Time     `json:"last_try"`
+	NextSync  time.Time     `json:"next_sync"`
+	Duration  time.Duration `json:"duration"`
+	FailCount uint          `json:"fail_count"`
+	// Error of the last run, empty when it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+func (ReplicationJobStatus) mapToStruct(params map[string]interface{}) *ReplicationJobStatus {
+	status := ReplicationJobStatus{}
+	if _, isSet := params["id"]; isSet {
+		status.ID = params["id"].(string)
+	}
+	if _, isSet := params["target"]; isSet {
+		status.Target = params["target"].(string)
+	}
+	if _, isSet := params["last_sync"]; isSet {
+		status.LastSync = time.Unix(int64(params["last_sync"].(float64)), 0)
+	}
+	if _, isSet := params["last_try"]; isSet {
+		status.LastTry = time.Unix(int64(params["last_try"].(float64)), 0)
+	}
+	if _, isSet := params["next_sync"]; isSet {
+		status.NextSync = time.Unix(int64(params["next_sync"].(float64)), 0)
+	}
+	if _, isSet := params["duration"]; isSet {
+		status.Duration = time.Duration(params["duration"].(float64) * float64(time.Second))
+	}
+	if _, isSet := params["fail_count"]; isSet {
+		status.FailCount = uint(params["fail_count"].(float64))
+	}
+	if _, isSet := params["error"]; isSet {
+		status.Error = params["error"].(string)
+	}
+	return &status
+}
+
+// GetReplicationJobStatus returns the status of the job, node must be the node the guest is on.
+func (c *Client) GetReplicationJobStatus(ctx context.Context, node, id string) (*ReplicationJobStatus, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/nodes/"+node+"/replication/"+id+"/status", "replication job", "STATUS")
+	if err != nil {
+		return nil, err
+	}
+	return ReplicationJobStatus{}.mapToStruct(params), nil
+}
+
+// RunReplicationJob schedules the job to run as soon as possible, node must be the node the guest is on.
+func (c *Client) RunReplicationJob(ctx context.Context, node, id string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.Post(ctx, map[string]interface{}{}, "/nodes/"+node+"/replication/"+id+"/schedule_now")
+}
@@ -0,0 +1,76 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReplicationJob_mapToApiValues(t *testing.T) {
+	job := ReplicationJob{ID: "100-0", Target: "pve2", Schedule: "*/30", Rate: 10.5}
+	require.Equal(t, map[string]interface{}{
+		"id":       "100-0",
+		"type":     "local",
+		"target":   "pve2",
+		"schedule": "*/30",
+		"rate":     10.5,
+		"disable":  "0",
+	}, job.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{
+		"disable": "1",
+		"delete":  "schedule,rate,comment",
+	}, ReplicationJob{ID: "100-0", Target: "pve2", Disable: true}.mapToApiValues(false))
+}
+
+func Test_ReplicationJob_mapToStruct(t *testing.T) {
+	require.Equal(t, &ReplicationJob{ID: "100-1", Target: "pve2", Schedule: "*/15", Rate: 5, Comment: "db", Disable: true},
+		ReplicationJob{}.mapToStruct(map[string]interface{}{
+			"id":       "100-1",
+			"type":     "local",
+			"guest":    float64(100),
+			"jobnum":   float64(1),
+			"target":   "pve2",
+			"schedule": "*/15",
+			"rate":     float64(5),
+			"comment":  "db",
+			"disable":  float64(1),
+		}))
+}
+
+func Test_ReplicationJob_Validate(t *testing.T) {
+	require.NoError(t, ReplicationJob{ID: "100-0", Target: "pve2"}.Validate())
+	require.NoError(t, ReplicationJob{ID: "999999999-12", Target: "pve2", Rate: 1}.Validate())
+	for _, id := range []string{"", "100", "99-0", "100-", "100_0", "vm100-0"} {
+		require.Error(t, ReplicationJob{ID: id, Target: "pve2"}.Validate(), id)
+	}
+	require.Error(t, ReplicationJob{ID: "100-0"}.Validate())
+	require.Error(t, ReplicationJob{ID: "100-0", Target: "pve2", Rate: -1}.Validate())
+}
+
+func Test_ReplicationJob_VmID(t *testing.T) {
+	require.Equal(t, uint(123), ReplicationJob{ID: "123-4"}.VmID())
+	require.Equal(t, uint(0), ReplicationJob{ID: "invalid"}.VmID())
+}
+
+func Test_ReplicationJobStatus_mapToStruct(t *testing.T) {
+	require.Equal(t, &ReplicationJobStatus{
+		ID:        "100-0",
+		Target:    "pve2",
+		LastSync:  time.Unix(1680000000, 0),
+		LastTry:   time.Unix(1680000900, 0),
+		NextSync:  time.Unix(1680001800, 0),
+		Duration:  1500 * time.Millisecond,
+		FailCount: 1,
+		Error:     "command 'zfs send' failed",
+	}, ReplicationJobStatus{}.mapToStruct(map[string]interface{}{
+		"id":         "100-0",
+		"target":     "pve2",
+		"last_sync":  float64(1680000000),
+		"last_try":   float64(1680000900),
+		"next_sync":  float64(1680001800),
+		"duration":   float64(1.5),
+		"fail_count": float64(1),
+		"error":      "command 'zfs send' failed",
+	}))
+}
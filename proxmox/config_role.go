@@ -0,0 +1,216 @@
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Role that every privilege is part of.
+const roleAdministrator RoleName = "Administrator"
+
+var (
+	rxRoleName      = regexp.MustCompile(`^[A-Za-z0-9.\-_]+$`)
+	rxPrivilegeName = regexp.MustCompile(`^[A-Za-z]+(\.[A-Za-z]+)*$`)
+)
+
+// Role options for the Proxmox API
+type ConfigRole struct {
+	Name RoleName `json:"name"`
+	// e.g. "VM.PowerMgmt", the order is not preserved.
+	Privileges []string `json:"privileges"`
+	// Built-in roles can not be changed or deleted, always false when creating or updating a role.
+	Special bool `json:"special,omitempty"`
+}
+
+// Creates the specified role
+func (config ConfigRole) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	params := config.mapToApiValues(true)
+	if err := client.Post(ctx, params, "/access/roles"); err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error creating Role: %v, (params: %v)", err, string(params))
+	}
+	return nil
+}
+
+// Maps the struct to the API values proxmox understands
+func (config ConfigRole) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"privs": strings.Join(config.Privileges, ","),
+	}
+	if create {
+		params["roleid"] = string(config.Name)
+	}
+	return params
+}
+
+// Maps the API values from proxmox to a struct, accepts both the list and the single role format.
+func (config ConfigRole) mapToStruct(params map[string]interface{}) *ConfigRole {
+	config.Privileges = []string{}
+	if _, isSet := params["roleid"]; isSet {
+		config.Name = RoleName(params["roleid"].(string))
+	}
+	if _, isSet := params["special"]; isSet {
+		config.Special = Itob(int(params["special"].(float64)))
+	}
+	if privileges, isSet := params["privs"]; isSet {
+		// GET /access/roles returns a comma separated list
+		if privileges != "" {
+			config.Privileges = strings.Split(privileges.(string), ",")
+		}
+	} else {
+		// GET /access/roles/{roleid} returns every privilege as a key
+		for k := range params {
+			if k != "roleid" && k != "special" {
+				config.Privileges = append(config.Privileges, k)
+			}
+		}
+	}
+	sort.Strings(config.Privileges)
+	return &config
+}
+
+// Replaces all privileges of the specified role
+func (config ConfigRole) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	params := config.mapToApiValues(false)
+	if err := client.Put(ctx, params, "/access/roles/"+string(config.Name)); err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error updating Role: %v, (params: %v)", err, string(params))
+	}
+	return nil
+}
+
+// Validates all items of the ConfigRole
+func (config ConfigRole) Validate() error {
+	if err := config.Name.Validate(); err != nil {
+		return err
+	}
+	return validatePrivileges(config.Privileges)
+}
+
+func validatePrivileges(privileges []string) error {
+	for _, e := range privileges {
+		if !rxPrivilegeName.MatchString(e) {
+			return fmt.Errorf("invalid privilege (%s), privileges are in the format Group.Privilege", e)
+		}
+	}
+	return nil
+}
+
+// RoleName may only contain letters, digits, '.', '-' and '_'
+type RoleName string
+
+// Adds the privileges to the role, the privileges the role already has are kept.
+func (role RoleName) AddPrivileges(ctx context.Context, privileges []string, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := role.Validate(); err != nil {
+		return err
+	}
+	if err := validatePrivileges(privileges); err != nil {
+		return err
+	}
+	return client.Put(ctx, map[string]interface{}{
+		"privs":  strings.Join(privileges, ","),
+		"append": true,
+	}, "/access/roles/"+string(role))
+}
+
+// Deletes the specified role
+func (role RoleName) Delete(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := role.Validate(); err != nil {
+		return err
+	}
+	return client.Delete(ctx, "/access/roles/"+string(role))
+}
+
+// Removes the privileges from the role, the other privileges of the role are kept.
+func (role RoleName) RemovePrivileges(ctx context.Context, privileges []string, client *Client) error {
+	config, err := NewConfigRoleFromApi(ctx, role, client)
+	if err != nil {
+		return err
+	}
+	config.Privileges = removePrivileges(config.Privileges, privileges)
+	return config.Update(ctx, client)
+}
+
+func removePrivileges(current, remove []string) []string {
+	privileges := []string{}
+	for _, e := range current {
+		if !inArray(remove, e) {
+			privileges = append(privileges, e)
+		}
+	}
+	return privileges
+}
+
+// Check if a role name is valid.
+func (role RoleName) Validate() error {
+	if role == "" {
+		return errors.New("variable of type (RoleName) may not be empty")
+	}
+	if !rxRoleName.MatchString(string(role)) {
+		return fmt.Errorf("invalid role name (%s), may only contain letters, digits, '.', '-' and '_'", role)
+	}
+	return nil
+}
+
+// Returns a list of all existing roles, including the built-in ones
+func ListRoles(ctx context.Context, client *Client) (*[]ConfigRole, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	paramArray, err := client.GetItemListInterfaceArray(ctx, "/access/roles")
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]ConfigRole, len(paramArray))
+	for i, e := range paramArray {
+		roles[i] = *ConfigRole{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return &roles, nil
+}
+
+// Returns all privileges that can be assigned to a role.
+// Proxmox has no endpoint for this, the built-in Administrator role has every privilege.
+func ListPrivileges(ctx context.Context, client *Client) ([]string, error) {
+	config, err := NewConfigRoleFromApi(ctx, roleAdministrator, client)
+	if err != nil {
+		return nil, err
+	}
+	return config.Privileges, nil
+}
+
+func NewConfigRoleFromApi(ctx context.Context, role RoleName, client *Client) (*ConfigRole, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := role.Validate(); err != nil {
+		return nil, err
+	}
+	config, err := client.GetItemConfigMapStringInterface(ctx, "/access/roles/"+string(role), "role", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return ConfigRole{Name: role}.mapToStruct(config), nil
+}
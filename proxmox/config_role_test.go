@@ -0,0 +1,57 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigRole_mapToApiValues(t *testing.T) {
+	config := ConfigRole{Name: "Operator", Privileges: []string{"VM.Audit", "VM.PowerMgmt"}}
+	require.Equal(t, map[string]interface{}{"roleid": "Operator", "privs": "VM.Audit,VM.PowerMgmt"}, config.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{"privs": "VM.Audit,VM.PowerMgmt"}, config.mapToApiValues(false))
+	require.Equal(t, map[string]interface{}{"privs": ""}, ConfigRole{Name: "Empty"}.mapToApiValues(false))
+}
+
+func Test_ConfigRole_mapToStruct(t *testing.T) {
+	testData := []struct {
+		name   string
+		input  map[string]interface{}
+		output *ConfigRole
+	}{
+		{
+			name:   "list",
+			input:  map[string]interface{}{"roleid": "PVEAuditor", "privs": "VM.Audit,Sys.Audit", "special": float64(1)},
+			output: &ConfigRole{Name: "PVEAuditor", Privileges: []string{"Sys.Audit", "VM.Audit"}, Special: true},
+		},
+		{
+			name:   "list no privileges",
+			input:  map[string]interface{}{"roleid": "NoAccess", "privs": "", "special": float64(1)},
+			output: &ConfigRole{Name: "NoAccess", Privileges: []string{}, Special: true},
+		},
+		{
+			name:   "single",
+			input:  map[string]interface{}{"VM.PowerMgmt": float64(1), "VM.Audit": float64(1)},
+			output: &ConfigRole{Name: "Operator", Privileges: []string{"VM.Audit", "VM.PowerMgmt"}},
+		},
+	}
+	for _, e := range testData {
+		t.Run(e.name, func(*testing.T) {
+			require.Equal(t, e.output, ConfigRole{Name: "Operator"}.mapToStruct(e.input))
+		})
+	}
+}
+
+func Test_ConfigRole_Validate(t *testing.T) {
+	require.NoError(t, ConfigRole{Name: "Operator_1.a-b"}.Validate())
+	require.NoError(t, ConfigRole{Name: "Operator", Privileges: []string{"VM.Config.Disk", "Pool.Allocate"}}.Validate())
+	require.Error(t, ConfigRole{}.Validate())
+	require.Error(t, ConfigRole{Name: "Oper ator"}.Validate())
+	require.Error(t, ConfigRole{Name: "Operator", Privileges: []string{"VM.Audit,VM.PowerMgmt"}}.Validate())
+	require.Error(t, ConfigRole{Name: "Operator", Privileges: []string{""}}.Validate())
+}
+
+func Test_removePrivileges(t *testing.T) {
+	require.Equal(t, []string{"VM.Audit"}, removePrivileges([]string{"VM.Audit", "VM.PowerMgmt", "VM.Console"}, []string{"VM.Console", "VM.PowerMgmt", "Sys.Audit"}))
+	require.Equal(t, []string{}, removePrivileges([]string{"VM.Audit"}, []string{"VM.Audit"}))
+}
@@ -0,0 +1,32 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// Zones and vnets are identified by 2 to 8 lowercase letters and digits, starting with a letter.
+var rxSdnID = regexp.MustCompile(`^[a-z][a-z0-9]{1,7}$`)
+
+const sdnUrl = "/cluster/sdn"
+
+func validateSdnID(kind, id string) error {
+	if id == "" {
+		return errors.New("sdn " + kind + " may not be empty")
+	}
+	if !rxSdnID.MatchString(id) {
+		return errors.New("sdn " + kind + " (" + id + ") must be 2 to 8 lowercase letters and digits, starting with a letter")
+	}
+	return nil
+}
+
+// ApplySDN commits the pending changes of the zones, vnets and subnets to all nodes.
+// Changes made through the API are staged until they are applied.
+func (c *Client) ApplySDN(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, err := c.PutWithTask(ctx, map[string]interface{}{}, sdnUrl)
+	return err
+}
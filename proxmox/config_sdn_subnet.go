@@ -0,0 +1,253 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// Range of addresses the dhcp server of the zone hands out.
+type SdnDhcpRange struct {
+	Start string `json:"start-address"`
+	End   string `json:"end-address"`
+}
+
+func (r SdnDhcpRange) String() string {
+	return "start-address=" + r.Start + ",end-address=" + r.End
+}
+
+func (SdnDhcpRange) mapToStruct(params interface{}) SdnDhcpRange {
+	r := SdnDhcpRange{}
+	switch params := params.(type) {
+	case string:
+		for _, e := range strings.Split(params, ",") {
+			key, value, _ := strings.Cut(e, "=")
+			switch key {
+			case "start-address":
+				r.Start = value
+			case "end-address":
+				r.End = value
+			}
+		}
+	case map[string]interface{}:
+		if _, isSet := params["start-address"]; isSet {
+			r.Start = params["start-address"].(string)
+		}
+		if _, isSet := params["end-address"]; isSet {
+			r.End = params["end-address"].(string)
+		}
+	}
+	return r
+}
+
+// Checks that both addresses are in the network and that start does not come after end.
+func (r SdnDhcpRange) Validate(network *net.IPNet) error {
+	start := net.ParseIP(r.Start)
+	end := net.ParseIP(r.End)
+	if start == nil || end == nil {
+		return errors.New("dhcp range (" + r.String() + ") must have a valid start and end address")
+	}
+	if !network.Contains(start) || !network.Contains(end) {
+		return errors.New("dhcp range (" + r.String() + ") must be inside subnet " + network.String())
+	}
+	if compareIP(start, end) > 0 {
+		return errors.New("dhcp range (" + r.String() + ") start address must not be after the end address")
+	}
+	return nil
+}
+
+// Subnet of an SDN vnet.
+type ConfigSdnSubnet struct {
+	Vnet SdnVnetName `json:"vnet"`
+	// Network address with prefix length, e.g. 10.0.0.0/24.
+	CIDR    string `json:"cidr"`
+	Gateway string `json:"gateway,omitempty"`
+	// Masquerade traffic leaving the subnet behind the address of the node.
+	Snat          bool           `json:"snat"`
+	DnsZonePrefix string         `json:"dnszoneprefix,omitempty"`
+	DhcpRanges    []SdnDhcpRange `json:"dhcp-range,omitempty"`
+	DhcpDnsServer string         `json:"dhcp-dns-server,omitempty"`
+}
+
+func (config ConfigSdnSubnet) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return client.Post(ctx, config.mapToApiValues(true), sdnVnetUrl(config.Vnet)+"/subnets")
+}
+
+// Updates the subnet with the same CIDR, empty optional settings are removed.
+func (config ConfigSdnSubnet) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	url, err := sdnSubnetUrl(ctx, config.Vnet, config.CIDR, client)
+	if err != nil {
+		return err
+	}
+	return client.Put(ctx, config.mapToApiValues(false), url)
+}
+
+func (config ConfigSdnSubnet) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"snat": config.Snat,
+	}
+	if create {
+		params["subnet"] = config.CIDR
+		params["type"] = "subnet"
+	}
+	var deletions string
+	optionals := []struct {
+		key   string
+		value string
+	}{
+		{"dhcp-dns-server", config.DhcpDnsServer},
+		{"dnszoneprefix", config.DnsZonePrefix},
+		{"gateway", config.Gateway},
+	}
+	for _, e := range optionals {
+		if e.value != "" {
+			params[e.key] = e.value
+		} else if !create {
+			deletions = AddToList(deletions, e.key)
+		}
+	}
+	if len(config.DhcpRanges) > 0 {
+		ranges := make([]string, len(config.DhcpRanges))
+		for i, e := range config.DhcpRanges {
+			ranges[i] = e.String()
+		}
+		params["dhcp-range"] = ranges
+	} else if !create {
+		deletions = AddToList(deletions, "dhcp-range")
+	}
+	if deletions != "" {
+		params["delete"] = deletions
+	}
+	return params
+}
+
+func (ConfigSdnSubnet) mapToStruct(params map[string]interface{}) *ConfigSdnSubnet {
+	config := ConfigSdnSubnet{}
+	if _, isSet := params["vnet"]; isSet {
+		config.Vnet = SdnVnetName(params["vnet"].(string))
+	}
+	if _, isSet := params["cidr"]; isSet {
+		config.CIDR = params["cidr"].(string)
+	}
+	if _, isSet := params["gateway"]; isSet {
+		config.Gateway = params["gateway"].(string)
+	}
+	if _, isSet := params["snat"]; isSet {
+		config.Snat = Itob(int(params["snat"].(float64)))
+	}
+	if _, isSet := params["dnszoneprefix"]; isSet {
+		config.DnsZonePrefix = params["dnszoneprefix"].(string)
+	}
+	if _, isSet := params["dhcp-dns-server"]; isSet {
+		config.DhcpDnsServer = params["dhcp-dns-server"].(string)
+	}
+	if _, isSet := params["dhcp-range"]; isSet {
+		for _, e := range params["dhcp-range"].([]interface{}) {
+			config.DhcpRanges = append(config.DhcpRanges, SdnDhcpRange{}.mapToStruct(e))
+		}
+	}
+	return &config
+}
+
+func (config ConfigSdnSubnet) Validate() error {
+	if err := config.Vnet.Validate(); err != nil {
+		return err
+	}
+	_, network, err := net.ParseCIDR(config.CIDR)
+	if err != nil {
+		return errors.New("sdn subnet cidr (" + config.CIDR + ") must be a network address with prefix length")
+	}
+	if network.String() != config.CIDR {
+		return errors.New("sdn subnet cidr (" + config.CIDR + ") must be the network address, e.g. " + network.String())
+	}
+	if config.Gateway != "" {
+		gateway := net.ParseIP(config.Gateway)
+		if gateway == nil || !network.Contains(gateway) {
+			return errors.New("sdn subnet gateway (" + config.Gateway + ") must be an address inside " + config.CIDR)
+		}
+	}
+	for _, e := range config.DhcpRanges {
+		if err := e.Validate(network); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListSdnSubnets returns the subnets of the vnet including the changes that have not been applied yet.
+func (c *Client) ListSdnSubnets(ctx context.Context, vnet SdnVnetName) ([]ConfigSdnSubnet, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := vnet.Validate(); err != nil {
+		return nil, err
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, sdnVnetUrl(vnet)+"/subnets")
+	if err != nil {
+		return nil, err
+	}
+	subnets := make([]ConfigSdnSubnet, len(list))
+	for i, e := range list {
+		subnets[i] = *ConfigSdnSubnet{}.mapToStruct(e.(map[string]interface{}))
+		subnets[i].Vnet = vnet
+	}
+	return subnets, nil
+}
+
+func (c *Client) DeleteSdnSubnet(ctx context.Context, vnet SdnVnetName, cidr string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := vnet.Validate(); err != nil {
+		return err
+	}
+	url, err := sdnSubnetUrl(ctx, vnet, cidr, c)
+	if err != nil {
+		return err
+	}
+	return c.Delete(ctx, url)
+}
+
+// Proxmox identifies subnets by zone and cidr, e.g. zone1-10.0.0.0-24.
+func sdnSubnetID(zone SdnZoneName, cidr string) string {
+	return string(zone) + "-" + strings.Replace(cidr, "/", "-", 1)
+}
+
+func sdnSubnetUrl(ctx context.Context, vnet SdnVnetName, cidr string, client *Client) (string, error) {
+	config, err := client.GetSdnVnet(ctx, vnet)
+	if err != nil {
+		return "", err
+	}
+	return sdnVnetUrl(vnet) + "/subnets/" + sdnSubnetID(config.Zone, cidr), nil
+}
+
+// Returns -1, 0 or 1 when a is before, equal to or after b.
+func compareIP(a, b net.IP) int {
+	if a4, b4 := a.To4(), b.To4(); a4 != nil && b4 != nil {
+		a, b = a4, b4
+	} else {
+		a, b = a.To16(), b.To16()
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
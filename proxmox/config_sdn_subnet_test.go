@@ -0,0 +1,68 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigSdnSubnet_mapToApiValues(t *testing.T) {
+	subnet := ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1", Snat: true, DhcpRanges: []SdnDhcpRange{{Start: "10.0.0.100", End: "10.0.0.200"}}}
+	require.Equal(t, map[string]interface{}{
+		"subnet":     "10.0.0.0/24",
+		"type":       "subnet",
+		"gateway":    "10.0.0.1",
+		"snat":       true,
+		"dhcp-range": []string{"start-address=10.0.0.100,end-address=10.0.0.200"},
+	}, subnet.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{
+		"snat":   false,
+		"delete": "dhcp-dns-server,dnszoneprefix,gateway,dhcp-range",
+	}, ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.0/24"}.mapToApiValues(false))
+}
+
+func Test_ConfigSdnSubnet_mapToStruct(t *testing.T) {
+	require.Equal(t, &ConfigSdnSubnet{Vnet: "web", CIDR: "fd00::/64", Gateway: "fd00::1", DhcpRanges: []SdnDhcpRange{{Start: "fd00::10", End: "fd00::20"}, {Start: "fd00::30", End: "fd00::40"}}},
+		ConfigSdnSubnet{}.mapToStruct(map[string]interface{}{
+			"vnet":    "web",
+			"subnet":  "lan-fd00::-64",
+			"cidr":    "fd00::/64",
+			"gateway": "fd00::1",
+			"dhcp-range": []interface{}{
+				map[string]interface{}{"start-address": "fd00::10", "end-address": "fd00::20"},
+				"start-address=fd00::30,end-address=fd00::40",
+			},
+		}))
+}
+
+func Test_ConfigSdnSubnet_Validate(t *testing.T) {
+	testData := []struct {
+		name  string
+		input ConfigSdnSubnet
+		err   bool
+	}{
+		{name: "ipv4", input: ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1", DhcpRanges: []SdnDhcpRange{{Start: "10.0.0.10", End: "10.0.0.20"}}}},
+		{name: "ipv6", input: ConfigSdnSubnet{Vnet: "web", CIDR: "fd00::/64", Gateway: "fd00::1"}},
+		{name: "invalid vnet", input: ConfigSdnSubnet{Vnet: "", CIDR: "10.0.0.0/24"}, err: true},
+		{name: "invalid cidr", input: ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.0"}, err: true},
+		{name: "host bits set", input: ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.1/24"}, err: true},
+		{name: "gateway outside", input: ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.0/24", Gateway: "10.0.1.1"}, err: true},
+		{name: "range outside", input: ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.0/24", DhcpRanges: []SdnDhcpRange{{Start: "10.0.0.10", End: "10.0.1.20"}}}, err: true},
+		{name: "range reversed", input: ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.0/24", DhcpRanges: []SdnDhcpRange{{Start: "10.0.0.20", End: "10.0.0.10"}}}, err: true},
+		{name: "range invalid", input: ConfigSdnSubnet{Vnet: "web", CIDR: "10.0.0.0/24", DhcpRanges: []SdnDhcpRange{{Start: "10.0.0.20"}}}, err: true},
+	}
+	for _, e := range testData {
+		t.Run(e.name, func(*testing.T) {
+			if e.err {
+				require.Error(t, e.input.Validate())
+			} else {
+				require.NoError(t, e.input.Validate())
+			}
+		})
+	}
+}
+
+func Test_sdnSubnetID(t *testing.T) {
+	require.Equal(t, "lan-10.0.0.0-24", sdnSubnetID("lan", "10.0.0.0/24"))
+	require.Equal(t, "lan-fd00::-64", sdnSubnetID("lan", "fd00::/64"))
+}
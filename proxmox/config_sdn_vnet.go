@@ -0,0 +1,145 @@
+package proxmox
+
+import (
+	"context"
+)
+
+// Virtual network in an SDN zone, guests connect to it as if it is a bridge.
+type ConfigSdnVnet struct {
+	Name  SdnVnetName `json:"vnet"`
+	Zone  SdnZoneName `json:"zone"`
+	Alias string      `json:"alias,omitempty"`
+	// vlan or vxlan id, depending on the type of the zone.
+	Tag       *uint `json:"tag,omitempty"`
+	VlanAware bool  `json:"vlanaware"`
+}
+
+func (config ConfigSdnVnet) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return client.Post(ctx, config.mapToApiValues(true), sdnUrl+"/vnets")
+}
+
+// Updates the vnet, an empty alias or tag is removed.
+func (config ConfigSdnVnet) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return client.Put(ctx, config.mapToApiValues(false), sdnVnetUrl(config.Name))
+}
+
+func (config ConfigSdnVnet) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"zone":      string(config.Zone),
+		"vlanaware": config.VlanAware,
+	}
+	if create {
+		params["vnet"] = string(config.Name)
+	}
+	var deletions string
+	if config.Alias != "" {
+		params["alias"] = config.Alias
+	} else if !create {
+		deletions = AddToList(deletions, "alias")
+	}
+	if config.Tag != nil {
+		params["tag"] = *config.Tag
+	} else if !create {
+		deletions = AddToList(deletions, "tag")
+	}
+	if deletions != "" {
+		params["delete"] = deletions
+	}
+	return params
+}
+
+func (ConfigSdnVnet) mapToStruct(params map[string]interface{}) *ConfigSdnVnet {
+	config := ConfigSdnVnet{}
+	if _, isSet := params["vnet"]; isSet {
+		config.Name = SdnVnetName(params["vnet"].(string))
+	}
+	if _, isSet := params["zone"]; isSet {
+		config.Zone = SdnZoneName(params["zone"].(string))
+	}
+	if _, isSet := params["alias"]; isSet {
+		config.Alias = params["alias"].(string)
+	}
+	if _, isSet := params["tag"]; isSet {
+		config.Tag = PointerUint(uint(params["tag"].(float64)))
+	}
+	if _, isSet := params["vlanaware"]; isSet {
+		config.VlanAware = Itob(int(params["vlanaware"].(float64)))
+	}
+	return &config
+}
+
+func (config ConfigSdnVnet) Validate() error {
+	if err := config.Name.Validate(); err != nil {
+		return err
+	}
+	if err := config.Zone.Validate(); err != nil {
+		return err
+	}
+	if config.Tag != nil {
+		return ValidateIntInRange(1, 16777215, int(*config.Tag), "tag")
+	}
+	return nil
+}
+
+type SdnVnetName string
+
+func (vnet SdnVnetName) Validate() error {
+	return validateSdnID("vnet", string(vnet))
+}
+
+// ListSdnVnets returns the vnets including the changes that have not been applied yet.
+func (c *Client) ListSdnVnets(ctx context.Context) ([]ConfigSdnVnet, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, sdnUrl+"/vnets")
+	if err != nil {
+		return nil, err
+	}
+	vnets := make([]ConfigSdnVnet, len(list))
+	for i, e := range list {
+		vnets[i] = *ConfigSdnVnet{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return vnets, nil
+}
+
+func (c *Client) GetSdnVnet(ctx context.Context, vnet SdnVnetName) (*ConfigSdnVnet, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := vnet.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, sdnVnetUrl(vnet), "sdn vnet", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return ConfigSdnVnet{}.mapToStruct(params), nil
+}
+
+// DeleteSdnVnet removes the vnet, Proxmox refuses this while the vnet still has subnets.
+func (c *Client) DeleteSdnVnet(ctx context.Context, vnet SdnVnetName) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := vnet.Validate(); err != nil {
+		return err
+	}
+	return c.Delete(ctx, sdnVnetUrl(vnet))
+}
+
+func sdnVnetUrl(vnet SdnVnetName) string {
+	return sdnUrl + "/vnets/" + string(vnet)
+}
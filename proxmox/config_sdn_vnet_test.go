@@ -0,0 +1,18 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigSdnVnet(t *testing.T) {
+	vnet := ConfigSdnVnet{Name: "web", Zone: "lan", Tag: PointerUint(10), VlanAware: true}
+	require.NoError(t, vnet.Validate())
+	require.Equal(t, map[string]interface{}{"vnet": "web", "zone": "lan", "tag": uint(10), "vlanaware": true}, vnet.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{"zone": "lan", "tag": uint(10), "vlanaware": true, "delete": "alias"}, vnet.mapToApiValues(false))
+	require.Equal(t, &vnet, ConfigSdnVnet{}.mapToStruct(map[string]interface{}{"vnet": "web", "zone": "lan", "tag": float64(10), "vlanaware": float64(1), "type": "vnet"}))
+	require.Error(t, ConfigSdnVnet{Name: "web"}.Validate())
+	require.Error(t, ConfigSdnVnet{Name: "w", Zone: "lan"}.Validate())
+	require.Error(t, ConfigSdnVnet{Name: "web", Zone: "lan", Tag: PointerUint(0)}.Validate())
+}
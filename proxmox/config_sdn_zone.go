@@ -0,0 +1,345 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type SdnZoneType string
+
+const (
+	SdnZoneType_Evpn   SdnZoneType = "evpn"
+	SdnZoneType_QinQ   SdnZoneType = "qinq"
+	SdnZoneType_Simple SdnZoneType = "simple"
+	SdnZoneType_Vlan   SdnZoneType = "vlan"
+	SdnZoneType_Vxlan  SdnZoneType = "vxlan"
+)
+
+func (t SdnZoneType) Validate() error {
+	switch t {
+	case SdnZoneType_Evpn, SdnZoneType_QinQ, SdnZoneType_Simple, SdnZoneType_Vlan, SdnZoneType_Vxlan:
+		return nil
+	}
+	return errors.New("sdn zone type must be one of (evpn,qinq,simple,vlan,vxlan)")
+}
+
+type ConfigSdnZoneVlan struct {
+	Bridge string `json:"bridge"`
+}
+
+type ConfigSdnZoneQinQ struct {
+	Bridge string `json:"bridge"`
+	// Outer vlan tag of the zone.
+	ServiceVlan uint `json:"tag"`
+	// 802.1q or 802.1ad, Proxmox defaults to 802.1q.
+	VlanProtocol string `json:"vlan-protocol,omitempty"`
+}
+
+type ConfigSdnZoneVxlan struct {
+	// Addresses of the nodes that take part in the vxlan.
+	Peers []string `json:"peers"`
+}
+
+type ConfigSdnZoneEvpn struct {
+	Controller string `json:"controller"`
+	// vxlan id of the l3vni.
+	VrfVxlan uint   `json:"vrf-vxlan"`
+	Mac      string `json:"mac,omitempty"`
+	// Nodes that route traffic out of the zone.
+	ExitNodes               []string `json:"exitnodes,omitempty"`
+	PrimaryExitNode         string   `json:"exitnodes-primary,omitempty"`
+	ExitNodesLocalRouting   bool     `json:"exitnodes-local-routing"`
+	AdvertiseSubnets        bool     `json:"advertise-subnets"`
+	DisableArpNdSuppression bool     `json:"disable-arp-nd-suppression"`
+	RouteTargetImport       string   `json:"rt-import,omitempty"`
+}
+
+// SDN zone, only the settings matching the Type are used.
+type ConfigSdnZone struct {
+	Name SdnZoneName `json:"zone"`
+	Type SdnZoneType `json:"type"`
+	// Nodes the zone is deployed on, empty for all nodes.
+	Nodes      []string            `json:"nodes,omitempty"`
+	MTU        *uint               `json:"mtu,omitempty"`
+	Ipam       string              `json:"ipam,omitempty"`
+	Dns        string              `json:"dns,omitempty"`
+	ReverseDns string              `json:"reversedns,omitempty"`
+	DnsZone    string              `json:"dnszone,omitempty"`
+	Dhcp       string              `json:"dhcp,omitempty"` // only dnsmasq is supported by Proxmox
+	Vlan       *ConfigSdnZoneVlan  `json:"vlan,omitempty"`
+	QinQ       *ConfigSdnZoneQinQ  `json:"qinq,omitempty"`
+	Vxlan      *ConfigSdnZoneVxlan `json:"vxlan,omitempty"`
+	Evpn       *ConfigSdnZoneEvpn  `json:"evpn,omitempty"`
+}
+
+func (config ConfigSdnZone) Create(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return client.Post(ctx, config.mapToApiValues(true), sdnUrl+"/zones")
+}
+
+// Updates the zone, empty optional settings are removed. The type of a zone can not be changed.
+func (config ConfigSdnZone) Update(ctx context.Context, client *Client) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return client.Put(ctx, config.mapToApiValues(false), sdnUrl+"/zones/"+string(config.Name))
+}
+
+func (config ConfigSdnZone) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{}
+	if create {
+		params["zone"] = string(config.Name)
+		params["type"] = string(config.Type)
+	}
+	optionals := map[string]string{
+		"nodes":      strings.Join(config.Nodes, ","),
+		"ipam":       config.Ipam,
+		"dns":        config.Dns,
+		"reversedns": config.ReverseDns,
+		"dnszone":    config.DnsZone,
+		"dhcp":       config.Dhcp,
+		"mtu":        "",
+	}
+	if config.MTU != nil {
+		optionals["mtu"] = strconv.FormatUint(uint64(*config.MTU), 10)
+	}
+	switch config.Type {
+	case SdnZoneType_Vlan:
+		if config.Vlan != nil {
+			params["bridge"] = config.Vlan.Bridge
+		}
+	case SdnZoneType_QinQ:
+		if config.QinQ != nil {
+			params["bridge"] = config.QinQ.Bridge
+			params["tag"] = config.QinQ.ServiceVlan
+			optionals["vlan-protocol"] = config.QinQ.VlanProtocol
+		}
+	case SdnZoneType_Vxlan:
+		if config.Vxlan != nil {
+			params["peers"] = strings.Join(config.Vxlan.Peers, ",")
+		}
+	case SdnZoneType_Evpn:
+		if config.Evpn != nil {
+			params["controller"] = config.Evpn.Controller
+			params["vrf-vxlan"] = config.Evpn.VrfVxlan
+			params["exitnodes-local-routing"] = config.Evpn.ExitNodesLocalRouting
+			params["advertise-subnets"] = config.Evpn.AdvertiseSubnets
+			params["disable-arp-nd-suppression"] = config.Evpn.DisableArpNdSuppression
+			optionals["mac"] = config.Evpn.Mac
+			optionals["exitnodes"] = strings.Join(config.Evpn.ExitNodes, ",")
+			optionals["exitnodes-primary"] = config.Evpn.PrimaryExitNode
+			optionals["rt-import"] = config.Evpn.RouteTargetImport
+		}
+	}
+	keys := make([]string, 0, len(optionals))
+	for key := range optionals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var deletions string
+	for _, key := range keys {
+		if value := optionals[key]; value != "" {
+			params[key] = value
+		} else if !create {
+			deletions = AddToList(deletions, key)
+		}
+	}
+	if deletions != "" {
+		params["delete"] = deletions
+	}
+	return params
+}
+
+func (ConfigSdnZone) mapToStruct(params map[string]interface{}) *ConfigSdnZone {
+	config := ConfigSdnZone{}
+	if _, isSet := params["zone"]; isSet {
+		config.Name = SdnZoneName(params["zone"].(string))
+	}
+	if _, isSet := params["type"]; isSet {
+		config.Type = SdnZoneType(params["type"].(string))
+	}
+	if _, isSet := params["nodes"]; isSet {
+		config.Nodes = splitCsv(params["nodes"].(string))
+	}
+	if _, isSet := params["mtu"]; isSet {
+		config.MTU = PointerUint(uint(params["mtu"].(float64)))
+	}
+	if _, isSet := params["ipam"]; isSet {
+		config.Ipam = params["ipam"].(string)
+	}
+	if _, isSet := params["dns"]; isSet {
+		config.Dns = params["dns"].(string)
+	}
+	if _, isSet := params["reversedns"]; isSet {
+		config.ReverseDns = params["reversedns"].(string)
+	}
+	if _, isSet := params["dnszone"]; isSet {
+		config.DnsZone = params["dnszone"].(string)
+	}
+	if _, isSet := params["dhcp"]; isSet {
+		config.Dhcp = params["dhcp"].(string)
+	}
+	switch config.Type {
+	case SdnZoneType_Vlan:
+		config.Vlan = &ConfigSdnZoneVlan{}
+		if _, isSet := params["bridge"]; isSet {
+			config.Vlan.Bridge = params["bridge"].(string)
+		}
+	case SdnZoneType_QinQ:
+		config.QinQ = &ConfigSdnZoneQinQ{}
+		if _, isSet := params["bridge"]; isSet {
+			config.QinQ.Bridge = params["bridge"].(string)
+		}
+		if _, isSet := params["tag"]; isSet {
+			config.QinQ.ServiceVlan = uint(params["tag"].(float64))
+		}
+		if _, isSet := params["vlan-protocol"]; isSet {
+			config.QinQ.VlanProtocol = params["vlan-protocol"].(string)
+		}
+	case SdnZoneType_Vxlan:
+		config.Vxlan = &ConfigSdnZoneVxlan{}
+		if _, isSet := params["peers"]; isSet {
+			config.Vxlan.Peers = splitCsv(params["peers"].(string))
+		}
+	case SdnZoneType_Evpn:
+		config.Evpn = &ConfigSdnZoneEvpn{}
+		if _, isSet := params["controller"]; isSet {
+			config.Evpn.Controller = params["controller"].(string)
+		}
+		if _, isSet := params["vrf-vxlan"]; isSet {
+			config.Evpn.VrfVxlan = uint(params["vrf-vxlan"].(float64))
+		}
+		if _, isSet := params["mac"]; isSet {
+			config.Evpn.Mac = params["mac"].(string)
+		}
+		if _, isSet := params["exitnodes"]; isSet {
+			config.Evpn.ExitNodes = splitCsv(params["exitnodes"].(string))
+		}
+		if _, isSet := params["exitnodes-primary"]; isSet {
+			config.Evpn.PrimaryExitNode = params["exitnodes-primary"].(string)
+		}
+		if _, isSet := params["exitnodes-local-routing"]; isSet {
+			config.Evpn.ExitNodesLocalRouting = Itob(int(params["exitnodes-local-routing"].(float64)))
+		}
+		if _, isSet := params["advertise-subnets"]; isSet {
+			config.Evpn.AdvertiseSubnets = Itob(int(params["advertise-subnets"].(float64)))
+		}
+		if _, isSet := params["disable-arp-nd-suppression"]; isSet {
+			config.Evpn.DisableArpNdSuppression = Itob(int(params["disable-arp-nd-suppression"].(float64)))
+		}
+		if _, isSet := params["rt-import"]; isSet {
+			config.Evpn.RouteTargetImport = params["rt-import"].(string)
+		}
+	}
+	return &config
+}
+
+func (config ConfigSdnZone) Validate() error {
+	if err := config.Name.Validate(); err != nil {
+		return err
+	}
+	if err := config.Type.Validate(); err != nil {
+		return err
+	}
+	if config.MTU != nil {
+		if err := ValidateIntInRange(512, 65536, int(*config.MTU), "mtu"); err != nil {
+			return err
+		}
+	}
+	switch config.Type {
+	case SdnZoneType_Vlan:
+		if config.Vlan == nil || config.Vlan.Bridge == "" {
+			return errors.New("sdn zone of type vlan requires a bridge")
+		}
+	case SdnZoneType_QinQ:
+		if config.QinQ == nil || config.QinQ.Bridge == "" {
+			return errors.New("sdn zone of type qinq requires a bridge")
+		}
+		if err := ValidateIntInRange(1, 4094, int(config.QinQ.ServiceVlan), "tag"); err != nil {
+			return err
+		}
+		if config.QinQ.VlanProtocol != "" && !inArray([]string{"802.1q", "802.1ad"}, config.QinQ.VlanProtocol) {
+			return errors.New("sdn zone vlan-protocol must be one of (802.1q,802.1ad)")
+		}
+	case SdnZoneType_Vxlan:
+		if config.Vxlan == nil || len(config.Vxlan.Peers) == 0 {
+			return errors.New("sdn zone of type vxlan requires at least one peer")
+		}
+	case SdnZoneType_Evpn:
+		if config.Evpn == nil || config.Evpn.Controller == "" {
+			return errors.New("sdn zone of type evpn requires a controller")
+		}
+		if err := ValidateIntInRange(1, 16777215, int(config.Evpn.VrfVxlan), "vrf-vxlan"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type SdnZoneName string
+
+func (zone SdnZoneName) Validate() error {
+	return validateSdnID("zone", string(zone))
+}
+
+// ListSdnZones returns the zones including the changes that have not been applied yet.
+func (c *Client) ListSdnZones(ctx context.Context) ([]ConfigSdnZone, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, sdnUrl+"/zones")
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]ConfigSdnZone, len(list))
+	for i, e := range list {
+		zones[i] = *ConfigSdnZone{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return zones, nil
+}
+
+func (c *Client) GetSdnZone(ctx context.Context, zone SdnZoneName) (*ConfigSdnZone, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := zone.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, sdnUrl+"/zones/"+string(zone), "sdn zone", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return ConfigSdnZone{}.mapToStruct(params), nil
+}
+
+func (c *Client) DeleteSdnZone(ctx context.Context, zone SdnZoneName) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := zone.Validate(); err != nil {
+		return err
+	}
+	return c.Delete(ctx, sdnUrl+"/zones/"+string(zone))
+}
+
+// Splits a comma separated list, an empty string results in an empty list.
+func splitCsv(list string) []string {
+	items := []string{}
+	for _, e := range strings.Split(list, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			items = append(items, e)
+		}
+	}
+	return items
+}
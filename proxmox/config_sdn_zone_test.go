@@ -0,0 +1,83 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigSdnZone_mapToApiValues(t *testing.T) {
+	vlan := ConfigSdnZone{Name: "lan", Type: SdnZoneType_Vlan, Nodes: []string{"pve1", "pve2"}, Vlan: &ConfigSdnZoneVlan{Bridge: "vmbr0"}}
+	require.Equal(t, map[string]interface{}{
+		"zone":   "lan",
+		"type":   "vlan",
+		"nodes":  "pve1,pve2",
+		"bridge": "vmbr0",
+	}, vlan.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{
+		"nodes":  "pve1,pve2",
+		"bridge": "vmbr0",
+		"delete": "dhcp,dns,dnszone,ipam,mtu,reversedns",
+	}, vlan.mapToApiValues(false))
+	evpn := ConfigSdnZone{Name: "evpn1", Type: SdnZoneType_Evpn, MTU: PointerUint(1450), Evpn: &ConfigSdnZoneEvpn{Controller: "ctl", VrfVxlan: 10000, ExitNodes: []string{"pve1"}, AdvertiseSubnets: true}}
+	require.Equal(t, map[string]interface{}{
+		"zone":                       "evpn1",
+		"type":                       "evpn",
+		"mtu":                        "1450",
+		"controller":                 "ctl",
+		"vrf-vxlan":                  uint(10000),
+		"exitnodes":                  "pve1",
+		"exitnodes-local-routing":    false,
+		"advertise-subnets":          true,
+		"disable-arp-nd-suppression": false,
+	}, evpn.mapToApiValues(true))
+}
+
+func Test_ConfigSdnZone_mapToStruct(t *testing.T) {
+	require.Equal(t, &ConfigSdnZone{Name: "qz", Type: SdnZoneType_QinQ, MTU: PointerUint(1496), Nodes: []string{"pve1"}, QinQ: &ConfigSdnZoneQinQ{Bridge: "vmbr1", ServiceVlan: 20, VlanProtocol: "802.1ad"}},
+		ConfigSdnZone{}.mapToStruct(map[string]interface{}{
+			"zone":          "qz",
+			"type":          "qinq",
+			"mtu":           float64(1496),
+			"nodes":         "pve1",
+			"bridge":        "vmbr1",
+			"tag":           float64(20),
+			"vlan-protocol": "802.1ad",
+			"digest":        "abc",
+		}))
+	require.Equal(t, &ConfigSdnZone{Name: "vx", Type: SdnZoneType_Vxlan, Vxlan: &ConfigSdnZoneVxlan{Peers: []string{"10.0.0.1", "10.0.0.2"}}},
+		ConfigSdnZone{}.mapToStruct(map[string]interface{}{"zone": "vx", "type": "vxlan", "peers": "10.0.0.1, 10.0.0.2"}))
+}
+
+func Test_ConfigSdnZone_Validate(t *testing.T) {
+	testData := []struct {
+		name  string
+		input ConfigSdnZone
+		err   bool
+	}{
+		{name: "simple", input: ConfigSdnZone{Name: "simple1", Type: SdnZoneType_Simple}},
+		{name: "vlan", input: ConfigSdnZone{Name: "lan", Type: SdnZoneType_Vlan, Vlan: &ConfigSdnZoneVlan{Bridge: "vmbr0"}}},
+		{name: "qinq", input: ConfigSdnZone{Name: "qz", Type: SdnZoneType_QinQ, QinQ: &ConfigSdnZoneQinQ{Bridge: "vmbr0", ServiceVlan: 100}}},
+		{name: "vxlan", input: ConfigSdnZone{Name: "vx", Type: SdnZoneType_Vxlan, Vxlan: &ConfigSdnZoneVxlan{Peers: []string{"10.0.0.1"}}}},
+		{name: "evpn", input: ConfigSdnZone{Name: "ev", Type: SdnZoneType_Evpn, Evpn: &ConfigSdnZoneEvpn{Controller: "ctl", VrfVxlan: 1}}},
+		{name: "invalid name", input: ConfigSdnZone{Name: "Zone", Type: SdnZoneType_Simple}, err: true},
+		{name: "name too long", input: ConfigSdnZone{Name: "zone12345", Type: SdnZoneType_Simple}, err: true},
+		{name: "invalid type", input: ConfigSdnZone{Name: "zone", Type: "faucet"}, err: true},
+		{name: "invalid mtu", input: ConfigSdnZone{Name: "zone", Type: SdnZoneType_Simple, MTU: PointerUint(100)}, err: true},
+		{name: "vlan without bridge", input: ConfigSdnZone{Name: "lan", Type: SdnZoneType_Vlan}, err: true},
+		{name: "qinq without tag", input: ConfigSdnZone{Name: "qz", Type: SdnZoneType_QinQ, QinQ: &ConfigSdnZoneQinQ{Bridge: "vmbr0"}}, err: true},
+		{name: "qinq invalid protocol", input: ConfigSdnZone{Name: "qz", Type: SdnZoneType_QinQ, QinQ: &ConfigSdnZoneQinQ{Bridge: "vmbr0", ServiceVlan: 1, VlanProtocol: "802.1x"}}, err: true},
+		{name: "vxlan without peers", input: ConfigSdnZone{Name: "vx", Type: SdnZoneType_Vxlan, Vxlan: &ConfigSdnZoneVxlan{}}, err: true},
+		{name: "evpn without controller", input: ConfigSdnZone{Name: "ev", Type: SdnZoneType_Evpn, Evpn: &ConfigSdnZoneEvpn{VrfVxlan: 1}}, err: true},
+		{name: "evpn without vrf-vxlan", input: ConfigSdnZone{Name: "ev", Type: SdnZoneType_Evpn, Evpn: &ConfigSdnZoneEvpn{Controller: "ctl"}}, err: true},
+	}
+	for _, e := range testData {
+		t.Run(e.name, func(*testing.T) {
+			if e.err {
+				require.Error(t, e.input.Validate())
+			} else {
+				require.NoError(t, e.input.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package proxmox
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Startup and shutdown behavior of a guest, controls in which order the guests of a node are started when it boots.
+// Shared by QEMU and LXC guests, whether the guest is started at all when the node boots is set by the onboot option.
+type StartupConfig struct {
+	// Guests with a lower order are started first and shut down last, 0 leaves the order unset.
+	Order int `json:"order,omitempty"`
+	// Seconds to wait after starting the guest before the next guest is started.
+	UpDelay int `json:"up,omitempty"`
+	// Seconds to wait for the guest to shut down before it is stopped.
+	DownDelay int `json:"down,omitempty"`
+}
+
+func (startup StartupConfig) mapToApiValues() string {
+	settings := []string{}
+	if startup.Order != 0 {
+		settings = append(settings, "order="+strconv.Itoa(startup.Order))
+	}
+	if startup.UpDelay != 0 {
+		settings = append(settings, "up="+strconv.Itoa(startup.UpDelay))
+	}
+	if startup.DownDelay != 0 {
+		settings = append(settings, "down="+strconv.Itoa(startup.DownDelay))
+	}
+	return strings.Join(settings, ",")
+}
+
+func (StartupConfig) mapToStruct(setting string) *StartupConfig {
+	startup := StartupConfig{}
+	for _, e := range strings.Split(setting, ",") {
+		key, value, _ := strings.Cut(e, "=")
+		switch key {
+		case "order":
+			startup.Order, _ = strconv.Atoi(value)
+		case "up":
+			startup.UpDelay, _ = strconv.Atoi(value)
+		case "down":
+			startup.DownDelay, _ = strconv.Atoi(value)
+		}
+	}
+	return &startup
+}
+
+func (startup StartupConfig) Validate() error {
+	if startup.Order < 0 {
+		return errors.New("startup order may not be negative")
+	}
+	if startup.UpDelay < 0 {
+		return errors.New("startup up delay may not be negative")
+	}
+	if startup.DownDelay < 0 {
+		return errors.New("startup down delay may not be negative")
+	}
+	return nil
+}
+
+// StartupSettings returns the StartupConfig when it is set, otherwise the parsed untyped Startup.
+// Returns nil when neither is set.
+func (config ConfigQemu) StartupSettings() *StartupConfig {
+	if config.StartupConfig != nil {
+		return config.StartupConfig
+	}
+	if config.Startup == "" {
+		return nil
+	}
+	return StartupConfig{}.mapToStruct(config.Startup)
+}
+
+// StartupSettings returns the StartupConfig when it is set, otherwise the parsed untyped Startup.
+// Returns nil when neither is set.
+func (config ConfigLxc) StartupSettings() *StartupConfig {
+	if config.StartupConfig != nil {
+		return config.StartupConfig
+	}
+	if config.Startup == "" {
+		return nil
+	}
+	return StartupConfig{}.mapToStruct(config.Startup)
+}
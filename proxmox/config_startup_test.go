@@ -0,0 +1,38 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StartupConfig_mapToApiValues(t *testing.T) {
+	require.Equal(t, "", StartupConfig{}.mapToApiValues())
+	require.Equal(t, "order=2", StartupConfig{Order: 2}.mapToApiValues())
+	require.Equal(t, "order=1,up=30,down=60", StartupConfig{Order: 1, UpDelay: 30, DownDelay: 60}.mapToApiValues())
+}
+
+func Test_StartupConfig_mapToStruct(t *testing.T) {
+	require.Equal(t, &StartupConfig{Order: 1, UpDelay: 30, DownDelay: 60}, StartupConfig{}.mapToStruct("order=1,up=30,down=60"))
+	require.Equal(t, &StartupConfig{DownDelay: 10}, StartupConfig{}.mapToStruct("down=10"))
+}
+
+func Test_StartupConfig_Validate(t *testing.T) {
+	require.NoError(t, StartupConfig{}.Validate())
+	require.NoError(t, StartupConfig{Order: 1, UpDelay: 30, DownDelay: 60}.Validate())
+	require.Error(t, StartupConfig{Order: -1}.Validate())
+	require.Error(t, StartupConfig{UpDelay: -1}.Validate())
+	require.Error(t, StartupConfig{DownDelay: -1}.Validate())
+}
+
+func Test_ConfigQemu_StartupSettings(t *testing.T) {
+	require.Nil(t, ConfigQemu{}.StartupSettings())
+	require.Equal(t, &StartupConfig{Order: 1, UpDelay: 30}, ConfigQemu{Startup: "order=1,up=30"}.StartupSettings())
+	require.Equal(t, &StartupConfig{Order: 2}, ConfigQemu{Startup: "order=1,up=30", StartupConfig: &StartupConfig{Order: 2}}.StartupSettings())
+}
+
+func Test_ConfigLxc_StartupSettings(t *testing.T) {
+	require.Nil(t, ConfigLxc{}.StartupSettings())
+	require.Equal(t, &StartupConfig{DownDelay: 60}, ConfigLxc{Startup: "down=60"}.StartupSettings())
+	require.Equal(t, &StartupConfig{Order: 2}, ConfigLxc{Startup: "down=60", StartupConfig: &StartupConfig{Order: 2}}.StartupSettings())
+}
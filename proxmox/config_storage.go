@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -149,6 +150,8 @@ type ConfigStorageNFS struct {
 	Export        string  `json:"export"`
 	Preallocation *string `json:"preallocation,omitempty"`
 	Version       *string `json:"version,omitempty"`
+	// Mount options other than the version, e.g. "soft".
+	Options []string `json:"options,omitempty"`
 }
 
 func (nfs *ConfigStorageNFS) SetDefaults() {
@@ -269,6 +272,11 @@ type ConfigStoragePBS struct {
 	Fingerprint string  `json:"fingerprint,omitempty"`
 	Port        *int    `json:"port,omitempty"`
 	Namespace   string  `json:"namespace,omitempty"`
+	// Key used to encrypt the backups client side, "autogen" lets Proxmox generate a key without passphrase
+	// and an empty string removes the key. nil leaves the key unchanged, the API never returns the key itself.
+	EncryptionKey *string `json:"encryption-key,omitempty"`
+	// Fingerprint of the encryption key in use, read only.
+	EncryptionKeyFingerprint string `json:"encryption-key-fingerprint,omitempty"`
 }
 
 func (pbs *ConfigStoragePBS) SetDefaults() {
@@ -277,6 +285,28 @@ func (pbs *ConfigStoragePBS) SetDefaults() {
 	}
 }
 
+const pbsMaxNamespaceDepth = 7
+
+var rxPbsNamespaceComponent = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._\-]*$`)
+
+// Validates the namespace, which is a path of at most pbsMaxNamespaceDepth components below the root namespace.
+func validatePbsNamespace(namespace string) error {
+	namespace = strings.Trim(namespace, "/")
+	if namespace == "" {
+		return nil
+	}
+	components := strings.Split(namespace, "/")
+	if len(components) > pbsMaxNamespaceDepth {
+		return fmt.Errorf("pbs:{ namespace } may be at most %d levels deep", pbsMaxNamespaceDepth)
+	}
+	for _, e := range components {
+		if !rxPbsNamespaceComponent.MatchString(e) {
+			return fmt.Errorf("pbs:{ namespace } component (%s) is invalid", e)
+		}
+	}
+	return nil
+}
+
 // Storage options for the Proxmox API
 type ConfigStorage struct {
 	ID              string                        `json:"id"`
@@ -723,6 +753,10 @@ func (newConfig *ConfigStorage) Validate(ctx context.Context, id string, create
 			if err != nil {
 				return
 			}
+			err = validatePbsNamespace(newConfig.PBS.Namespace)
+			if err != nil {
+				return
+			}
 		}
 	}
 	if !inArray([]string{"pbs", "zfs-over-iscsi"}, newConfig.Type) {
@@ -782,8 +816,12 @@ func (config *ConfigStorage) mapToApiValues(create bool) (params map[string]inte
 	case "nfs":
 		if config.NFS != nil {
 			config.NFS.SetDefaults()
+			options := config.NFS.Options
 			if config.NFS.Version != nil {
-				params["options"] = "vers=" + *config.NFS.Version
+				options = append([]string{"vers=" + *config.NFS.Version}, options...)
+			}
+			if len(options) > 0 {
+				params["options"] = strings.Join(options, ",")
 			} else {
 				deletions = AddToList(deletions, "options")
 			}
@@ -917,6 +955,13 @@ func (config *ConfigStorage) mapToApiValues(create bool) (params map[string]inte
 			if config.PBS.Namespace != "" {
 				params["namespace"] = strings.TrimLeft(config.PBS.Namespace, "/")
 			}
+			if config.PBS.EncryptionKey != nil {
+				if *config.PBS.EncryptionKey != "" {
+					params["encryption-key"] = *config.PBS.EncryptionKey
+				} else if !create {
+					deletions = AddToList(deletions, "encryption-key")
+				}
+			}
 		}
 		config.Content = &ConfigStorageContent{
 			Backup: PointerBool(true),
@@ -987,6 +1032,18 @@ func (config *ConfigStorage) Update(ctx context.Context, id string, client *Clie
 	return
 }
 
+// Deletes the storage definition, the data on the storage itself is not touched.
+func (config *ConfigStorage) Delete(ctx context.Context, id string, client *Client) (err error) {
+	exists, err := client.CheckStorageExistance(ctx, id)
+	if err != nil {
+		return
+	}
+	if !exists {
+		return ErrorItemNotExists(id, "storage")
+	}
+	return client.DeleteStorage(ctx, id)
+}
+
 func NewConfigStorageFromApi(ctx context.Context, storageid string, client *Client) (config *ConfigStorage, err error) {
 	// prepare json map to receive the information from the api
 	var rawConfig map[string]interface{}
@@ -994,7 +1051,11 @@ func NewConfigStorageFromApi(ctx context.Context, storageid string, client *Clie
 	if err != nil {
 		return nil, err
 	}
+	return ConfigStorage{}.mapToStruct(storageid, rawConfig), nil
+}
 
+// Maps the raw storage config to the struct of its type, the "type" field decides which backend struct is populated.
+func (ConfigStorage) mapToStruct(storageid string, rawConfig map[string]interface{}) (config *ConfigStorage) {
 	config = new(ConfigStorage)
 
 	config.ID = storageid
@@ -1016,14 +1077,18 @@ func NewConfigStorageFromApi(ctx context.Context, storageid string, client *Clie
 	case "directory":
 		config.Directory = new(ConfigStorageDirectory)
 		config.Directory.Path = rawConfig["path"].(string)
-		config.Directory.Shared = Itob(int(rawConfig["shared"].(float64)))
+		if _, isSet := rawConfig["shared"]; isSet {
+			config.Directory.Shared = Itob(int(rawConfig["shared"].(float64)))
+		}
 		if _, isSet := rawConfig["preallocation"]; isSet {
 			config.Directory.Preallocation = PointerString(rawConfig["preallocation"].(string))
 		}
 	case "lvm":
 		config.LVM = new(ConfigStorageLVM)
 		config.LVM.VGname = rawConfig["vgname"].(string)
-		config.LVM.Shared = Itob(int(rawConfig["shared"].(float64)))
+		if _, isSet := rawConfig["shared"]; isSet {
+			config.LVM.Shared = Itob(int(rawConfig["shared"].(float64)))
+		}
 	case "lvm-thin":
 		config.LVMThin = new(ConfigStorageLVMThin)
 		config.LVMThin.Thinpool = rawConfig["thinpool"].(string)
@@ -1033,8 +1098,13 @@ func NewConfigStorageFromApi(ctx context.Context, storageid string, client *Clie
 		config.NFS.Server = rawConfig["server"].(string)
 		config.NFS.Export = rawConfig["export"].(string)
 		if _, isSet := rawConfig["options"]; isSet {
-			version := strings.Split(rawConfig["options"].(string), "=")
-			config.NFS.Version = PointerString(version[1])
+			for _, option := range strings.Split(rawConfig["options"].(string), ",") {
+				if strings.HasPrefix(option, "vers=") {
+					config.NFS.Version = PointerString(strings.TrimPrefix(option, "vers="))
+				} else if option != "" {
+					config.NFS.Options = append(config.NFS.Options, option)
+				}
+			}
 		}
 		if _, isSet := rawConfig["preallocation"]; isSet {
 			config.NFS.Preallocation = PointerString(rawConfig["preallocation"].(string))
@@ -1085,7 +1155,9 @@ func NewConfigStorageFromApi(ctx context.Context, storageid string, client *Clie
 		}
 	case "rbd":
 		config.RBD = new(ConfigStorageRBD)
-		config.RBD.KRBD = Itob(int(rawConfig["krbd"].(float64)))
+		if _, isSet := rawConfig["krbd"]; isSet {
+			config.RBD.KRBD = Itob(int(rawConfig["krbd"].(float64)))
+		}
 		config.RBD.Monitors = CSVtoArray(rawConfig["monhost"].(string))
 		config.RBD.Pool = rawConfig["pool"].(string)
 		if _, isSet := rawConfig["namespace"]; isSet {
@@ -1127,7 +1199,9 @@ func NewConfigStorageFromApi(ctx context.Context, storageid string, client *Clie
 	case "zfs":
 		config.ZFS = new(ConfigStorageZFS)
 		config.ZFS.Pool = rawConfig["pool"].(string)
-		config.ZFS.Thinprovision = Itob(int(rawConfig["sparse"].(float64)))
+		if _, isSet := rawConfig["sparse"]; isSet {
+			config.ZFS.Thinprovision = Itob(int(rawConfig["sparse"].(float64)))
+		}
 		if _, isSet := rawConfig["blocksize"]; isSet {
 			config.ZFS.Blocksize = PointerString(rawConfig["blocksize"].(string))
 		}
@@ -1145,6 +1219,9 @@ func NewConfigStorageFromApi(ctx context.Context, storageid string, client *Clie
 		if _, isSet := rawConfig["namespace"]; isSet {
 			config.PBS.Namespace = rawConfig["namespace"].(string)
 		}
+		if _, isSet := rawConfig["encryption-key"]; isSet {
+			config.PBS.EncryptionKeyFingerprint = rawConfig["encryption-key"].(string)
+		}
 	}
 	config.SetDefaults()
 	if _, isSet := rawConfig["content"]; isSet {
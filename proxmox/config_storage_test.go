@@ -0,0 +1,111 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigStorage_mapToApiValues(t *testing.T) {
+	tests := []struct {
+		input  ConfigStorage
+		create bool
+		output map[string]interface{}
+	}{
+		{input: ConfigStorage{Type: "directory", Enable: true, Directory: &ConfigStorageDirectory{Path: "/mnt/data"}, Content: &ConfigStorageContent{Iso: PointerBool(true), Backup: PointerBool(true)}},
+			create: true,
+			output: map[string]interface{}{"content": "backup,iso", "disable": false, "nodes": "", "path": "/mnt/data", "preallocation": "metadata", "shared": false, "storage": "test", "type": "dir"}},
+		{input: ConfigStorage{Type: "directory", Enable: false, Directory: &ConfigStorageDirectory{Path: "/mnt/data"}, Content: &ConfigStorageContent{Iso: PointerBool(true)}},
+			output: map[string]interface{}{"content": "iso", "disable": true, "nodes": "", "preallocation": "metadata", "shared": false, "storage": "test"}},
+		{input: ConfigStorage{Type: "nfs", Enable: true, NFS: &ConfigStorageNFS{Server: "10.0.0.1", Export: "/export", Version: PointerString("4.2")}, Content: &ConfigStorageContent{DiskImage: PointerBool(true)}},
+			create: true,
+			output: map[string]interface{}{"content": "images", "disable": false, "export": "/export", "nodes": "", "options": "vers=4.2", "preallocation": "metadata", "server": "10.0.0.1", "storage": "test", "type": "nfs"}},
+		{input: ConfigStorage{Type: "smb", Enable: true, SMB: &ConfigStorageSMB{Server: "10.0.0.1", Share: "share", Username: "user", Domain: "example", Password: PointerString("secret")}, Content: &ConfigStorageContent{Iso: PointerBool(true)}},
+			create: true,
+			output: map[string]interface{}{"content": "iso", "disable": false, "domain": "example", "nodes": "", "password": "secret", "preallocation": "metadata", "server": "10.0.0.1", "share": "share", "storage": "test", "type": "cifs", "username": "user"}},
+		{input: ConfigStorage{Type: "lvm-thin", Enable: true, LVMThin: &ConfigStorageLVMThin{VGname: "pve", Thinpool: "data"}, Content: &ConfigStorageContent{DiskImage: PointerBool(true), Container: PointerBool(true)}},
+			create: true,
+			output: map[string]interface{}{"content": "rootdir,images", "disable": false, "nodes": "", "storage": "test", "thinpool": "data", "type": "lvmthin", "vgname": "pve"}},
+		{input: ConfigStorage{Type: "zfs", Enable: true, ZFS: &ConfigStorageZFS{Pool: "rpool/data", Thinprovision: true}, Content: &ConfigStorageContent{DiskImage: PointerBool(true)}},
+			create: true,
+			output: map[string]interface{}{"blocksize": "8k", "content": "images", "disable": false, "nodes": "", "pool": "rpool/data", "sparse": true, "storage": "test", "type": "zfspool"}},
+		{input: ConfigStorage{Type: "pbs", Enable: true, PBS: &ConfigStoragePBS{Server: "10.0.0.2", Datastore: "store", Username: "root@pam", Password: PointerString("secret"), Fingerprint: "aa:bb"}},
+			create: true,
+			output: map[string]interface{}{"content": "backup", "datastore": "store", "disable": false, "fingerprint": "aa:bb", "nodes": "", "password": "secret", "port": 8007, "server": "10.0.0.2", "storage": "test", "type": "pbs", "username": "root@pam"}},
+	}
+	for _, test := range tests {
+		test.input.ID = "test"
+		require.Equal(t, test.output, test.input.mapToApiValues(test.create), test.input.Type)
+	}
+}
+
+// Test that the backend specific fields survive reading the config and writing it back
+func Test_ConfigStorage_mapToStruct_RoundTrip(t *testing.T) {
+	tests := []struct {
+		input  map[string]interface{}
+		output *ConfigStorage
+	}{
+		{input: map[string]interface{}{"content": "backup,iso", "path": "/mnt/data", "preallocation": "full", "shared": float64(1), "type": "dir"},
+			output: &ConfigStorage{ID: "test", Enable: true, Type: "directory",
+				Directory: &ConfigStorageDirectory{Path: "/mnt/data", Preallocation: PointerString("full"), Shared: true},
+				Content:   &ConfigStorageContent{Backup: PointerBool(true), Container: PointerBool(false), DiskImage: PointerBool(false), Iso: PointerBool(true), Snippets: PointerBool(false), Template: PointerBool(false)}}},
+		{input: map[string]interface{}{"content": "images", "export": "/export", "options": "vers=4.2,soft,timeo=30", "preallocation": "metadata", "server": "10.0.0.1", "type": "nfs"},
+			output: &ConfigStorage{ID: "test", Enable: true, Type: "nfs",
+				NFS:     &ConfigStorageNFS{Server: "10.0.0.1", Export: "/export", Preallocation: PointerString("metadata"), Version: PointerString("4.2"), Options: []string{"soft", "timeo=30"}},
+				Content: &ConfigStorageContent{Backup: PointerBool(false), Container: PointerBool(false), DiskImage: PointerBool(true), Iso: PointerBool(false), Snippets: PointerBool(false), Template: PointerBool(false)}}},
+		{input: map[string]interface{}{"content": "iso", "domain": "example", "preallocation": "metadata", "server": "10.0.0.1", "share": "share", "smbversion": "3.0", "type": "cifs", "username": "user"},
+			output: &ConfigStorage{ID: "test", Enable: true, Type: "smb",
+				SMB:     &ConfigStorageSMB{Username: "user", Share: "share", Preallocation: PointerString("metadata"), Domain: "example", Server: "10.0.0.1", Version: PointerString("3.0")},
+				Content: &ConfigStorageContent{Backup: PointerBool(false), Container: PointerBool(false), DiskImage: PointerBool(false), Iso: PointerBool(true), Snippets: PointerBool(false), Template: PointerBool(false)}}},
+		{input: map[string]interface{}{"content": "rootdir,images", "disable": float64(1), "thinpool": "data", "type": "lvmthin", "vgname": "pve"},
+			output: &ConfigStorage{ID: "test", Enable: false, Type: "lvm-thin",
+				LVMThin: &ConfigStorageLVMThin{VGname: "pve", Thinpool: "data"},
+				Content: &ConfigStorageContent{Container: PointerBool(true), DiskImage: PointerBool(true)}}},
+		{input: map[string]interface{}{"blocksize": "16k", "content": "images", "nodes": "pve1,pve2", "pool": "rpool/data", "sparse": float64(1), "type": "zfspool"},
+			output: &ConfigStorage{ID: "test", Enable: true, Nodes: []string{"pve1", "pve2"}, Type: "zfs",
+				ZFS:     &ConfigStorageZFS{Pool: "rpool/data", Blocksize: PointerString("16k"), Thinprovision: true},
+				Content: &ConfigStorageContent{Container: PointerBool(false), DiskImage: PointerBool(true)}}},
+		{input: map[string]interface{}{"content": "backup", "datastore": "store", "fingerprint": "aa:bb", "namespace": "prod", "port": float64(8008), "server": "10.0.0.2", "type": "pbs", "username": "root@pam"},
+			output: &ConfigStorage{ID: "test", Enable: true, Type: "pbs",
+				PBS:     &ConfigStoragePBS{Server: "10.0.0.2", Datastore: "store", Username: "root@pam", Fingerprint: "aa:bb", Port: PointerInt(8008), Namespace: "prod"},
+				Content: &ConfigStorageContent{Backup: PointerBool(true)}}},
+	}
+	for _, test := range tests {
+		config := ConfigStorage{}.mapToStruct("test", test.input)
+		require.Equal(t, test.output, config, test.input["type"])
+		params := config.mapToApiValues(true)
+		for key, value := range test.input {
+			if key == "disable" || key == "port" || key == "shared" || key == "sparse" {
+				// booleans and numbers are sent with their native type
+				continue
+			}
+			require.Equal(t, value, params[key], key)
+		}
+	}
+}
+
+func Test_validatePbsNamespace(t *testing.T) {
+	for _, namespace := range []string{"", "/", "prod", "/prod/db", "a/b/c/d/e/f/g", "team_1/host-2.example"} {
+		require.NoError(t, validatePbsNamespace(namespace), namespace)
+	}
+	for _, namespace := range []string{"a/b/c/d/e/f/g/h", "prod//db", "-prod", "prod/d b"} {
+		require.Error(t, validatePbsNamespace(namespace), namespace)
+	}
+}
+
+func Test_ConfigStoragePBS_EncryptionKey(t *testing.T) {
+	config := ConfigStorage{ID: "test", Enable: true, Type: "pbs", PBS: &ConfigStoragePBS{Server: "10.0.0.2", Datastore: "store", Username: "root@pam", Namespace: "/prod/db", EncryptionKey: PointerString("autogen")}}
+	params := config.mapToApiValues(true)
+	require.Equal(t, "autogen", params["encryption-key"])
+	require.Equal(t, "prod/db", params["namespace"])
+
+	config = ConfigStorage{ID: "test", Enable: true, Type: "pbs", PBS: &ConfigStoragePBS{Username: "root@pam", Fingerprint: "aa:bb", EncryptionKey: PointerString("")}}
+	params = config.mapToApiValues(false)
+	_, isSet := params["encryption-key"]
+	require.False(t, isSet)
+	require.Equal(t, "encryption-key", params["delete"])
+
+	parsed := ConfigStorage{}.mapToStruct("test", map[string]interface{}{"content": "backup", "datastore": "store", "encryption-key": "cc:dd", "server": "10.0.0.2", "type": "pbs", "username": "root@pam"})
+	require.Equal(t, "cc:dd", parsed.PBS.EncryptionKeyFingerprint)
+	require.Nil(t, parsed.PBS.EncryptionKey)
+}
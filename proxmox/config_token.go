@@ -0,0 +1,143 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var rxTokenName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9.\-_]+$`)
+
+// API token of a user, used as username@realm!name together with its secret.
+type ApiToken struct {
+	User    UserID `json:"user"`
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+	// Unix epoch after which the token stops working, 0 means the token never expires.
+	Expire uint `json:"expire"`
+	// When enabled the token only has the permissions granted to the token itself, nil uses the proxmox default (enabled).
+	PrivilegeSeparation *bool `json:"privsep,omitempty"`
+}
+
+// Returns the full token ID in the format username@realm!name.
+func (token ApiToken) ID() string {
+	return token.User.ToString() + "!" + token.Name
+}
+
+func (token ApiToken) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{
+		"comment": token.Comment,
+		"expire":  token.Expire,
+	}
+	if token.PrivilegeSeparation != nil {
+		params["privsep"] = *token.PrivilegeSeparation
+	}
+	return params
+}
+
+func (token ApiToken) mapToStruct(params map[string]interface{}) *ApiToken {
+	if _, isSet := params["tokenid"]; isSet {
+		token.Name = params["tokenid"].(string)
+	}
+	if _, isSet := params["comment"]; isSet {
+		token.Comment = params["comment"].(string)
+	}
+	if _, isSet := params["expire"]; isSet {
+		token.Expire = uint(params["expire"].(float64))
+	}
+	if _, isSet := params["privsep"]; isSet {
+		token.PrivilegeSeparation = PointerBool(Itob(int(params["privsep"].(float64))))
+	}
+	return &token
+}
+
+func (token ApiToken) url() string {
+	return "/access/users/" + token.User.ToString() + "/token/" + token.Name
+}
+
+func (token ApiToken) Validate() error {
+	if err := token.User.Validate(); err != nil {
+		return err
+	}
+	if !rxTokenName.MatchString(token.Name) {
+		return fmt.Errorf("token name (%s) must start with a letter and may only contain letters, digits, '.', '-' and '_'", token.Name)
+	}
+	return nil
+}
+
+// Secret of a newly created API token, proxmox only returns it once.
+type ApiTokenSecret struct {
+	// Full token ID in the format username@realm!name
+	ID    string `json:"full-tokenid"`
+	Value string `json:"value"`
+}
+
+// CreateToken creates the API token and returns its secret, which can not be retrieved afterwards.
+func (c *Client) CreateToken(ctx context.Context, token ApiToken) (*ApiTokenSecret, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := token.Validate(); err != nil {
+		return nil, err
+	}
+	reqbody := ParamsToBody(token.mapToApiValues())
+	resp, err := c.session.Post(ctx, token.url(), nil, nil, &reqbody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token: %v", err)
+	}
+	response, err := ResponseJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := response["data"].(map[string]interface{})
+	secret := ApiTokenSecret{}
+	secret.ID, _ = data["full-tokenid"].(string)
+	secret.Value, _ = data["value"].(string)
+	if secret.Value == "" {
+		return nil, errors.New("token was created but proxmox did not return its secret")
+	}
+	return &secret, nil
+}
+
+// UpdateToken changes the comment, expiration and privilege separation of the API token, the secret is kept.
+func (c *Client) UpdateToken(ctx context.Context, token ApiToken) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := token.Validate(); err != nil {
+		return err
+	}
+	return c.Put(ctx, token.mapToApiValues(), token.url())
+}
+
+// DeleteToken revokes the API token.
+func (c *Client) DeleteToken(ctx context.Context, user UserID, name string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	token := ApiToken{User: user, Name: name}
+	if err := token.Validate(); err != nil {
+		return err
+	}
+	return c.Delete(ctx, token.url())
+}
+
+// ListTokens returns the API tokens of the user, without their secrets.
+func (c *Client) ListTokens(ctx context.Context, user UserID) ([]ApiToken, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/access/users/"+user.ToString()+"/token")
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]ApiToken, len(list))
+	for i, e := range list {
+		tokens[i] = *ApiToken{User: user}.mapToStruct(e.(map[string]interface{}))
+	}
+	return tokens, nil
+}
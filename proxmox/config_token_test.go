@@ -0,0 +1,37 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ApiToken_ID(t *testing.T) {
+	require.Equal(t, "ci@pve!deploy", ApiToken{User: UserID{Name: "ci", Realm: "pve"}, Name: "deploy"}.ID())
+}
+
+func Test_ApiToken_mapToApiValues(t *testing.T) {
+	require.Equal(t, map[string]interface{}{"comment": "", "expire": uint(0)}, ApiToken{}.mapToApiValues())
+	require.Equal(t, map[string]interface{}{"comment": "deploy", "expire": uint(1700000000), "privsep": false},
+		ApiToken{Comment: "deploy", Expire: 1700000000, PrivilegeSeparation: PointerBool(false)}.mapToApiValues())
+}
+
+func Test_ApiToken_mapToStruct(t *testing.T) {
+	user := UserID{Name: "ci", Realm: "pve"}
+	require.Equal(t, &ApiToken{User: user, Name: "deploy", Comment: "ci", Expire: 1700000000, PrivilegeSeparation: PointerBool(true)},
+		ApiToken{User: user}.mapToStruct(map[string]interface{}{
+			"tokenid": "deploy",
+			"comment": "ci",
+			"expire":  float64(1700000000),
+			"privsep": float64(1),
+		}))
+}
+
+func Test_ApiToken_Validate(t *testing.T) {
+	user := UserID{Name: "ci", Realm: "pve"}
+	require.NoError(t, ApiToken{User: user, Name: "deploy-1.a_b"}.Validate())
+	require.Error(t, ApiToken{Name: "deploy"}.Validate())
+	require.Error(t, ApiToken{User: user}.Validate())
+	require.Error(t, ApiToken{User: user, Name: "1deploy"}.Validate())
+	require.Error(t, ApiToken{User: user, Name: "de!ploy"}.Validate())
+}
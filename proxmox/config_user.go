@@ -5,19 +5,27 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"unicode/utf8"
 )
 
 const Error_NewUserID string = "no username or realm specified, syntax is \"username@realm\""
 
+const userIdMaxLength = 64
+
+var (
+	rxUserName  = regexp.MustCompile(`^[^\s:/@]+$`)
+	rxRealmName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9.\-_]+$`)
+)
+
 // User options for the Proxmox API
 type ConfigUser struct {
 	User      UserID       `json:"user"`
 	Comment   string       `json:"comment,omitempty"`
 	Email     string       `json:"email,omitempty"`
 	Enable    bool         `json:"enable"`
-	Expire    uint         `json:"expire"`
+	Expire    uint         `json:"expire"` // Unix epoch after which the user is disabled, 0 means the user never expires.
 	FirstName string       `json:"firstname,omitempty"`
 	Groups    *[]GroupName `json:"groups,omitempty"`
 	Keys      string       `json:"keys,omitempty"`
@@ -173,6 +181,20 @@ func (config ConfigUser) UpdateUserPassword(ctx context.Context, client *Client)
 	}, "/access/password")
 }
 
+// SetUserPassword changes the password of the user, only works for users in the pve and pam realms.
+func (c *Client) SetUserPassword(ctx context.Context, userId UserID, password UserPassword) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := userId.Validate(); err != nil {
+		return err
+	}
+	if password == "" {
+		return errors.New("the password may not be empty")
+	}
+	return ConfigUser{User: userId, Password: password}.UpdateUserPassword(ctx, c)
+}
+
 // Validates all items and sub items in the ConfigUser struct
 func (config ConfigUser) Validate() (err error) {
 	err = config.User.Validate()
@@ -269,6 +291,15 @@ func (id UserID) Validate() error {
 	if id.Realm == "" {
 		return errors.New("no realm is specified")
 	}
+	if !rxUserName.MatchString(id.Name) {
+		return errors.New("username may not contain whitespace, '@', ':' or '/'")
+	}
+	if !rxRealmName.MatchString(id.Realm) {
+		return errors.New("realm must start with a letter and may only contain letters, digits, '.', '-' and '_'")
+	}
+	if utf8.RuneCountInString(id.ToString()) > userIdMaxLength {
+		return fmt.Errorf("user id may not be longer than %d characters", userIdMaxLength)
+	}
 	return nil
 }
 
@@ -2,6 +2,7 @@ package proxmox
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/perimeter-81/proxmox-api-go/test/data/test_data_group"
@@ -518,6 +519,27 @@ func Test_UserID_Validate(t *testing.T) {
 			err:   true,
 		},
 		{input: UserID{Name: "username", Realm: "pam"}},
+		{input: UserID{Name: "first.last-1_a", Realm: "ldap-corp.example"}},
+		{
+			input: UserID{Name: "user name", Realm: "pam"},
+			err:   true,
+		},
+		{
+			input: UserID{Name: "user:name", Realm: "pam"},
+			err:   true,
+		},
+		{
+			input: UserID{Name: "username", Realm: "1pam"},
+			err:   true,
+		},
+		{
+			input: UserID{Name: "username", Realm: "p"},
+			err:   true,
+		},
+		{
+			input: UserID{Name: strings.Repeat("a", 61), Realm: "pam"},
+			err:   true,
+		},
 	}
 	for _, e := range testData {
 		if e.err {
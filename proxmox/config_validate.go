@@ -0,0 +1,250 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Problem with a single field of a config, Field is the name of the field in the json of the config.
+type FieldError struct {
+	Field string `json:"field"`
+	Err   error  `json:"-"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// All problems found in a config, returned by the Validate methods of ConfigQemu and ConfigLxc.
+type FieldErrors []FieldError
+
+func (errs FieldErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (errs *FieldErrors) add(field string, err error) {
+	if err != nil {
+		*errs = append(*errs, FieldError{Field: field, Err: err})
+	}
+}
+
+// Returns nil when there are no errors, so the result can be compared against nil.
+func (errs FieldErrors) errorOrNil() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+const (
+	qemuMinMemory = 16
+	vmIdMin       = 100
+	vmIdMax       = 999999999
+)
+
+var (
+	qemuNicModels = []string{"e1000", "e1000-82540em", "e1000-82544gc", "e1000-82545em", "e1000e", "i82551", "i82557b", "i82559er", "ne2k_isa", "ne2k_pci", "pcnet", "rtl8139", "virtio", "vmxnet3"}
+	// highest disk number of every bus
+	qemuDiskBuses = map[string]int{"ide": 3, "sata": 5, "scsi": 30, "virtio": 15}
+	rxDiskSize    = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[KMGT]?B?$`)
+)
+
+func validateVmId(id int) error {
+	if id != 0 && (id < vmIdMin || id > vmIdMax) {
+		return fmt.Errorf("must be between %d and %d", vmIdMin, vmIdMax)
+	}
+	return nil
+}
+
+func validateTags(tags []string) error {
+	for _, tag := range tags {
+		if err := validateTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateQemuNic(nic QemuDevice) error {
+	model, _ := nic["model"].(string)
+	if !inArray(qemuNicModels, model) {
+		return errors.New("model must be one of (" + strings.Join(qemuNicModels, ",") + ")")
+	}
+	if bridge, _ := nic["bridge"].(string); bridge == "" {
+		return errors.New("bridge may not be empty, use (nat) for user networking")
+	}
+	switch mac := nic["macaddr"].(type) {
+	case nil:
+	case string:
+		if mac != "" && mac != "repeatable" {
+			if _, err := net.ParseMAC(mac); err != nil {
+				return fmt.Errorf("macaddr (%s) is not a valid mac address", mac)
+			}
+		}
+	default:
+		return errors.New("macaddr must be a string")
+	}
+	_, err := qemuNicQueuesAndRate(nic)
+	return err
+}
+
+func validateQemuDisk(id int, disk QemuDevice) error {
+	diskType, _ := disk["type"].(string)
+	max, isBus := qemuDiskBuses[diskType]
+	if !isBus {
+		return errors.New("type must be one of (ide,sata,scsi,virtio)")
+	}
+	if id < 0 || id > max {
+		return fmt.Errorf("%s disks are numbered 0 to %d", diskType, max)
+	}
+	if volume, _ := disk["volume"].(string); volume != "" {
+		return nil
+	}
+	if storage, _ := disk["storage"].(string); storage == "" {
+		return errors.New("storage may not be empty for a new disk")
+	}
+	if importFrom, isSet := disk["import_from"]; isSet && importFrom != "" {
+		source, _ := importFrom.(string)
+		return validateImportSource(source)
+	}
+	switch size := disk["size"].(type) {
+	case float64:
+		if size <= 0 {
+			return errors.New("size must be larger than 0")
+		}
+	case string:
+		if !rxDiskSize.MatchString(strings.ToUpper(size)) {
+			return fmt.Errorf("size (%s) must be a number with an optional unit (K,M,G,T)", size)
+		}
+	default:
+		return errors.New("size is required for a new disk")
+	}
+	return nil
+}
+
+// Validate checks the config without contacting Proxmox and returns all problems that were found as FieldErrors.
+// Returns nil when the config is valid.
+func (config ConfigQemu) Validate() error {
+	errs := FieldErrors{}
+	errs.add("vmid", validateVmId(config.VmID))
+	if config.Memory != 0 && config.Memory < qemuMinMemory {
+		errs.add("memory", fmt.Errorf("must be at least %d MiB", qemuMinMemory))
+	}
+	if config.Balloon != nil {
+		if *config.Balloon < 0 {
+			errs.add("balloon", errors.New("may not be negative"))
+		} else if config.Memory != 0 && *config.Balloon > config.Memory {
+			errs.add("balloon", errors.New("may not be larger than memory"))
+		}
+	}
+	if config.QemuCores < 0 {
+		errs.add("cores", errors.New("may not be negative"))
+	}
+	if config.QemuSockets < 0 {
+		errs.add("sockets", errors.New("may not be negative"))
+	}
+	if config.QemuVcpus < 0 {
+		errs.add("vcpus", errors.New("may not be negative"))
+	} else if config.QemuVcpus > 0 && config.QemuCores > 0 {
+		sockets := config.QemuSockets
+		if sockets == 0 {
+			sockets = 1
+		}
+		if config.QemuVcpus > config.QemuCores*sockets {
+			errs.add("vcpus", errors.New("may not be larger than cores times sockets"))
+		}
+	}
+	if config.Agent != 0 && config.Agent != 1 {
+		errs.add("agent", errors.New("must be 0 or 1"))
+	}
+	errs.add("machine", config.CreateQemuMachineParam(map[string]interface{}{}))
+	errs.add("tags", validateTags(config.TagList()))
+	errs.add("boot", config.ValidateBootOrder())
+	if startup := config.StartupSettings(); startup != nil {
+		errs.add("startup", startup.Validate())
+	}
+	if config.Rng != nil {
+		errs.add("rng", config.Rng.Validate())
+	}
+	if config.Watchdog != nil {
+		errs.add("watchdog", config.Watchdog.Validate())
+	}
+	if config.Audio != nil {
+		errs.add("audio", config.Audio.Validate())
+	}
+	if config.SpiceEnhancements != nil {
+		errs.add("spice_enhancements", config.SpiceEnhancements.Validate())
+	}
+	_, err := createQemuVgaParam(config.QemuVga)
+	errs.add("vga", err)
+	errs.add("serials", config.CreateQemuPortsParams(map[string]interface{}{}))
+	for id, nic := range config.QemuNetworks {
+		errs.add("network["+strconv.Itoa(id)+"]", validateQemuNic(nic))
+	}
+	for id, disk := range config.QemuDisks {
+		errs.add("disk["+strconv.Itoa(id)+"]", validateQemuDisk(id, disk))
+		if disk["type"] == "ide" && id == 2 && config.QemuIso != "" {
+			errs.add("iso", errors.New("can not be used together with disk ide2, the iso is attached as ide2"))
+		}
+	}
+	errs.add("ipconfigs", validateQemuIPConfigs(config.IPConfigs))
+	errs.add("nameservers", validateQemuNameservers(config.Nameservers))
+	errs.add("searchdomains", validateQemuSearchDomains(config.SearchDomains))
+	for id := range config.Ipconfig {
+		if id < 0 || id > 15 {
+			errs.add("ipconfig["+strconv.Itoa(id)+"]", errors.New("only ipconfig0 to ipconfig15 are supported"))
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// Validate checks the config without contacting Proxmox and returns all problems that were found as FieldErrors.
+// Returns nil when the config is valid. Cpuunits are validated against the range of both cgroup versions,
+// as the version of Proxmox is unknown.
+func (config ConfigLxc) Validate() error {
+	errs := FieldErrors{}
+	if config.Ostemplate == "" && config.Clone == "" {
+		errs.add("ostemplate", errors.New("may not be empty unless the container is cloned"))
+	}
+	if config.Ostemplate != "" && config.Clone != "" {
+		errs.add("clone", errors.New("can not be used together with ostemplate"))
+	}
+	if config.Arch != "" && !inArray([]string{"amd64", "i386", "arm64", "armhf", "riscv32", "riscv64"}, config.Arch) {
+		errs.add("arch", errors.New("must be one of (amd64,i386,arm64,armhf,riscv32,riscv64)"))
+	}
+	if config.BWLimit < 0 {
+		errs.add("bwlimit", errors.New("may not be negative"))
+	}
+	errs.add("resources", config.validateResources(0))
+	errs.add("console", config.validateConsole())
+	errs.add("tags", validateTags(config.TagList()))
+	if config.RootFs != nil {
+		errs.add("rootfs", config.RootFs.Validate())
+	}
+	if startup := config.StartupSettings(); startup != nil {
+		errs.add("startup", startup.Validate())
+	}
+	errs.add("mountpoints", validateLxcMountPoints(config.Mountpoints))
+	errs.add("devices", validateLxcDevices(config.Devices))
+	if config.Features != nil {
+		errs.add("features", config.Features.Validate(config.Unprivileged))
+	}
+	for id, nic := range config.Networks {
+		if name, _ := nic["name"].(string); name == "" {
+			errs.add("networks["+strconv.Itoa(id)+"]", errors.New("name may not be empty"))
+		}
+	}
+	return errs.errorOrNil()
+}
@@ -0,0 +1,72 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func fieldErrorNames(t *testing.T, err error) []string {
+	var errs FieldErrors
+	require.True(t, errors.As(err, &errs))
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	return fields
+}
+
+func Test_ConfigQemu_Validate(t *testing.T) {
+	config := ConfigQemu{
+		Name:         "test",
+		Memory:       2048,
+		Balloon:      PointerInt(1024),
+		QemuCores:    2,
+		QemuSockets:  1,
+		QemuNetworks: QemuDevices{0: {"model": "virtio", "bridge": "vmbr0", "macaddr": "repeatable"}},
+		QemuDisks:    QemuDevices{0: {"type": "scsi", "slot": 0, "storage": "local-lvm", "size": "8G"}},
+	}
+	require.NoError(t, config.Validate())
+	// the size of an imported disk is taken from the image
+	config.QemuDisks[1] = QemuDevice{"type": "scsi", "storage": "local-lvm", "import_from": "local:import/debian.qcow2"}
+	require.NoError(t, config.Validate())
+	config.QemuDisks[1]["import_from"] = "debian.qcow2"
+	require.Equal(t, []string{"disk[1]"}, fieldErrorNames(t, config.Validate()))
+	delete(config.QemuDisks, 1)
+	config.QemuNetworks[1] = QemuDevice{"model": "e1000-82545em", "bridge": "vmbr0"}
+	config.QemuNetworks[2] = QemuDevice{"model": "ne2k_pci", "bridge": "vmbr0"}
+	// an iso of none is an empty cdrom, which is not a bootable disk
+	config.QemuIso = "none"
+	config.Boot = "order=ide2;net0"
+	require.Equal(t, []string{"boot"}, fieldErrorNames(t, config.Validate()))
+	config.Boot = "order=ide2;scsi0"
+	require.NoError(t, config.Validate())
+
+	invalid := ConfigQemu{
+		VmID:         1,
+		Memory:       512,
+		Balloon:      PointerInt(1024),
+		QemuCores:    2,
+		QemuVcpus:    4,
+		Machine:      "virt",
+		Tags:         "-tag",
+		QemuIso:      "local:iso/test.iso",
+		QemuNetworks: QemuDevices{0: {"model": "ne2k", "bridge": "vmbr0"}},
+		QemuDisks:    QemuDevices{2: {"type": "ide", "storage": "local-lvm", "size": "big"}},
+	}
+	require.ElementsMatch(t, []string{"vmid", "balloon", "vcpus", "machine", "tags", "network[0]", "disk[2]", "iso"}, fieldErrorNames(t, invalid.Validate()))
+}
+
+func Test_ConfigLxc_Validate(t *testing.T) {
+	config := NewConfigLxc()
+	config.Ostemplate = "local:vztmpl/debian.tar.zst"
+	config.Networks = QemuDevices{0: {"name": "eth0", "bridge": "vmbr0"}}
+	require.NoError(t, config.Validate())
+
+	config.Ostemplate = ""
+	config.Tty = 7
+	config.Swap = -1
+	config.Networks[0]["name"] = ""
+	require.ElementsMatch(t, []string{"ostemplate", "resources", "console", "networks[0]"}, fieldErrorNames(t, config.Validate()))
+}
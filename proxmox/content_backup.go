@@ -0,0 +1,46 @@
+package proxmox
+
+import (
+	"context"
+)
+
+// GetBackupNotes returns the notes of the backup volume on the node.
+func (c *Client) GetBackupNotes(ctx context.Context, node, volid string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	volumeUrl, err := storageVolumeUrl(node, volid)
+	if err != nil {
+		return "", err
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, volumeUrl, "backup", "CONFIG")
+	if err != nil {
+		return "", err
+	}
+	notes, _ := params["notes"].(string)
+	return notes, nil
+}
+
+// SetBackupNotes replaces the notes of the backup volume on the node, empty notes remove them.
+func (c *Client) SetBackupNotes(ctx context.Context, node, volid, notes string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	volumeUrl, err := storageVolumeUrl(node, volid)
+	if err != nil {
+		return err
+	}
+	return c.Put(ctx, map[string]interface{}{"notes": notes}, volumeUrl)
+}
+
+// SetBackupProtected protects the backup volume on the node against removal and pruning.
+func (c *Client) SetBackupProtected(ctx context.Context, node, volid string, protected bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	volumeUrl, err := storageVolumeUrl(node, volid)
+	if err != nil {
+		return err
+	}
+	return c.Put(ctx, map[string]interface{}{"protected": protected}, volumeUrl)
+}
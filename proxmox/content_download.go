@@ -0,0 +1,77 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Options of DownloadURLToStorage.
+type DownloadUrlOptions struct {
+	// Name of the file on the storage.
+	Filename string
+	// ContentType_Iso or ContentType_Template, defaults to ContentType_Iso.
+	ContentType ContentType
+	// Proxmox verifies the downloaded file against the checksum, both Checksum and ChecksumAlgorithm have to be set.
+	Checksum          string
+	ChecksumAlgorithm string
+	// nil uses the default of Proxmox, which is to verify the TLS certificate of the URL.
+	VerifyCertificates *bool
+}
+
+func (opts DownloadUrlOptions) mapToApiValues(url string) map[string]interface{} {
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = ContentType_Iso
+	}
+	params := map[string]interface{}{
+		"content":  string(contentType.toApiValue()),
+		"filename": opts.Filename,
+		"url":      url,
+	}
+	if opts.Checksum != "" {
+		params["checksum"] = opts.Checksum
+		params["checksum-algorithm"] = opts.ChecksumAlgorithm
+	}
+	if opts.VerifyCertificates != nil {
+		params["verify-certificates"] = *opts.VerifyCertificates
+	}
+	return params
+}
+
+func (opts DownloadUrlOptions) Validate(url string) error {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("url (%s) must be an http or https URL", url)
+	}
+	if opts.Filename == "" {
+		return errors.New("filename may not be empty")
+	}
+	if opts.ContentType != "" && !inArray([]string{string(contentType_Iso_ApiValue), string(contentType_Template_ApiValue)}, string(opts.ContentType.toApiValue())) {
+		return errors.New("content type must be one of (" + string(ContentType_Iso) + "," + string(ContentType_Template) + ")")
+	}
+	return UploadOptions{Checksum: opts.Checksum, ChecksumAlgorithm: opts.ChecksumAlgorithm}.Validate()
+}
+
+// DownloadURLToStorage lets the node download the ISO image or container template from the URL to the storage.
+// Requires Proxmox 7.2 or later. Returns the UPID of the download task.
+func (c *Client) DownloadURLToStorage(ctx context.Context, node, storage, url string, opts DownloadUrlOptions) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = opts.Validate(url); err != nil {
+		return
+	}
+	reqbody := ParamsToBody(opts.mapToApiValues(url))
+	resp, err := c.session.Post(ctx, "/nodes/"+node+"/storage/"+storage+"/download-url", nil, nil, &reqbody)
+	if err != nil {
+		return "", fmt.Errorf("error downloading url: %v, error status: %s", err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
@@ -0,0 +1,38 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DownloadUrlOptions_mapToApiValues(t *testing.T) {
+	disabled := false
+	require.Equal(t, "content=iso&filename=debian.iso&url=https%3A%2F%2Fexample.com%2Fdebian.iso",
+		ParamsToValues(DownloadUrlOptions{Filename: "debian.iso"}.mapToApiValues("https://example.com/debian.iso")).Encode())
+	require.Equal(t, "checksum=abc&checksum-algorithm=sha256&content=vztmpl&filename=debian.tar.zst&url=https%3A%2F%2Fexample.com%2Fdebian.tar.zst&verify-certificates=0",
+		ParamsToValues(DownloadUrlOptions{Filename: "debian.tar.zst", ContentType: ContentType_Template, Checksum: "abc", ChecksumAlgorithm: "sha256", VerifyCertificates: &disabled}.mapToApiValues("https://example.com/debian.tar.zst")).Encode())
+}
+
+func Test_DownloadUrlOptions_Validate(t *testing.T) {
+	tests := []struct {
+		input DownloadUrlOptions
+		url   string
+		err   bool
+	}{
+		{input: DownloadUrlOptions{Filename: "debian.iso"}, url: "https://example.com/debian.iso"},
+		{input: DownloadUrlOptions{Filename: "debian.tar.zst", ContentType: ContentType_Template}, url: "http://example.com/debian.tar.zst"},
+		{input: DownloadUrlOptions{Filename: "debian.iso", Checksum: "abc", ChecksumAlgorithm: "sha256"}, url: "https://example.com/debian.iso"},
+		{input: DownloadUrlOptions{Filename: "debian.iso"}, url: "ftp://example.com/debian.iso", err: true},
+		{input: DownloadUrlOptions{}, url: "https://example.com/debian.iso", err: true},
+		{input: DownloadUrlOptions{Filename: "debian.iso", ContentType: ContentType_Backup}, url: "https://example.com/debian.iso", err: true},
+		{input: DownloadUrlOptions{Filename: "debian.iso", Checksum: "abc"}, url: "https://example.com/debian.iso", err: true},
+	}
+	for _, test := range tests {
+		if test.err {
+			require.Error(t, test.input.Validate(test.url), test.input)
+		} else {
+			require.NoError(t, test.input.Validate(test.url), test.input)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const Error_VolumeInUse string = "volume is still in use by a guest, detach it first"
+
+// Volume on a storage as listed by the storage content endpoint.
+type StorageContent struct {
+	VolID string `json:"volid"`
+	// One of the ContentType enum values.
+	Content      ContentType `json:"content"`
+	CreationTime time.Time   `json:"ctime"`
+	Format       string      `json:"format"`
+	Notes        string      `json:"notes,omitempty"`
+	Protected    bool        `json:"protected,omitempty"`
+	Size         uint        `json:"size"`
+	// Used space in bytes, only reported for disk images.
+	Used uint `json:"used,omitempty"`
+	// ID of the guest the volume belongs to, 0 when it does not belong to a guest.
+	VmID uint `json:"vmid,omitempty"`
+}
+
+// Converts the value the proxmox api returns to the user friendly enum value.
+func contentTypeFromApiValue(content string) ContentType {
+	switch ContentType(content) {
+	case contentType_Container_ApiValue:
+		return ContentType_Container
+	case contentType_DiskImage_ApiValue:
+		return ContentType_DiskImage
+	case contentType_Template_ApiValue:
+		return ContentType_Template
+	}
+	return ContentType(content)
+}
+
+func createStorageContentList(contentList []interface{}) []StorageContent {
+	volumes := make([]StorageContent, len(contentList))
+	for i := range contentList {
+		itemMap := contentList[i].(map[string]interface{})
+		volume := StorageContent{}
+		if _, isSet := itemMap["volid"]; isSet {
+			volume.VolID = itemMap["volid"].(string)
+		}
+		if _, isSet := itemMap["content"]; isSet {
+			volume.Content = contentTypeFromApiValue(itemMap["content"].(string))
+		}
+		if _, isSet := itemMap["ctime"]; isSet {
+			volume.CreationTime = time.Unix(int64(itemMap["ctime"].(float64)), 0)
+		}
+		if _, isSet := itemMap["format"]; isSet {
+			volume.Format = itemMap["format"].(string)
+		}
+		if _, isSet := itemMap["notes"]; isSet {
+			volume.Notes = itemMap["notes"].(string)
+		}
+		if _, isSet := itemMap["protected"]; isSet {
+			volume.Protected = Itob(int(itemMap["protected"].(float64)))
+		}
+		if _, isSet := itemMap["size"]; isSet {
+			volume.Size = uint(itemMap["size"].(float64))
+		}
+		if _, isSet := itemMap["used"]; isSet {
+			volume.Used = uint(itemMap["used"].(float64))
+		}
+		if _, isSet := itemMap["vmid"]; isSet {
+			volume.VmID = uint(itemMap["vmid"].(float64))
+		}
+		volumes[i] = volume
+	}
+	return volumes
+}
+
+// ListStorageContent lists the volumes on the storage of the node.
+// When contentType is empty volumes of all types are listed, otherwise only volumes of that type.
+func (c *Client) ListStorageContent(ctx context.Context, node, storage string, contentType ContentType) ([]StorageContent, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	url := "/nodes/" + node + "/storage/" + storage + "/content"
+	if contentType != "" {
+		apiContentType, err := contentType.toApiValueAndValidate()
+		if err != nil {
+			return nil, err
+		}
+		url += "?content=" + string(apiContentType)
+	}
+	contentList, err := c.GetItemListInterfaceArray(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return createStorageContentList(contentList), nil
+}
+
+// Url of the volume (e.g. "local:backup/vzdump-qemu-100-2023_01_01-00_00_00.vma.zst") on its storage.
+func storageVolumeUrl(node, volid string) (string, error) {
+	storage, _, found := strings.Cut(volid, ":")
+	if !found {
+		return "", errors.New("volume id must be in the format storage:volume")
+	}
+	return "/nodes/" + node + "/storage/" + storage + "/content/" + url.PathEscape(volid), nil
+}
+
+// Proxmox refuses to delete a volume that is referenced by the config of a guest.
+func volumeInUse(status string) bool {
+	status = strings.ToLower(status)
+	return strings.Contains(status, "in use") || strings.Contains(status, "used by")
+}
+
+// DeleteStorageVolume deletes the volume (e.g. "local-lvm:vm-100-disk-0") from its storage on the node.
+// Unlike DeleteVolume it does not require a guest, so it can be used for orphaned volumes.
+// Returns Error_VolumeInUse when the volume is still used by a guest.
+func (c *Client) DeleteStorageVolume(ctx context.Context, node, volid string) (exitStatus string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	volumeUrl, err := storageVolumeUrl(node, volid)
+	if err != nil {
+		return
+	}
+	resp, err := c.session.Delete(ctx, volumeUrl, nil, nil)
+	if err != nil {
+		exitStatus = c.HandleTaskError(resp)
+		if volumeInUse(exitStatus) {
+			return exitStatus, errors.New(Error_VolumeInUse)
+		}
+		return
+	}
+	exitStatus, err = c.CheckTask(ctx, resp)
+	// a failed task is returned as the error
+	if err != nil && volumeInUse(err.Error()) {
+		return err.Error(), errors.New(Error_VolumeInUse)
+	}
+	return
+}
+
+// VolumeExists checks if the volume (e.g. "local-lvm:vm-100-disk-0") exists on its storage on the node.
+func (c *Client) VolumeExists(ctx context.Context, node, volid string) (bool, error) {
+	storage, _, found := strings.Cut(volid, ":")
+	if !found {
+		return false, errors.New("volume id must be in the format storage:volume")
+	}
+	volumes, err := c.ListStorageContent(ctx, node, storage, "")
+	if err != nil {
+		return false, err
+	}
+	return checkVolumeExistence(volid, volumes), nil
+}
+
+func checkVolumeExistence(volid string, volumes []StorageContent) bool {
+	for _, e := range volumes {
+		if e.VolID == volid {
+			return true
+		}
+	}
+	return false
+}
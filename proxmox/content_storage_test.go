@@ -0,0 +1,75 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_contentTypeFromApiValue(t *testing.T) {
+	require.Equal(t, ContentType_Backup, contentTypeFromApiValue("backup"))
+	require.Equal(t, ContentType_Container, contentTypeFromApiValue("rootdir"))
+	require.Equal(t, ContentType_DiskImage, contentTypeFromApiValue("images"))
+	require.Equal(t, ContentType_Iso, contentTypeFromApiValue("iso"))
+	require.Equal(t, ContentType_Snippets, contentTypeFromApiValue("snippets"))
+	require.Equal(t, ContentType_Template, contentTypeFromApiValue("vztmpl"))
+}
+
+func Test_createStorageContentList(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"content": "images",
+			"ctime":   float64(1671032208),
+			"format":  "raw",
+			"size":    float64(8589934592),
+			"used":    float64(1073741824),
+			"vmid":    float64(100),
+			"volid":   "local-lvm:vm-100-disk-0",
+		},
+		map[string]interface{}{
+			"content":   "backup",
+			"ctime":     float64(1671032191),
+			"format":    "tar.zst",
+			"notes":     "before upgrade",
+			"protected": float64(1),
+			"size":      float64(99098368),
+			"vmid":      float64(101),
+			"volid":     "local:backup/vzdump-lxc-101-2022_12_14-15_36_31.tar.zst",
+		},
+		map[string]interface{}{
+			"content": "iso",
+			"ctime":   float64(1665838226),
+			"format":  "iso",
+			"size":    float64(77551540),
+			"volid":   "local:iso/debian-11.iso",
+		},
+	}
+	output := []StorageContent{
+		{VolID: "local-lvm:vm-100-disk-0", Content: ContentType_DiskImage, CreationTime: time.Unix(1671032208, 0), Format: "raw", Size: 8589934592, Used: 1073741824, VmID: 100},
+		{VolID: "local:backup/vzdump-lxc-101-2022_12_14-15_36_31.tar.zst", Content: ContentType_Backup, CreationTime: time.Unix(1671032191, 0), Format: "tar.zst", Notes: "before upgrade", Protected: true, Size: 99098368, VmID: 101},
+		{VolID: "local:iso/debian-11.iso", Content: ContentType_Iso, CreationTime: time.Unix(1665838226, 0), Format: "iso", Size: 77551540},
+	}
+	require.Equal(t, output, createStorageContentList(input))
+}
+
+func Test_volumeInUse(t *testing.T) {
+	require.True(t, volumeInUse("unable to delete 'local-lvm:vm-100-disk-0' - volume is still in use (referenced by VM 100)"))
+	require.True(t, volumeInUse("volume 'local-lvm:vm-100-disk-0' is used by VM 100"))
+	require.False(t, volumeInUse("storage 'local-lvm' does not exist"))
+}
+
+func Test_checkVolumeExistence(t *testing.T) {
+	volumes := []StorageContent{{VolID: "local-lvm:vm-100-disk-0"}, {VolID: "local:iso/debian-11.iso"}}
+	require.True(t, checkVolumeExistence("local:iso/debian-11.iso", volumes))
+	require.False(t, checkVolumeExistence("local-lvm:vm-100-disk-1", volumes))
+	require.False(t, checkVolumeExistence("local-lvm:vm-100-disk-0", nil))
+}
+
+func Test_storageVolumeUrl(t *testing.T) {
+	volumeUrl, err := storageVolumeUrl("pve", "local:backup/vzdump-qemu-100-2023_01_01-00_00_00.vma.zst")
+	require.NoError(t, err)
+	require.Equal(t, "/nodes/pve/storage/local/content/local:backup%2Fvzdump-qemu-100-2023_01_01-00_00_00.vma.zst", volumeUrl)
+	_, err = storageVolumeUrl("pve", "vzdump-qemu-100.vma.zst")
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package proxmox
 import (
 	"context"
 	"errors"
+	"fmt"
 )
 
 type ConfigContent_Template struct {
@@ -121,3 +122,61 @@ func ListTemplates(ctx context.Context, client *Client, node string) (templateLi
 	templateList = createTemplateList(tmpList)
 	return
 }
+
+// Sections that templates are grouped by.
+const (
+	TemplateSection_Mail         string = "mail"
+	TemplateSection_System       string = "system"
+	TemplateSection_TurnKeyLinux string = "turnkeylinux"
+)
+
+// Returns the templates in the section, all templates when section is empty.
+func filterTemplateSection(templates []TemplateItem, section string) []TemplateItem {
+	if section == "" {
+		return templates
+	}
+	filtered := []TemplateItem{}
+	for _, e := range templates {
+		if e.Section == section {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// ListAvailableTemplates lists the LXC templates available for download in the section, like "pveam available --section".
+// All templates are listed when section is empty.
+func (c *Client) ListAvailableTemplates(ctx context.Context, node, section string) ([]TemplateItem, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	templates, err := ListTemplates(ctx, c, node)
+	if err != nil {
+		return nil, err
+	}
+	return filterTemplateSection(*templates, section), nil
+}
+
+// DownloadTemplate downloads the LXC template to the storage, like "pveam download".
+// Returns the UPID of the download task.
+func (c *Client) DownloadTemplate(ctx context.Context, node, storage, template string) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	content := ConfigContent_Template{Node: node, Storage: storage, Template: template}
+	if err = content.Validate(); err != nil {
+		return
+	}
+	reqbody := ParamsToBody(content.mapToApiValues())
+	resp, err := c.session.Post(ctx, "/nodes/"+node+"/aplinfo", nil, nil, &reqbody)
+	if err != nil {
+		return "", fmt.Errorf("error downloading template: %v, error status: %s", err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
@@ -164,3 +164,14 @@ func Test_createTemplateList(t *testing.T) {
 		require.Equal(t, e.Output, createTemplateList(e.Input))
 	}
 }
+
+func Test_filterTemplateSection(t *testing.T) {
+	templates := []TemplateItem{
+		{Template: "debian-12-standard", Section: TemplateSection_System},
+		{Template: "debian-12-turnkey-nextcloud", Section: TemplateSection_TurnKeyLinux},
+		{Template: "proxmox-mailgateway", Section: TemplateSection_Mail},
+	}
+	require.Equal(t, templates, filterTemplateSection(templates, ""))
+	require.Equal(t, []TemplateItem{templates[1]}, filterTemplateSection(templates, TemplateSection_TurnKeyLinux))
+	require.Equal(t, []TemplateItem{}, filterTemplateSection(templates, "other"))
+}
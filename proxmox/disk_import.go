@@ -0,0 +1,89 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Volume of a storage, e.g. "local:import/debian.qcow2" or "local-lvm:vm-100-disk-0".
+var rxStorageVolume = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9\-_.]*:[^\s,=]+$`)
+
+// The source is either an absolute path on the node or a volume of a storage.
+// Importing from a path is only allowed for root@pam.
+func validateImportSource(source string) error {
+	if strings.HasPrefix(source, "/") {
+		if strings.ContainsAny(source, ", =") {
+			return fmt.Errorf("import source (%s) may not contain commas, spaces or equal signs", source)
+		}
+		return nil
+	}
+	if !rxStorageVolume.MatchString(source) {
+		return fmt.Errorf("import source (%s) must be an absolute path on the node or a volume in the form storage:volume", source)
+	}
+	return nil
+}
+
+// Returns the first scsi disk that is not used in the guest config.
+func freeQemuScsiDisk(vmConfig map[string]interface{}) (string, error) {
+	for i := 0; i <= qemuDiskBuses["scsi"]; i++ {
+		disk := "scsi" + strconv.Itoa(i)
+		if _, isSet := vmConfig[disk]; !isSet {
+			return disk, nil
+		}
+	}
+	return "", errors.New("all scsi disks are in use")
+}
+
+// The size 0 makes Proxmox use the size of the imported image.
+func importDiskSetting(source, targetStorage, format string) string {
+	setting := targetStorage + ":0,import-from=" + source
+	if format != "" {
+		setting += ",format=" + format
+	}
+	return setting
+}
+
+// ImportDisk imports the image (raw, qcow2 or vmdk) as a new disk of the virtual machine, like "qm importdisk" does.
+// The source is either an absolute path on the node or a volume like "local:import/debian.qcow2" or "local-lvm:vm-100-disk-0".
+// The disk is attached as the first free scsi disk, when format is empty the default format of the storage is used.
+// Returns the name of the new disk (e.g. "scsi1") and the UPID of the import task.
+func (c *Client) ImportDisk(ctx context.Context, vmr *VmRef, source, targetStorage, format string) (disk, upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = validateImportSource(source); err != nil {
+		return
+	}
+	if err = ValidateStringNotEmpty(targetStorage, "target storage"); err != nil {
+		return
+	}
+	if format != "" && !inArray(qemuDiskFormats, format) {
+		return "", "", errors.New("disk format must be one of (" + strings.Join(qemuDiskFormats, ",") + ")")
+	}
+	vmConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return
+	}
+	if vmr.vmType != "qemu" {
+		return "", "", fmt.Errorf("guest %d is not a virtual machine", vmr.vmId)
+	}
+	if disk, err = freeQemuScsiDisk(vmConfig); err != nil {
+		return
+	}
+	reqbody := ParamsToBody(map[string]interface{}{disk: importDiskSetting(source, targetStorage, format)})
+	resp, err := c.session.Post(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/config", vmr.node, vmr.vmId), nil, nil, &reqbody)
+	if err != nil {
+		return "", "", fmt.Errorf("error importing disk: %v, error status: %s", err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
@@ -0,0 +1,35 @@
+package proxmox
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateImportSource(t *testing.T) {
+	require.NoError(t, validateImportSource("/var/lib/vz/images/debian.qcow2"))
+	require.NoError(t, validateImportSource("local:import/debian.qcow2"))
+	require.NoError(t, validateImportSource("local-lvm:vm-100-disk-0"))
+	require.Error(t, validateImportSource(""))
+	require.Error(t, validateImportSource("debian.qcow2"))
+	require.Error(t, validateImportSource("/tmp/a,b.qcow2"))
+	require.Error(t, validateImportSource("local:vm-100-disk-0,size=8G"))
+}
+
+func Test_freeQemuScsiDisk(t *testing.T) {
+	disk, err := freeQemuScsiDisk(map[string]interface{}{"scsi0": "local-lvm:vm-100-disk-0", "scsi2": "local-lvm:vm-100-disk-1", "ide2": "none,media=cdrom"})
+	require.NoError(t, err)
+	require.Equal(t, "scsi1", disk)
+	full := map[string]interface{}{}
+	for i := 0; i <= 30; i++ {
+		full["scsi"+strconv.Itoa(i)] = "local-lvm:vm-100-disk-" + strconv.Itoa(i)
+	}
+	_, err = freeQemuScsiDisk(full)
+	require.Error(t, err)
+}
+
+func Test_importDiskSetting(t *testing.T) {
+	require.Equal(t, "local-lvm:0,import-from=local:import/debian.qcow2", importDiskSetting("local:import/debian.qcow2", "local-lvm", ""))
+	require.Equal(t, "local:0,import-from=/root/debian.raw,format=qcow2", importDiskSetting("/root/debian.raw", "local", "qcow2"))
+}
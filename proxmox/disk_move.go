@@ -0,0 +1,114 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var qemuDiskFormats = []string{"qcow2", "raw", "vmdk"}
+
+// Checks that the disk exists in the guest config and is not a cdrom.
+func validateQemuDiskMove(vmConfig map[string]interface{}, diskID, format string) error {
+	if format != "" && !inArray(qemuDiskFormats, format) {
+		return errors.New("disk format must be one of (" + strings.Join(qemuDiskFormats, ",") + ")")
+	}
+	if !rxDiskName.MatchString(diskID) {
+		return fmt.Errorf("invalid disk id (%s)", diskID)
+	}
+	setting, isSet := vmConfig[diskID]
+	if !isSet {
+		return fmt.Errorf("disk (%s) does not exist", diskID)
+	}
+	for _, e := range strings.Split(setting.(string), ",") {
+		if e == "media=cdrom" {
+			return fmt.Errorf("disk (%s) is a cdrom and can not be moved", diskID)
+		}
+	}
+	return nil
+}
+
+// Checks that the volume exists in the container config and is not a bind mount.
+func validateLxcVolumeMove(lxcConfig map[string]interface{}, volume string) error {
+	if volume != "rootfs" && !rxMpName.MatchString(volume) {
+		return fmt.Errorf("invalid volume (%s), must be rootfs or a mount point", volume)
+	}
+	setting, isSet := lxcConfig[volume]
+	if !isSet {
+		return fmt.Errorf("volume (%s) does not exist", volume)
+	}
+	if strings.HasPrefix(setting.(string), "/") {
+		return fmt.Errorf("volume (%s) is a bind mount and can not be moved", volume)
+	}
+	return nil
+}
+
+// MoveDisk moves the disk (e.g. "scsi0") of the virtual machine to the target storage.
+// When format is empty the format of the source is kept. With deleteSource the source is removed,
+// otherwise it is kept as an unused disk.
+// Returns the UPID of the move task.
+func (c *Client) MoveDisk(ctx context.Context, vmr *VmRef, diskID, targetStorage, format string, deleteSource bool) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return
+	}
+	if vmr.vmType != "qemu" {
+		return "", fmt.Errorf("guest %d is not a virtual machine, use MoveLxcVolume for containers", vmr.vmId)
+	}
+	if err = validateQemuDiskMove(vmConfig, diskID, format); err != nil {
+		return
+	}
+	params := map[string]interface{}{
+		"disk":    diskID,
+		"storage": targetStorage,
+		"delete":  deleteSource,
+	}
+	if format != "" {
+		params["format"] = format
+	}
+	return c.moveDiskTask(ctx, fmt.Sprintf("/nodes/%s/qemu/%d/move_disk", vmr.node, vmr.vmId), params)
+}
+
+// MoveLxcVolume moves the volume ("rootfs" or "mpN") of the container to the target storage.
+// With deleteSource the source is removed, otherwise it is kept as an unused volume.
+// Returns the UPID of the move task.
+func (c *Client) MoveLxcVolume(ctx context.Context, vmr *VmRef, volume, targetStorage string, deleteSource bool) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	lxcConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return
+	}
+	if vmr.vmType != "lxc" {
+		return "", fmt.Errorf("guest %d is not an LXC container, use MoveDisk for virtual machines", vmr.vmId)
+	}
+	if err = validateLxcVolumeMove(lxcConfig, volume); err != nil {
+		return
+	}
+	params := map[string]interface{}{
+		"volume":  volume,
+		"storage": targetStorage,
+		"delete":  deleteSource,
+	}
+	return c.moveDiskTask(ctx, fmt.Sprintf("/nodes/%s/lxc/%d/move_volume", vmr.node, vmr.vmId), params)
+}
+
+func (c *Client) moveDiskTask(ctx context.Context, url string, params map[string]interface{}) (upid string, err error) {
+	reqbody := ParamsToBody(params)
+	resp, err := c.session.Post(ctx, url, nil, nil, &reqbody)
+	if err != nil {
+		return "", fmt.Errorf("error moving disk: %v, error status: %s", err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
@@ -0,0 +1,33 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateQemuDiskMove(t *testing.T) {
+	vmConfig := map[string]interface{}{
+		"ide2":  "local:iso/debian-11.iso,media=cdrom",
+		"scsi0": "local-lvm:vm-100-disk-0,size=32G",
+	}
+	require.NoError(t, validateQemuDiskMove(vmConfig, "scsi0", ""))
+	require.NoError(t, validateQemuDiskMove(vmConfig, "scsi0", "qcow2"))
+	require.Error(t, validateQemuDiskMove(vmConfig, "scsi0", "vdi"))
+	require.Error(t, validateQemuDiskMove(vmConfig, "scsi1", ""))
+	require.Error(t, validateQemuDiskMove(vmConfig, "ide2", ""))
+	require.Error(t, validateQemuDiskMove(vmConfig, "net0", ""))
+}
+
+func Test_validateLxcVolumeMove(t *testing.T) {
+	lxcConfig := map[string]interface{}{
+		"mp0":    "local-lvm:vm-100-disk-1,mp=/data",
+		"mp1":    "/mnt/host,mp=/host",
+		"rootfs": "local-lvm:vm-100-disk-0,size=8G",
+	}
+	require.NoError(t, validateLxcVolumeMove(lxcConfig, "rootfs"))
+	require.NoError(t, validateLxcVolumeMove(lxcConfig, "mp0"))
+	require.Error(t, validateLxcVolumeMove(lxcConfig, "mp1"))
+	require.Error(t, validateLxcVolumeMove(lxcConfig, "mp2"))
+	require.Error(t, validateLxcVolumeMove(lxcConfig, "net0"))
+}
@@ -0,0 +1,211 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Returns the members to add to and remove from the pool so the members match the config.
+func poolMemberChanges(config ConfigPool, info *PoolInfo) (addGuests, removeGuests []uint, addStorages, removeStorages []string) {
+	if config.Guests != nil {
+		addGuests = filterGuests(config.Guests, info.Guests, false)
+		removeGuests = filterGuests(info.Guests, config.Guests, false)
+	}
+	if config.Storages != nil {
+		addStorages = filterStorages(config.Storages, info.Storages, false)
+		removeStorages = filterStorages(info.Storages, config.Storages, false)
+	}
+	return
+}
+
+// EnsurePool creates the pool when it does not exist, otherwise it updates the comment and members to match the config.
+// Returns whether anything was changed, calling it again with the same config changes nothing.
+// Guests that are a member of another pool are not moved, Proxmox refuses to add them.
+func (c *Client) EnsurePool(ctx context.Context, config ConfigPool) (changed bool, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = config.Name.Validate(); err != nil {
+		return
+	}
+	pools, err := c.ListPools(ctx)
+	if err != nil {
+		return
+	}
+	exists := false
+	for _, e := range pools {
+		if e.Name == config.Name {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		if err = config.Create(ctx, c); err != nil {
+			return
+		}
+		changed = true
+	}
+	info, err := c.GetPool(ctx, config.Name)
+	if err != nil {
+		return
+	}
+	if info.Comment != config.Comment {
+		if err = config.Update(ctx, c); err != nil {
+			return
+		}
+		changed = true
+	}
+	addGuests, removeGuests, addStorages, removeStorages := poolMemberChanges(config, info)
+	if len(addGuests) > 0 {
+		if err = config.Name.update(ctx, map[string]interface{}{"vms": guestsToCsv(addGuests)}, c); err != nil {
+			return
+		}
+		changed = true
+	}
+	if len(removeGuests) > 0 {
+		if err = config.Name.update(ctx, map[string]interface{}{"vms": guestsToCsv(removeGuests), "delete": true}, c); err != nil {
+			return
+		}
+		changed = true
+	}
+	if len(addStorages) > 0 {
+		if err = config.Name.update(ctx, map[string]interface{}{"storage": strings.Join(addStorages, ",")}, c); err != nil {
+			return
+		}
+		changed = true
+	}
+	if len(removeStorages) > 0 {
+		if err = config.Name.update(ctx, map[string]interface{}{"storage": strings.Join(removeStorages, ","), "delete": true}, c); err != nil {
+			return
+		}
+		changed = true
+	}
+	return
+}
+
+// Disk sizes may be set as a number of gigabytes or as a string with a unit.
+func sameDiskSize(a, b interface{}) bool {
+	if a == nil || b == nil || a == "" || b == "" {
+		return false
+	}
+	return DiskSizeGB(a) == DiskSizeGB(b)
+}
+
+// Returns a copy of the config with the settings Proxmox generated when the guest was created taken from the current config:
+// the volume and format of existing disks and the mac address of nics.
+// Disks which are imported from an image are only imported when their slot does not exist yet.
+// Without them the config would never match the guest that was created from it.
+func (config ConfigQemu) withGeneratedSettings(current *ConfigQemu) ConfigQemu {
+	config.QemuDisks = copyQemuDevices(config.QemuDisks)
+	config.QemuNetworks = copyQemuDevices(config.QemuNetworks)
+	currentDisks := map[string]QemuDevice{}
+	for _, disk := range current.QemuDisks {
+		currentDisks[fmt.Sprintf("%v%v", disk["type"], disk["slot"])] = disk
+	}
+	for _, disk := range config.QemuDisks {
+		if volume, _ := disk["volume"].(string); volume != "" {
+			continue
+		}
+		existing, exists := currentDisks[fmt.Sprintf("%v%v", disk["type"], disk["slot"])]
+		if !exists || existing["storage"] != disk["storage"] {
+			continue
+		}
+		if importFrom, _ := disk["import_from"].(string); importFrom != "" {
+			// the image was imported when the disk was created, importing it again would replace the disk
+			delete(disk, "import_from")
+		} else if !sameDiskSize(existing["size"], disk["size"]) {
+			continue
+		}
+		disk["volume"] = existing["volume"]
+		disk["file"] = existing["file"]
+		disk["size"] = existing["size"]
+		if format, _ := disk["format"].(string); format == "" {
+			disk["format"] = existing["format"]
+		}
+	}
+	for id, nic := range config.QemuNetworks {
+		existing, exists := current.QemuNetworks[id]
+		if !exists {
+			continue
+		}
+		currentMac, _ := existing["macaddr"].(string)
+		if mac, _ := nic["macaddr"].(string); mac == "" || strings.EqualFold(mac, currentMac) {
+			nic["macaddr"] = currentMac
+		}
+	}
+	return config
+}
+
+// Returns the changes an update with the config would make, both configs are formatted the same way
+// so only actual differences are reported.
+func (config ConfigQemu) ensureChanges(ctx context.Context, vmr *VmRef, client *Client, current *ConfigQemu) ([]ConfigChange, error) {
+	currentConfig, err := client.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return nil, err
+	}
+	params, err := config.withGeneratedSettings(current).mapToApiUpdateParams(vmr, currentConfig)
+	if err != nil {
+		return nil, err
+	}
+	current.QemuDisks = copyQemuDevices(current.QemuDisks)
+	current.QemuNetworks = copyQemuDevices(current.QemuNetworks)
+	currentParams, err := current.mapToApiUpdateParams(vmr, currentConfig)
+	if err != nil {
+		return nil, err
+	}
+	currentValues := map[string]interface{}{}
+	for key, values := range ParamsToValues(currentParams) {
+		currentValues[key] = strings.Join(values, ",")
+	}
+	return configChanges(currentValues, ParamsToValues(params)), nil
+}
+
+// EnsureGuest creates the qemu guest when it does not exist, otherwise it updates the guest when its config differs from the config.
+// The node of vmr is used when the guest has to be created.
+// The config is compared against the parsed config of the guest, settings Proxmox generated like the volumes of disks
+// and the mac addresses of nics are taken from the guest when the config does not set them.
+// Returns whether anything was changed, calling it again with the same config changes nothing.
+func (c *Client) EnsureGuest(ctx context.Context, vmr *VmRef, config ConfigQemu) (changed bool, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	exists, err := c.VMIdExists(ctx, vmr.vmId)
+	if err != nil {
+		return
+	}
+	if !exists {
+		if err = config.CreateVm(ctx, vmr, c); err != nil {
+			return
+		}
+		return true, nil
+	}
+	// read the current node, pool and HA state, vmr may be outdated
+	if _, err = c.GetVmInfo(ctx, vmr); err != nil {
+		return
+	}
+	if vmr.vmType != "qemu" {
+		return false, errors.New("guest is not a qemu guest")
+	}
+	if vmr.haState != "" {
+		if err = c.ReadVMHA(ctx, vmr); err != nil {
+			return
+		}
+	}
+	current, err := NewConfigQemuFromApi(ctx, vmr, c)
+	if err != nil {
+		return
+	}
+	changes, err := config.ensureChanges(ctx, vmr, c, current)
+	if err != nil {
+		return
+	}
+	if len(changes) == 0 && vmr.pool == config.Pool && vmr.haState == config.HaState && vmr.haGroup == config.HaGroup {
+		return false, nil
+	}
+	if err = config.withGeneratedSettings(current).UpdateConfig(ctx, vmr, c); err != nil {
+		return
+	}
+	return true, nil
+}
@@ -0,0 +1,79 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_poolMemberChanges(t *testing.T) {
+	info := &PoolInfo{Name: "test", Guests: []uint{100, 101}, Storages: []string{"local"}}
+	addGuests, removeGuests, addStorages, removeStorages := poolMemberChanges(ConfigPool{Name: "test", Guests: []uint{101, 102}, Storages: []string{"local", "nfs"}}, info)
+	require.Equal(t, []uint{102}, addGuests)
+	require.Equal(t, []uint{100}, removeGuests)
+	require.Equal(t, []string{"nfs"}, addStorages)
+	require.Equal(t, []string{}, removeStorages)
+
+	// nil leaves the members as they are, empty removes all of them
+	addGuests, removeGuests, addStorages, removeStorages = poolMemberChanges(ConfigPool{Name: "test", Storages: []string{}}, info)
+	require.Nil(t, addGuests)
+	require.Nil(t, removeGuests)
+	require.Equal(t, []string{}, addStorages)
+	require.Equal(t, []string{"local"}, removeStorages)
+}
+
+func Test_ConfigQemu_withGeneratedSettings(t *testing.T) {
+	current := &ConfigQemu{
+		QemuDisks: QemuDevices{0: {"type": "scsi", "slot": 0, "storage": "local-lvm", "size": "10G", "volume": "local-lvm:vm-100-disk-0", "file": "vm-100-disk-0", "format": "raw"}},
+		QemuNetworks: QemuDevices{
+			0: {"model": "virtio", "bridge": "vmbr0", "macaddr": "AA:BB:CC:DD:EE:FF"},
+			1: {"model": "virtio", "bridge": "vmbr1", "macaddr": "AA:BB:CC:DD:EE:FE"},
+		},
+	}
+	config := ConfigQemu{
+		QemuDisks: QemuDevices{
+			0: {"type": "scsi", "slot": float64(0), "storage": "local-lvm", "size": float64(10)},
+			1: {"type": "scsi", "slot": 1, "storage": "local-lvm", "size": "20G"},
+		},
+		QemuNetworks: QemuDevices{
+			0: {"model": "virtio", "bridge": "vmbr0"},
+			1: {"model": "virtio", "bridge": "vmbr1", "macaddr": "aa:bb:cc:dd:ee:01"},
+		},
+	}
+	generated := config.withGeneratedSettings(current)
+	require.Equal(t, map[string]interface{}{"type": "scsi", "slot": float64(0), "storage": "local-lvm", "size": "10G", "volume": "local-lvm:vm-100-disk-0", "file": "vm-100-disk-0", "format": "raw"}, generated.QemuDisks[0])
+	require.NotContains(t, generated.QemuDisks[1], "volume")
+	require.Equal(t, "AA:BB:CC:DD:EE:FF", generated.QemuNetworks[0]["macaddr"])
+	// an explicitly set mac address is kept
+	require.Equal(t, "aa:bb:cc:dd:ee:01", generated.QemuNetworks[1]["macaddr"])
+	// the config itself is not changed
+	require.NotContains(t, config.QemuDisks[0], "volume")
+	require.NotContains(t, config.QemuNetworks[0], "macaddr")
+}
+
+// A disk imported by the first EnsureGuest must not be imported again by the next one.
+func Test_ConfigQemu_withGeneratedSettings_importFrom(t *testing.T) {
+	current := &ConfigQemu{
+		QemuDisks: QemuDevices{0: {"type": "scsi", "slot": 0, "storage": "local-lvm", "size": "2G", "volume": "local-lvm:vm-100-disk-0", "file": "vm-100-disk-0", "format": "raw"}},
+	}
+	config := ConfigQemu{
+		QemuDisks: QemuDevices{
+			0: {"type": "scsi", "slot": 0, "storage": "local-lvm", "import_from": "local:import/debian.qcow2"},
+			1: {"type": "scsi", "slot": 1, "storage": "local-lvm", "import_from": "local:import/debian.qcow2"},
+		},
+	}
+	generated := config.withGeneratedSettings(current)
+	require.NotContains(t, generated.QemuDisks[0], "import_from")
+	require.Equal(t, "local-lvm:vm-100-disk-0", generated.QemuDisks[0]["volume"])
+	// the slot does not exist yet, so the disk is imported
+	require.Equal(t, "local:import/debian.qcow2", generated.QemuDisks[1]["import_from"])
+	require.Equal(t, "local:import/debian.qcow2", config.QemuDisks[0]["import_from"])
+
+	delete(generated.QemuDisks, 1)
+	params := map[string]interface{}{}
+	currentParams := map[string]interface{}{}
+	require.NoError(t, generated.CreateQemuDisksParams(100, params, false))
+	require.NoError(t, current.CreateQemuDisksParams(100, currentParams, false))
+	require.Contains(t, params, "scsi0")
+	require.Equal(t, currentParams, params)
+}
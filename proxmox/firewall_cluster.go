@@ -0,0 +1,172 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var rxFirewallRate = regexp.MustCompile(`^[1-9]\d*(\.\d+)?/(second|minute|hour|day)$`)
+
+// Limits the amount of firewall log messages.
+type FirewallLogRateLimit struct {
+	Enable bool `json:"enable"`
+	// e.g. 1/second, the unit is one of second, minute, hour or day.
+	Rate  string `json:"rate,omitempty"`
+	Burst *uint  `json:"burst,omitempty"`
+}
+
+func (limit FirewallLogRateLimit) String() string {
+	settings := "enable=" + boolToIntString(limit.Enable)
+	if limit.Rate != "" {
+		settings += ",rate=" + limit.Rate
+	}
+	if limit.Burst != nil {
+		settings += ",burst=" + strconv.FormatUint(uint64(*limit.Burst), 10)
+	}
+	return settings
+}
+
+func (FirewallLogRateLimit) mapToStruct(settings string) *FirewallLogRateLimit {
+	limit := FirewallLogRateLimit{}
+	for i, e := range strings.Split(settings, ",") {
+		key, value, found := strings.Cut(e, "=")
+		if !found && i == 0 {
+			// enable is the default key
+			key, value = "enable", e
+		}
+		switch key {
+		case "enable":
+			limit.Enable = value == "1"
+		case "rate":
+			limit.Rate = value
+		case "burst":
+			if burst, err := strconv.ParseUint(value, 10, 32); err == nil {
+				limit.Burst = PointerUint(uint(burst))
+			}
+		}
+	}
+	return &limit
+}
+
+func (limit FirewallLogRateLimit) Validate() error {
+	if limit.Rate != "" && !rxFirewallRate.MatchString(limit.Rate) {
+		return errors.New("firewall log rate (" + limit.Rate + ") must be a number followed by /second, /minute, /hour or /day")
+	}
+	return nil
+}
+
+// Firewall options of the datacenter, nil and empty fields are left unchanged on update.
+type ClusterFirewallOptions struct {
+	// The firewall of the nodes and guests only filters traffic when this is enabled.
+	Enable   *bool `json:"enable,omitempty"`
+	Ebtables *bool `json:"ebtables,omitempty"`
+	// ACCEPT, DROP or REJECT.
+	PolicyIn     string                `json:"policy_in,omitempty"`
+	PolicyOut    string                `json:"policy_out,omitempty"`
+	LogRateLimit *FirewallLogRateLimit `json:"log_ratelimit,omitempty"`
+}
+
+func (options ClusterFirewallOptions) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{}
+	if options.Enable != nil {
+		params["enable"] = *options.Enable
+	}
+	if options.Ebtables != nil {
+		params["ebtables"] = *options.Ebtables
+	}
+	if options.PolicyIn != "" {
+		params["policy_in"] = options.PolicyIn
+	}
+	if options.PolicyOut != "" {
+		params["policy_out"] = options.PolicyOut
+	}
+	if options.LogRateLimit != nil {
+		params["log_ratelimit"] = options.LogRateLimit.String()
+	}
+	return params
+}
+
+func (ClusterFirewallOptions) mapToStruct(params map[string]interface{}) *ClusterFirewallOptions {
+	options := ClusterFirewallOptions{}
+	if _, isSet := params["enable"]; isSet {
+		options.Enable = PointerBool(Itob(int(params["enable"].(float64))))
+	}
+	if _, isSet := params["ebtables"]; isSet {
+		options.Ebtables = PointerBool(Itob(int(params["ebtables"].(float64))))
+	}
+	if _, isSet := params["policy_in"]; isSet {
+		options.PolicyIn = params["policy_in"].(string)
+	}
+	if _, isSet := params["policy_out"]; isSet {
+		options.PolicyOut = params["policy_out"].(string)
+	}
+	if _, isSet := params["log_ratelimit"]; isSet {
+		options.LogRateLimit = FirewallLogRateLimit{}.mapToStruct(params["log_ratelimit"].(string))
+	}
+	return &options
+}
+
+func (options ClusterFirewallOptions) Validate() error {
+	for _, policy := range []string{options.PolicyIn, options.PolicyOut} {
+		if policy != "" && !inArray(firewallRuleActions, policy) {
+			return errors.New("firewall policy must be one of (" + strings.Join(firewallRuleActions, ",") + ")")
+		}
+	}
+	if options.LogRateLimit != nil {
+		return options.LogRateLimit.Validate()
+	}
+	return nil
+}
+
+func (c *Client) GetClusterFirewallOptions(ctx context.Context) (*ClusterFirewallOptions, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, clusterFirewallUrl+"/options", "firewall options", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return ClusterFirewallOptions{}.mapToStruct(params), nil
+}
+
+// UpdateClusterFirewallOptions changes the options that are set, the other options keep their current value.
+func (c *Client) UpdateClusterFirewallOptions(ctx context.Context, options ClusterFirewallOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	return c.Put(ctx, options.mapToApiValues(), clusterFirewallUrl+"/options")
+}
+
+// Predefined rule which can be used as the macro of a firewall rule.
+type FirewallMacro struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ListFirewallMacros returns the macros that can be used in firewall rules.
+func (c *Client) ListFirewallMacros(ctx context.Context) ([]FirewallMacro, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, clusterFirewallUrl+"/macros")
+	if err != nil {
+		return nil, err
+	}
+	macros := make([]FirewallMacro, len(list))
+	for i, e := range list {
+		macro := e.(map[string]interface{})
+		if _, isSet := macro["macro"]; isSet {
+			macros[i].Name = macro["macro"].(string)
+		}
+		if _, isSet := macro["descr"]; isSet {
+			macros[i].Description = macro["descr"].(string)
+		}
+	}
+	return macros, nil
+}
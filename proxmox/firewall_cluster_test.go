@@ -0,0 +1,47 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FirewallLogRateLimit(t *testing.T) {
+	limit := FirewallLogRateLimit{Enable: true, Rate: "10/minute", Burst: PointerUint(5)}
+	require.Equal(t, "enable=1,rate=10/minute,burst=5", limit.String())
+	require.Equal(t, &limit, FirewallLogRateLimit{}.mapToStruct("enable=1,rate=10/minute,burst=5"))
+	require.Equal(t, &FirewallLogRateLimit{Enable: false, Rate: "1/second"}, FirewallLogRateLimit{}.mapToStruct("0,rate=1/second"))
+	require.NoError(t, limit.Validate())
+	require.NoError(t, FirewallLogRateLimit{Rate: "1.5/hour"}.Validate())
+	require.Error(t, FirewallLogRateLimit{Rate: "10/week"}.Validate())
+	require.Error(t, FirewallLogRateLimit{Rate: "0/second"}.Validate())
+}
+
+func Test_ClusterFirewallOptions(t *testing.T) {
+	options := ClusterFirewallOptions{
+		Enable:       PointerBool(true),
+		Ebtables:     PointerBool(false),
+		PolicyIn:     "DROP",
+		PolicyOut:    "ACCEPT",
+		LogRateLimit: &FirewallLogRateLimit{Enable: true, Rate: "1/second"},
+	}
+	require.NoError(t, options.Validate())
+	require.Equal(t, map[string]interface{}{
+		"enable":        true,
+		"ebtables":      false,
+		"policy_in":     "DROP",
+		"policy_out":    "ACCEPT",
+		"log_ratelimit": "enable=1,rate=1/second",
+	}, options.mapToApiValues())
+	require.Equal(t, &options, ClusterFirewallOptions{}.mapToStruct(map[string]interface{}{
+		"enable":        float64(1),
+		"ebtables":      float64(0),
+		"policy_in":     "DROP",
+		"policy_out":    "ACCEPT",
+		"log_ratelimit": "enable=1,rate=1/second",
+		"digest":        "abc",
+	}))
+	require.Equal(t, map[string]interface{}{}, ClusterFirewallOptions{}.mapToApiValues())
+	require.Error(t, ClusterFirewallOptions{PolicyOut: "allow"}.Validate())
+	require.Error(t, ClusterFirewallOptions{LogRateLimit: &FirewallLogRateLimit{Rate: "fast"}}.Validate())
+}
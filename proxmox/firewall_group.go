@@ -0,0 +1,163 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+const firewallGroupNameMaxLength = 18
+
+// Security group, a named set of rules that can be referenced from the rules of the cluster, nodes and guests.
+type FirewallSecurityGroup struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+}
+
+func (group FirewallSecurityGroup) mapToApiValues() map[string]interface{} {
+	return map[string]interface{}{
+		"group":   group.Name,
+		"comment": group.Comment,
+	}
+}
+
+func (FirewallSecurityGroup) mapToStruct(params map[string]interface{}) *FirewallSecurityGroup {
+	group := FirewallSecurityGroup{}
+	if _, isSet := params["group"]; isSet {
+		group.Name = params["group"].(string)
+	}
+	if _, isSet := params["comment"]; isSet {
+		group.Comment = params["comment"].(string)
+	}
+	return &group
+}
+
+func (group FirewallSecurityGroup) Validate() error {
+	return validateFirewallGroupName(group.Name)
+}
+
+func validateFirewallGroupName(name string) error {
+	if err := validateFirewallName("security group", name); err != nil {
+		return err
+	}
+	if len(name) > firewallGroupNameMaxLength {
+		return errors.New("security group name (" + name + ") may not be longer than " + strconv.Itoa(firewallGroupNameMaxLength) + " characters")
+	}
+	return nil
+}
+
+// Returns a rule that applies the rules of the security group, on iface only when iface is not empty.
+func FirewallGroupRule(group, iface string, enable bool) FirewallRule {
+	return FirewallRule{
+		Type:   FirewallRuleType_Group,
+		Action: group,
+		Iface:  iface,
+		Enable: enable,
+	}
+}
+
+func firewallGroupUrl(group string) string {
+	return clusterFirewallUrl + "/groups/" + group
+}
+
+// ListFirewallGroups returns the security groups of the cluster.
+func (c *Client) ListFirewallGroups(ctx context.Context) ([]FirewallSecurityGroup, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, clusterFirewallUrl+"/groups")
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]FirewallSecurityGroup, len(list))
+	for i, e := range list {
+		groups[i] = *FirewallSecurityGroup{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return groups, nil
+}
+
+func (c *Client) CreateFirewallGroup(ctx context.Context, group FirewallSecurityGroup) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+	return c.Post(ctx, group.mapToApiValues(), clusterFirewallUrl+"/groups")
+}
+
+// UpdateFirewallGroup changes the comment of the security group with the same name.
+func (c *Client) UpdateFirewallGroup(ctx context.Context, group FirewallSecurityGroup) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+	params := group.mapToApiValues()
+	// Proxmox updates an existing group when rename is set, setting it to the current name keeps it.
+	params["rename"] = group.Name
+	return c.Post(ctx, params, clusterFirewallUrl+"/groups")
+}
+
+// DeleteFirewallGroup removes the security group, Proxmox refuses this while the group still has rules.
+func (c *Client) DeleteFirewallGroup(ctx context.Context, group string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateFirewallGroupName(group); err != nil {
+		return err
+	}
+	return c.Delete(ctx, firewallGroupUrl(group))
+}
+
+// ListFirewallGroupRules returns the rules of the security group, ordered by position.
+func (c *Client) ListFirewallGroupRules(ctx context.Context, group string) ([]FirewallRule, error) {
+	if err := validateFirewallGroupName(group); err != nil {
+		return nil, err
+	}
+	return c.listFirewallRules(ctx, firewallGroupUrl(group))
+}
+
+// CreateFirewallGroupRule inserts the rule at pos, the rules at and after pos move down by one.
+// When pos is nil the rule is added at the top. Security groups can not be nested.
+func (c *Client) CreateFirewallGroupRule(ctx context.Context, group string, rule FirewallRule, pos *uint) error {
+	if err := validateFirewallGroupRule(group, rule); err != nil {
+		return err
+	}
+	return c.createFirewallRule(ctx, firewallGroupUrl(group), rule, pos)
+}
+
+// UpdateFirewallGroupRule replaces the rule at pos, empty optional fields are removed from the rule.
+func (c *Client) UpdateFirewallGroupRule(ctx context.Context, group string, pos uint, rule FirewallRule) error {
+	if err := validateFirewallGroupRule(group, rule); err != nil {
+		return err
+	}
+	return c.updateFirewallRule(ctx, firewallGroupUrl(group), pos, rule)
+}
+
+// MoveFirewallGroupRule moves the rule at pos to the position moveTo within the security group.
+func (c *Client) MoveFirewallGroupRule(ctx context.Context, group string, pos, moveTo uint) error {
+	if err := validateFirewallGroupName(group); err != nil {
+		return err
+	}
+	return c.moveFirewallRule(ctx, firewallGroupUrl(group), pos, moveTo)
+}
+
+// DeleteFirewallGroupRule removes the rule at pos, the rules after it move up by one.
+func (c *Client) DeleteFirewallGroupRule(ctx context.Context, group string, pos uint) error {
+	if err := validateFirewallGroupName(group); err != nil {
+		return err
+	}
+	return c.deleteFirewallRule(ctx, firewallGroupUrl(group), pos)
+}
+
+func validateFirewallGroupRule(group string, rule FirewallRule) error {
+	if err := validateFirewallGroupName(group); err != nil {
+		return err
+	}
+	if rule.Type == FirewallRuleType_Group {
+		return errors.New("security group (" + group + ") can not contain rules of type group")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FirewallSecurityGroup(t *testing.T) {
+	group := FirewallSecurityGroup{Name: "webservers", Comment: "http and https"}
+	require.NoError(t, group.Validate())
+	require.Equal(t, map[string]interface{}{"group": "webservers", "comment": "http and https"}, group.mapToApiValues())
+	require.Equal(t, &group, FirewallSecurityGroup{}.mapToStruct(map[string]interface{}{"group": "webservers", "comment": "http and https", "digest": "abc"}))
+}
+
+func Test_validateFirewallGroupName(t *testing.T) {
+	require.NoError(t, validateFirewallGroupName("web-servers_18char"))
+	require.Error(t, validateFirewallGroupName("web-servers_19chars"))
+	require.Error(t, validateFirewallGroupName("1web"))
+	require.Error(t, validateFirewallGroupName(""))
+}
+
+func Test_FirewallGroupRule(t *testing.T) {
+	rule := FirewallGroupRule("webservers", "net0", true)
+	require.Equal(t, FirewallRule{Type: "group", Action: "webservers", Iface: "net0", Enable: true}, rule)
+	require.NoError(t, rule.Validate())
+	require.Error(t, validateFirewallGroupRule("dmz", rule))
+	require.NoError(t, validateFirewallGroupRule("dmz", FirewallRule{Type: "in", Action: "ACCEPT"}))
+}
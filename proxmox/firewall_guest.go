@@ -0,0 +1,212 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Firewall options of a guest, nil and empty fields are left unchanged on update.
+type GuestFirewallOptions struct {
+	Enable    *bool `json:"enable,omitempty"`
+	Dhcp      *bool `json:"dhcp,omitempty"`
+	Ndp       *bool `json:"ndp,omitempty"`
+	Radv      *bool `json:"radv,omitempty"`
+	MacFilter *bool `json:"macfilter,omitempty"`
+	IpFilter  *bool `json:"ipfilter,omitempty"`
+	// ACCEPT, DROP or REJECT.
+	PolicyIn  string `json:"policy_in,omitempty"`
+	PolicyOut string `json:"policy_out,omitempty"`
+	// Log level, nolog disables logging.
+	LogLevelIn  string `json:"log_level_in,omitempty"`
+	LogLevelOut string `json:"log_level_out,omitempty"`
+}
+
+func (options GuestFirewallOptions) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{}
+	flags := map[string]*bool{
+		"enable":    options.Enable,
+		"dhcp":      options.Dhcp,
+		"ndp":       options.Ndp,
+		"radv":      options.Radv,
+		"macfilter": options.MacFilter,
+		"ipfilter":  options.IpFilter,
+	}
+	for key, value := range flags {
+		if value != nil {
+			params[key] = *value
+		}
+	}
+	values := map[string]string{
+		"policy_in":     options.PolicyIn,
+		"policy_out":    options.PolicyOut,
+		"log_level_in":  options.LogLevelIn,
+		"log_level_out": options.LogLevelOut,
+	}
+	for key, value := range values {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	return params
+}
+
+func (GuestFirewallOptions) mapToStruct(params map[string]interface{}) *GuestFirewallOptions {
+	options := GuestFirewallOptions{}
+	if _, isSet := params["enable"]; isSet {
+		options.Enable = PointerBool(Itob(int(params["enable"].(float64))))
+	}
+	if _, isSet := params["dhcp"]; isSet {
+		options.Dhcp = PointerBool(Itob(int(params["dhcp"].(float64))))
+	}
+	if _, isSet := params["ndp"]; isSet {
+		options.Ndp = PointerBool(Itob(int(params["ndp"].(float64))))
+	}
+	if _, isSet := params["radv"]; isSet {
+		options.Radv = PointerBool(Itob(int(params["radv"].(float64))))
+	}
+	if _, isSet := params["macfilter"]; isSet {
+		options.MacFilter = PointerBool(Itob(int(params["macfilter"].(float64))))
+	}
+	if _, isSet := params["ipfilter"]; isSet {
+		options.IpFilter = PointerBool(Itob(int(params["ipfilter"].(float64))))
+	}
+	if _, isSet := params["policy_in"]; isSet {
+		options.PolicyIn = params["policy_in"].(string)
+	}
+	if _, isSet := params["policy_out"]; isSet {
+		options.PolicyOut = params["policy_out"].(string)
+	}
+	if _, isSet := params["log_level_in"]; isSet {
+		options.LogLevelIn = params["log_level_in"].(string)
+	}
+	if _, isSet := params["log_level_out"]; isSet {
+		options.LogLevelOut = params["log_level_out"].(string)
+	}
+	return &options
+}
+
+func (options GuestFirewallOptions) Validate() error {
+	for _, policy := range []string{options.PolicyIn, options.PolicyOut} {
+		if policy != "" && !inArray(firewallRuleActions, policy) {
+			return errors.New("firewall policy must be one of (" + strings.Join(firewallRuleActions, ",") + ")")
+		}
+	}
+	for _, level := range []string{options.LogLevelIn, options.LogLevelOut} {
+		if level != "" && !inArray(firewallLogLevels, level) {
+			return errors.New("firewall log level must be one of (" + strings.Join(firewallLogLevels, ",") + ")")
+		}
+	}
+	return nil
+}
+
+func guestFirewallUrl(vmr *VmRef) string {
+	return "/nodes/" + vmr.node + "/" + vmr.vmType + "/" + strconv.Itoa(vmr.vmId) + "/firewall"
+}
+
+// GetGuestFirewallOptions returns the firewall options of the qemu or lxc guest.
+func (c *Client) GetGuestFirewallOptions(ctx context.Context, vmr *VmRef) (*GuestFirewallOptions, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, guestFirewallUrl(vmr)+"/options", "firewall options", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	return GuestFirewallOptions{}.mapToStruct(params), nil
+}
+
+// UpdateGuestFirewallOptions changes the options that are set, the other options keep their current value.
+func (c *Client) UpdateGuestFirewallOptions(ctx context.Context, vmr *VmRef, options GuestFirewallOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.Put(ctx, options.mapToApiValues(), guestFirewallUrl(vmr)+"/options")
+}
+
+// ListGuestFirewallRules returns the firewall rules of the guest, ordered by position.
+func (c *Client) ListGuestFirewallRules(ctx context.Context, vmr *VmRef) ([]FirewallRule, error) {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	return c.listFirewallRules(ctx, guestFirewallUrl(vmr)+"/rules")
+}
+
+// CreateGuestFirewallRule inserts the rule at pos, the rules at and after pos move down by one.
+// When pos is nil the rule is added at the top.
+func (c *Client) CreateGuestFirewallRule(ctx context.Context, vmr *VmRef, rule FirewallRule, pos *uint) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.createFirewallRule(ctx, guestFirewallUrl(vmr)+"/rules", rule, pos)
+}
+
+// UpdateGuestFirewallRule replaces the rule at pos, empty optional fields are removed from the rule.
+func (c *Client) UpdateGuestFirewallRule(ctx context.Context, vmr *VmRef, pos uint, rule FirewallRule) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.updateFirewallRule(ctx, guestFirewallUrl(vmr)+"/rules", pos, rule)
+}
+
+// MoveGuestFirewallRule moves the rule at pos to the position moveTo.
+func (c *Client) MoveGuestFirewallRule(ctx context.Context, vmr *VmRef, pos, moveTo uint) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.moveFirewallRule(ctx, guestFirewallUrl(vmr)+"/rules", pos, moveTo)
+}
+
+// DeleteGuestFirewallRule removes the rule at pos, the rules after it move up by one.
+func (c *Client) DeleteGuestFirewallRule(ctx context.Context, vmr *VmRef, pos uint) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.deleteFirewallRule(ctx, guestFirewallUrl(vmr)+"/rules", pos)
+}
+
+// SetGuestNicFirewall sets the firewall flag of network interface netN of the guest.
+// The rules of the guest only apply to interfaces with the flag set.
+func (c *Client) SetGuestNicFirewall(ctx context.Context, vmr *VmRef, nicID uint, enable bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	config, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return err
+	}
+	key := "net" + strconv.FormatUint(uint64(nicID), 10)
+	nic, isSet := config[key].(string)
+	if !isSet {
+		return fmt.Errorf("guest %d has no network interface %s", vmr.vmId, key)
+	}
+	updated := nicWithFirewall(nic, enable)
+	if updated == nic {
+		return nil
+	}
+	return c.Put(ctx, map[string]interface{}{key: updated}, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/config")
+}
+
+// Returns the network interface settings with the firewall flag set to enable.
+func nicWithFirewall(nic string, enable bool) string {
+	setting := "firewall=" + boolToIntString(enable)
+	settings := strings.Split(nic, ",")
+	for i, e := range settings {
+		if strings.HasPrefix(e, "firewall=") {
+			settings[i] = setting
+			return strings.Join(settings, ",")
+		}
+	}
+	return nic + "," + setting
+}
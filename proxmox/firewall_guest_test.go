@@ -0,0 +1,41 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GuestFirewallOptions_mapToApiValues(t *testing.T) {
+	require.Equal(t, map[string]interface{}{}, GuestFirewallOptions{}.mapToApiValues())
+	require.Equal(t, map[string]interface{}{
+		"enable":     true,
+		"macfilter":  false,
+		"policy_in":  "DROP",
+		"policy_out": "ACCEPT",
+	}, GuestFirewallOptions{Enable: PointerBool(true), MacFilter: PointerBool(false), PolicyIn: "DROP", PolicyOut: "ACCEPT"}.mapToApiValues())
+}
+
+func Test_GuestFirewallOptions_mapToStruct(t *testing.T) {
+	require.Equal(t, &GuestFirewallOptions{Enable: PointerBool(true), Dhcp: PointerBool(false), PolicyIn: "REJECT", LogLevelIn: "info"},
+		GuestFirewallOptions{}.mapToStruct(map[string]interface{}{
+			"enable":       float64(1),
+			"dhcp":         float64(0),
+			"policy_in":    "REJECT",
+			"log_level_in": "info",
+			"digest":       "abc",
+		}))
+}
+
+func Test_GuestFirewallOptions_Validate(t *testing.T) {
+	require.NoError(t, GuestFirewallOptions{}.Validate())
+	require.NoError(t, GuestFirewallOptions{PolicyIn: "DROP", PolicyOut: "ACCEPT", LogLevelOut: "nolog"}.Validate())
+	require.Error(t, GuestFirewallOptions{PolicyIn: "deny"}.Validate())
+	require.Error(t, GuestFirewallOptions{LogLevelIn: "all"}.Validate())
+}
+
+func Test_nicWithFirewall(t *testing.T) {
+	require.Equal(t, "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,firewall=1", nicWithFirewall("virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0", true))
+	require.Equal(t, "virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,firewall=0,tag=10", nicWithFirewall("virtio=AA:BB:CC:DD:EE:FF,bridge=vmbr0,firewall=1,tag=10", false))
+	require.Equal(t, "name=eth0,bridge=vmbr0,firewall=1", nicWithFirewall("name=eth0,bridge=vmbr0,firewall=1", true))
+}
@@ -0,0 +1,355 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+)
+
+// Names of ipsets and aliases.
+var rxFirewallName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9\-_]+$`)
+
+func validateFirewallName(kind, name string) error {
+	if name == "" {
+		return errors.New(kind + " name may not be empty")
+	}
+	if !rxFirewallName.MatchString(name) {
+		return errors.New(kind + " name (" + name + ") must start with a letter and may only contain letters, digits, '-' and '_'")
+	}
+	return nil
+}
+
+// Accepts an IPv4 or IPv6 address, with or without prefix length.
+func validateFirewallCIDR(cidr string) error {
+	if cidr == "" {
+		return errors.New("cidr may not be empty")
+	}
+	if _, _, err := net.ParseCIDR(cidr); err == nil {
+		return nil
+	}
+	if net.ParseIP(cidr) != nil {
+		return nil
+	}
+	return errors.New("cidr (" + cidr + ") must be an IPv4 or IPv6 address or network")
+}
+
+type IPSet struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+}
+
+func (IPSet) mapToStruct(params map[string]interface{}) *IPSet {
+	ipset := IPSet{}
+	if _, isSet := params["name"]; isSet {
+		ipset.Name = params["name"].(string)
+	}
+	if _, isSet := params["comment"]; isSet {
+		ipset.Comment = params["comment"].(string)
+	}
+	return &ipset
+}
+
+func (ipset IPSet) Validate() error {
+	return validateFirewallName("ipset", ipset.Name)
+}
+
+type IPSetEntry struct {
+	CIDR    string `json:"cidr"`
+	Comment string `json:"comment,omitempty"`
+	// Excludes the address or network from the ipset.
+	NoMatch bool `json:"nomatch,omitempty"`
+}
+
+func (entry IPSetEntry) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"comment": entry.Comment,
+		"nomatch": entry.NoMatch,
+	}
+	if create {
+		params["cidr"] = entry.CIDR
+	}
+	return params
+}
+
+func (IPSetEntry) mapToStruct(params map[string]interface{}) *IPSetEntry {
+	entry := IPSetEntry{}
+	if _, isSet := params["cidr"]; isSet {
+		entry.CIDR = params["cidr"].(string)
+	}
+	if _, isSet := params["comment"]; isSet {
+		entry.Comment = params["comment"].(string)
+	}
+	if _, isSet := params["nomatch"]; isSet {
+		entry.NoMatch = Itob(int(params["nomatch"].(float64)))
+	}
+	return &entry
+}
+
+func (entry IPSetEntry) Validate() error {
+	return validateFirewallCIDR(entry.CIDR)
+}
+
+// Alias for an address or network, which can be used in the source and dest of firewall rules.
+type FirewallAlias struct {
+	Name    string `json:"name"`
+	CIDR    string `json:"cidr"`
+	Comment string `json:"comment,omitempty"`
+}
+
+func (alias FirewallAlias) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"cidr":    alias.CIDR,
+		"comment": alias.Comment,
+	}
+	if create {
+		params["name"] = alias.Name
+	}
+	return params
+}
+
+func (FirewallAlias) mapToStruct(params map[string]interface{}) *FirewallAlias {
+	alias := FirewallAlias{}
+	if _, isSet := params["name"]; isSet {
+		alias.Name = params["name"].(string)
+	}
+	if _, isSet := params["cidr"]; isSet {
+		alias.CIDR = params["cidr"].(string)
+	}
+	if _, isSet := params["comment"]; isSet {
+		alias.Comment = params["comment"].(string)
+	}
+	return &alias
+}
+
+func (alias FirewallAlias) Validate() error {
+	if err := validateFirewallName("alias", alias.Name); err != nil {
+		return err
+	}
+	return validateFirewallCIDR(alias.CIDR)
+}
+
+const clusterFirewallUrl = "/cluster/firewall"
+
+func (c *Client) listIPSets(ctx context.Context, firewallUrl string) ([]IPSet, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, firewallUrl+"/ipset")
+	if err != nil {
+		return nil, err
+	}
+	ipsets := make([]IPSet, len(list))
+	for i, e := range list {
+		ipsets[i] = *IPSet{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return ipsets, nil
+}
+
+func (c *Client) createIPSet(ctx context.Context, firewallUrl string, ipset IPSet) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ipset.Validate(); err != nil {
+		return err
+	}
+	return c.Post(ctx, map[string]interface{}{"name": ipset.Name, "comment": ipset.Comment}, firewallUrl+"/ipset")
+}
+
+func (c *Client) deleteIPSet(ctx context.Context, firewallUrl string, name string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateFirewallName("ipset", name); err != nil {
+		return err
+	}
+	return c.Delete(ctx, firewallUrl+"/ipset/"+name)
+}
+
+func (c *Client) listIPSetEntries(ctx context.Context, firewallUrl string, name string) ([]IPSetEntry, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateFirewallName("ipset", name); err != nil {
+		return nil, err
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, firewallUrl+"/ipset/"+name)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]IPSetEntry, len(list))
+	for i, e := range list {
+		entries[i] = *IPSetEntry{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return entries, nil
+}
+
+func (c *Client) addIPSetEntry(ctx context.Context, firewallUrl string, name string, entry IPSetEntry) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateFirewallName("ipset", name); err != nil {
+		return err
+	}
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	return c.Post(ctx, entry.mapToApiValues(true), firewallUrl+"/ipset/"+name)
+}
+
+func (c *Client) updateIPSetEntry(ctx context.Context, firewallUrl string, name string, entry IPSetEntry) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateFirewallName("ipset", name); err != nil {
+		return err
+	}
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	return c.Put(ctx, entry.mapToApiValues(false), firewallUrl+"/ipset/"+name+"/"+url.PathEscape(entry.CIDR))
+}
+
+func (c *Client) removeIPSetEntry(ctx context.Context, firewallUrl string, name string, cidr string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateFirewallName("ipset", name); err != nil {
+		return err
+	}
+	if err := validateFirewallCIDR(cidr); err != nil {
+		return err
+	}
+	return c.Delete(ctx, firewallUrl+"/ipset/"+name+"/"+url.PathEscape(cidr))
+}
+
+// ListClusterIPSets returns the ipsets defined at the cluster level.
+// Proxmox only has ipsets at the cluster and guest level, not for nodes.
+func (c *Client) ListClusterIPSets(ctx context.Context) ([]IPSet, error) {
+	return c.listIPSets(ctx, clusterFirewallUrl)
+}
+
+func (c *Client) CreateClusterIPSet(ctx context.Context, ipset IPSet) error {
+	return c.createIPSet(ctx, clusterFirewallUrl, ipset)
+}
+
+// DeleteClusterIPSet removes the ipset, Proxmox refuses this while the ipset still has entries.
+func (c *Client) DeleteClusterIPSet(ctx context.Context, name string) error {
+	return c.deleteIPSet(ctx, clusterFirewallUrl, name)
+}
+
+func (c *Client) ListClusterIPSetEntries(ctx context.Context, name string) ([]IPSetEntry, error) {
+	return c.listIPSetEntries(ctx, clusterFirewallUrl, name)
+}
+
+func (c *Client) AddClusterIPSetEntry(ctx context.Context, name string, entry IPSetEntry) error {
+	return c.addIPSetEntry(ctx, clusterFirewallUrl, name, entry)
+}
+
+// UpdateClusterIPSetEntry changes the comment and nomatch of the entry with the same cidr.
+func (c *Client) UpdateClusterIPSetEntry(ctx context.Context, name string, entry IPSetEntry) error {
+	return c.updateIPSetEntry(ctx, clusterFirewallUrl, name, entry)
+}
+
+func (c *Client) RemoveClusterIPSetEntry(ctx context.Context, name string, cidr string) error {
+	return c.removeIPSetEntry(ctx, clusterFirewallUrl, name, cidr)
+}
+
+func (c *Client) ListGuestIPSets(ctx context.Context, vmr *VmRef) ([]IPSet, error) {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	return c.listIPSets(ctx, guestFirewallUrl(vmr))
+}
+
+func (c *Client) CreateGuestIPSet(ctx context.Context, vmr *VmRef, ipset IPSet) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.createIPSet(ctx, guestFirewallUrl(vmr), ipset)
+}
+
+// DeleteGuestIPSet removes the ipset, Proxmox refuses this while the ipset still has entries.
+func (c *Client) DeleteGuestIPSet(ctx context.Context, vmr *VmRef, name string) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.deleteIPSet(ctx, guestFirewallUrl(vmr), name)
+}
+
+func (c *Client) ListGuestIPSetEntries(ctx context.Context, vmr *VmRef, name string) ([]IPSetEntry, error) {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	return c.listIPSetEntries(ctx, guestFirewallUrl(vmr), name)
+}
+
+func (c *Client) AddGuestIPSetEntry(ctx context.Context, vmr *VmRef, name string, entry IPSetEntry) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.addIPSetEntry(ctx, guestFirewallUrl(vmr), name, entry)
+}
+
+// UpdateGuestIPSetEntry changes the comment and nomatch of the entry with the same cidr.
+func (c *Client) UpdateGuestIPSetEntry(ctx context.Context, vmr *VmRef, name string, entry IPSetEntry) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.updateIPSetEntry(ctx, guestFirewallUrl(vmr), name, entry)
+}
+
+func (c *Client) RemoveGuestIPSetEntry(ctx context.Context, vmr *VmRef, name string, cidr string) error {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return err
+	}
+	return c.removeIPSetEntry(ctx, guestFirewallUrl(vmr), name, cidr)
+}
+
+// ListClusterAliases returns the aliases defined at the cluster level.
+func (c *Client) ListClusterAliases(ctx context.Context) ([]FirewallAlias, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, clusterFirewallUrl+"/aliases")
+	if err != nil {
+		return nil, err
+	}
+	aliases := make([]FirewallAlias, len(list))
+	for i, e := range list {
+		aliases[i] = *FirewallAlias{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return aliases, nil
+}
+
+func (c *Client) CreateClusterAlias(ctx context.Context, alias FirewallAlias) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := alias.Validate(); err != nil {
+		return err
+	}
+	return c.Post(ctx, alias.mapToApiValues(true), clusterFirewallUrl+"/aliases")
+}
+
+// UpdateClusterAlias changes the cidr and comment of the alias with the same name.
+func (c *Client) UpdateClusterAlias(ctx context.Context, alias FirewallAlias) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := alias.Validate(); err != nil {
+		return err
+	}
+	return c.Put(ctx, alias.mapToApiValues(false), clusterFirewallUrl+"/aliases/"+alias.Name)
+}
+
+func (c *Client) DeleteClusterAlias(ctx context.Context, name string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateFirewallName("alias", name); err != nil {
+		return err
+	}
+	return c.Delete(ctx, clusterFirewallUrl+"/aliases/"+name)
+}
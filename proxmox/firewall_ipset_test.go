@@ -0,0 +1,41 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateFirewallCIDR(t *testing.T) {
+	for _, e := range []string{"10.0.0.1", "10.0.0.0/8", "2001:db8::1", "2001:db8::/32"} {
+		require.NoError(t, validateFirewallCIDR(e), e)
+	}
+	for _, e := range []string{"", "10.0.0.256", "10.0.0.0/33", "2001:db8::/129", "example.com", "+ipset"} {
+		require.Error(t, validateFirewallCIDR(e), e)
+	}
+}
+
+func Test_validateFirewallName(t *testing.T) {
+	require.NoError(t, validateFirewallName("ipset", "office_net-1"))
+	require.Error(t, validateFirewallName("ipset", ""))
+	require.Error(t, validateFirewallName("ipset", "a"))
+	require.Error(t, validateFirewallName("ipset", "1office"))
+	require.Error(t, validateFirewallName("ipset", "office.net"))
+}
+
+func Test_IPSetEntry(t *testing.T) {
+	entry := IPSetEntry{CIDR: "192.168.0.0/16", Comment: "lan", NoMatch: true}
+	require.Equal(t, map[string]interface{}{"cidr": "192.168.0.0/16", "comment": "lan", "nomatch": true}, entry.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{"comment": "lan", "nomatch": true}, entry.mapToApiValues(false))
+	require.Equal(t, &entry, IPSetEntry{}.mapToStruct(map[string]interface{}{"cidr": "192.168.0.0/16", "comment": "lan", "nomatch": float64(1)}))
+}
+
+func Test_FirewallAlias(t *testing.T) {
+	alias := FirewallAlias{Name: "gateway", CIDR: "2001:db8::1", Comment: "router"}
+	require.NoError(t, alias.Validate())
+	require.Equal(t, map[string]interface{}{"name": "gateway", "cidr": "2001:db8::1", "comment": "router"}, alias.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{"cidr": "2001:db8::1", "comment": "router"}, alias.mapToApiValues(false))
+	require.Equal(t, &alias, FirewallAlias{}.mapToStruct(map[string]interface{}{"name": "gateway", "cidr": "2001:db8::1", "comment": "router", "ipversion": float64(6)}))
+	require.Error(t, FirewallAlias{Name: "gateway"}.Validate())
+	require.Error(t, FirewallAlias{CIDR: "10.0.0.1"}.Validate())
+}
@@ -0,0 +1,242 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	FirewallRuleAction_Accept string = "ACCEPT"
+	FirewallRuleAction_Drop   string = "DROP"
+	FirewallRuleAction_Reject string = "REJECT"
+
+	FirewallRuleType_Group string = "group"
+	FirewallRuleType_In    string = "in"
+	FirewallRuleType_Out   string = "out"
+)
+
+var (
+	firewallRuleActions   = []string{FirewallRuleAction_Accept, FirewallRuleAction_Drop, FirewallRuleAction_Reject}
+	firewallRuleTypes     = []string{FirewallRuleType_Group, FirewallRuleType_In, FirewallRuleType_Out}
+	firewallLogLevels     = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug", "nolog"}
+	firewallRuleOptionals = []string{"comment", "dest", "dport", "iface", "log", "macro", "proto", "source", "sport"}
+	// Single ports, ranges (80:90) or service names, separated by commas.
+	rxFirewallPorts = regexp.MustCompile(`^[A-Za-z0-9\-]+(:[A-Za-z0-9\-]+)?(,[A-Za-z0-9\-]+(:[A-Za-z0-9\-]+)?)*$`)
+)
+
+// Firewall rule as used at the cluster, node and guest level.
+type FirewallRule struct {
+	// Position of the rule, only set when getting information from Proxmox.
+	Pos uint `json:"pos"`
+	// in, out or group.
+	Type string `json:"type"`
+	// ACCEPT, DROP or REJECT, or the name of the security group for rules of type group.
+	Action  string `json:"action"`
+	Enable  bool   `json:"enable"`
+	Comment string `json:"comment,omitempty"`
+	// Predefined rule, e.g. SSH, the proto and ports are taken from it.
+	Macro  string `json:"macro,omitempty"`
+	Proto  string `json:"proto,omitempty"`
+	Source string `json:"source,omitempty"`
+	Dest   string `json:"dest,omitempty"`
+	Sport  string `json:"sport,omitempty"`
+	Dport  string `json:"dport,omitempty"`
+	// Network interface the rule applies to, e.g. net0 for guests or vmbr0 for nodes.
+	Iface string `json:"iface,omitempty"`
+	// Log level, empty or nolog disables logging.
+	Log string `json:"log,omitempty"`
+}
+
+func (rule FirewallRule) mapToApiValues(create bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"type":   rule.Type,
+		"action": rule.Action,
+		"enable": boolToIntString(rule.Enable),
+	}
+	optionals := map[string]string{
+		"comment": rule.Comment,
+		"dest":    rule.Dest,
+		"dport":   rule.Dport,
+		"iface":   rule.Iface,
+		"log":     rule.Log,
+		"macro":   rule.Macro,
+		"proto":   rule.Proto,
+		"source":  rule.Source,
+		"sport":   rule.Sport,
+	}
+	deletions := ""
+	for _, key := range firewallRuleOptionals {
+		if optionals[key] != "" {
+			params[key] = optionals[key]
+		} else if !create {
+			deletions = AddToList(deletions, key)
+		}
+	}
+	if deletions != "" {
+		params["delete"] = deletions
+	}
+	return params
+}
+
+func (FirewallRule) mapToStruct(params map[string]interface{}) *FirewallRule {
+	rule := FirewallRule{}
+	if _, isSet := params["pos"]; isSet {
+		rule.Pos = uint(params["pos"].(float64))
+	}
+	if _, isSet := params["type"]; isSet {
+		rule.Type = params["type"].(string)
+	}
+	if _, isSet := params["action"]; isSet {
+		rule.Action = params["action"].(string)
+	}
+	if _, isSet := params["enable"]; isSet {
+		rule.Enable = Itob(int(params["enable"].(float64)))
+	}
+	if _, isSet := params["comment"]; isSet {
+		rule.Comment = params["comment"].(string)
+	}
+	if _, isSet := params["macro"]; isSet {
+		rule.Macro = params["macro"].(string)
+	}
+	if _, isSet := params["proto"]; isSet {
+		rule.Proto = params["proto"].(string)
+	}
+	if _, isSet := params["source"]; isSet {
+		rule.Source = params["source"].(string)
+	}
+	if _, isSet := params["dest"]; isSet {
+		rule.Dest = params["dest"].(string)
+	}
+	if _, isSet := params["sport"]; isSet {
+		rule.Sport = params["sport"].(string)
+	}
+	if _, isSet := params["dport"]; isSet {
+		rule.Dport = params["dport"].(string)
+	}
+	if _, isSet := params["iface"]; isSet {
+		rule.Iface = params["iface"].(string)
+	}
+	if _, isSet := params["log"]; isSet {
+		rule.Log = params["log"].(string)
+	}
+	return &rule
+}
+
+func (rule FirewallRule) Validate() error {
+	if !inArray(firewallRuleTypes, rule.Type) {
+		return errors.New("firewall rule type must be one of (" + strings.Join(firewallRuleTypes, ",") + ")")
+	}
+	if rule.Type == FirewallRuleType_Group {
+		if rule.Action == "" {
+			return errors.New("firewall rule of type group must have the security group as action")
+		}
+		if err := validateFirewallGroupName(rule.Action); err != nil {
+			return err
+		}
+	} else if !inArray(firewallRuleActions, rule.Action) {
+		return errors.New("firewall rule action must be one of (" + strings.Join(firewallRuleActions, ",") + ")")
+	}
+	if rule.Log != "" && !inArray(firewallLogLevels, rule.Log) {
+		return errors.New("firewall rule log level must be one of (" + strings.Join(firewallLogLevels, ",") + ")")
+	}
+	for _, ports := range []string{rule.Sport, rule.Dport} {
+		if ports == "" {
+			continue
+		}
+		if !rxFirewallPorts.MatchString(ports) {
+			return fmt.Errorf("firewall rule ports (%s) must be ports, port ranges (80:90) or service names separated by commas", ports)
+		}
+		if rule.Proto == "" && rule.Macro == "" {
+			return errors.New("firewall rule ports require a proto or macro")
+		}
+	}
+	return nil
+}
+
+func (c *Client) listFirewallRules(ctx context.Context, rulesUrl string) ([]FirewallRule, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, rulesUrl)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]FirewallRule, len(list))
+	for i, e := range list {
+		rules[i] = *FirewallRule{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return rules, nil
+}
+
+// Proxmox inserts the rule at position 0 when pos is omitted.
+func (c *Client) createFirewallRule(ctx context.Context, rulesUrl string, rule FirewallRule, pos *uint) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	params := rule.mapToApiValues(true)
+	if pos != nil {
+		params["pos"] = *pos
+	}
+	return c.Post(ctx, params, rulesUrl)
+}
+
+func (c *Client) updateFirewallRule(ctx context.Context, rulesUrl string, pos uint, rule FirewallRule) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	return c.Put(ctx, rule.mapToApiValues(false), rulesUrl+"/"+strconv.FormatUint(uint64(pos), 10))
+}
+
+func (c *Client) moveFirewallRule(ctx context.Context, rulesUrl string, pos, moveTo uint) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.Put(ctx, map[string]interface{}{"moveto": moveTo}, rulesUrl+"/"+strconv.FormatUint(uint64(pos), 10))
+}
+
+func (c *Client) deleteFirewallRule(ctx context.Context, rulesUrl string, pos uint) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.Delete(ctx, rulesUrl+"/"+strconv.FormatUint(uint64(pos), 10))
+}
+
+func nodeFirewallUrl(node string) string {
+	return "/nodes/" + node + "/firewall"
+}
+
+// ListNodeFirewallRules returns the firewall rules of the node, ordered by position.
+func (c *Client) ListNodeFirewallRules(ctx context.Context, node string) ([]FirewallRule, error) {
+	return c.listFirewallRules(ctx, nodeFirewallUrl(node)+"/rules")
+}
+
+// CreateNodeFirewallRule inserts the rule at pos, the rules at and after pos move down by one.
+// When pos is nil the rule is added at the top.
+func (c *Client) CreateNodeFirewallRule(ctx context.Context, node string, rule FirewallRule, pos *uint) error {
+	return c.createFirewallRule(ctx, nodeFirewallUrl(node)+"/rules", rule, pos)
+}
+
+// UpdateNodeFirewallRule replaces the rule at pos, empty optional fields are removed from the rule.
+func (c *Client) UpdateNodeFirewallRule(ctx context.Context, node string, pos uint, rule FirewallRule) error {
+	return c.updateFirewallRule(ctx, nodeFirewallUrl(node)+"/rules", pos, rule)
+}
+
+// MoveNodeFirewallRule moves the rule at pos to the position moveTo.
+func (c *Client) MoveNodeFirewallRule(ctx context.Context, node string, pos, moveTo uint) error {
+	return c.moveFirewallRule(ctx, nodeFirewallUrl(node)+"/rules", pos, moveTo)
+}
+
+// DeleteNodeFirewallRule removes the rule at pos, the rules after it move up by one.
+func (c *Client) DeleteNodeFirewallRule(ctx context.Context, node string, pos uint) error {
+	return c.deleteFirewallRule(ctx, nodeFirewallUrl(node)+"/rules", pos)
+}
@@ -0,0 +1,73 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FirewallRule_mapToApiValues(t *testing.T) {
+	rule := FirewallRule{Type: "in", Action: "ACCEPT", Enable: true, Proto: "tcp", Dport: "22", Source: "10.0.0.0/8", Comment: "ssh"}
+	require.Equal(t, map[string]interface{}{
+		"type":    "in",
+		"action":  "ACCEPT",
+		"enable":  "1",
+		"proto":   "tcp",
+		"dport":   "22",
+		"source":  "10.0.0.0/8",
+		"comment": "ssh",
+	}, rule.mapToApiValues(true))
+	require.Equal(t, map[string]interface{}{
+		"type":    "in",
+		"action":  "ACCEPT",
+		"enable":  "1",
+		"proto":   "tcp",
+		"dport":   "22",
+		"source":  "10.0.0.0/8",
+		"comment": "ssh",
+		"delete":  "dest,iface,log,macro,sport",
+	}, rule.mapToApiValues(false))
+}
+
+func Test_FirewallRule_mapToStruct(t *testing.T) {
+	require.Equal(t, &FirewallRule{Pos: 2, Type: "out", Action: "DROP", Enable: true, Macro: "DNS", Dest: "+blocked", Iface: "vmbr0", Log: "info"},
+		FirewallRule{}.mapToStruct(map[string]interface{}{
+			"pos":    float64(2),
+			"type":   "out",
+			"action": "DROP",
+			"enable": float64(1),
+			"macro":  "DNS",
+			"dest":   "+blocked",
+			"iface":  "vmbr0",
+			"log":    "info",
+			"digest": "abc",
+		}))
+}
+
+func Test_FirewallRule_Validate(t *testing.T) {
+	testData := []struct {
+		name  string
+		input FirewallRule
+		err   bool
+	}{
+		{name: "accept", input: FirewallRule{Type: "in", Action: "ACCEPT", Proto: "tcp", Dport: "80,443,8000:8100"}},
+		{name: "macro", input: FirewallRule{Type: "out", Action: "REJECT", Macro: "HTTP", Sport: "http"}},
+		{name: "group", input: FirewallRule{Type: "group", Action: "webservers"}},
+		{name: "log", input: FirewallRule{Type: "in", Action: "DROP", Log: "nolog"}},
+		{name: "invalid type", input: FirewallRule{Type: "forward", Action: "ACCEPT"}, err: true},
+		{name: "invalid action", input: FirewallRule{Type: "in", Action: "allow"}, err: true},
+		{name: "group without action", input: FirewallRule{Type: "group"}, err: true},
+		{name: "invalid log", input: FirewallRule{Type: "in", Action: "DROP", Log: "verbose"}, err: true},
+		{name: "invalid ports", input: FirewallRule{Type: "in", Action: "ACCEPT", Proto: "tcp", Dport: "80;443"}, err: true},
+		{name: "ports without proto", input: FirewallRule{Type: "in", Action: "ACCEPT", Dport: "22"}, err: true},
+	}
+	for _, e := range testData {
+		t.Run(e.name, func(*testing.T) {
+			if e.err {
+				require.Error(t, e.input.Validate())
+			} else {
+				require.NoError(t, e.input.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+)
+
+// Operations shared by QEMU virtual machines and LXC containers.
+// Use a type switch on *QemuGuest or *LxcGuest to get to the typed configuration.
+type Guest interface {
+	VmRef() *VmRef
+	Start(ctx context.Context) (exitStatus string, err error)
+	Stop(ctx context.Context) (exitStatus string, err error)
+	Shutdown(ctx context.Context) (exitStatus string, err error)
+	Delete(ctx context.Context) (exitStatus string, err error)
+	// Current status of the guest as returned by status/current.
+	GetStatus(ctx context.Context) (map[string]interface{}, error)
+	// Untyped configuration of the guest.
+	GetConfig(ctx context.Context) (map[string]interface{}, error)
+	GetTags(ctx context.Context) ([]string, error)
+}
+
+// Implements the operations that are the same for both guest types.
+type guestBase struct {
+	client *Client
+	vmr    *VmRef
+}
+
+func (g guestBase) VmRef() *VmRef {
+	return g.vmr
+}
+
+func (g guestBase) Start(ctx context.Context) (exitStatus string, err error) {
+	return g.client.StartVm(ctx, g.vmr)
+}
+
+func (g guestBase) Stop(ctx context.Context) (exitStatus string, err error) {
+	return g.client.StopVm(ctx, g.vmr)
+}
+
+func (g guestBase) Shutdown(ctx context.Context) (exitStatus string, err error) {
+	return g.client.ShutdownVm(ctx, g.vmr)
+}
+
+func (g guestBase) Delete(ctx context.Context) (exitStatus string, err error) {
+	return g.client.DeleteVm(ctx, g.vmr)
+}
+
+func (g guestBase) GetStatus(ctx context.Context) (map[string]interface{}, error) {
+	return g.client.GetVmState(ctx, g.vmr)
+}
+
+func (g guestBase) GetConfig(ctx context.Context) (map[string]interface{}, error) {
+	return g.client.GetVmConfig(ctx, g.vmr)
+}
+
+func (g guestBase) GetTags(ctx context.Context) ([]string, error) {
+	vmConfig, err := g.client.GetVmConfig(ctx, g.vmr)
+	if err != nil {
+		return nil, err
+	}
+	if _, isSet := vmConfig["tags"]; isSet {
+		return parseTags(vmConfig["tags"].(string)), nil
+	}
+	return []string{}, nil
+}
+
+type QemuGuest struct {
+	guestBase
+}
+
+func (g QemuGuest) Config(ctx context.Context) (*ConfigQemu, error) {
+	return NewConfigQemuFromApi(ctx, g.vmr, g.client)
+}
+
+type LxcGuest struct {
+	guestBase
+}
+
+func (g LxcGuest) Config() (*ConfigLxc, error) {
+	return NewConfigLxcFromApi(g.vmr, g.client)
+}
+
+// GetGuest looks the guest up in the cluster resources and returns a *QemuGuest or *LxcGuest depending on its type.
+func (c *Client) GetGuest(ctx context.Context, vmid int) (Guest, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmr := NewVmRef(vmid)
+	err := c.CheckVmRef(ctx, vmr)
+	if err != nil {
+		return nil, err
+	}
+	return newGuest(c, vmr)
+}
+
+func newGuest(c *Client, vmr *VmRef) (Guest, error) {
+	switch vmr.vmType {
+	case "qemu":
+		return &QemuGuest{guestBase{client: c, vmr: vmr}}, nil
+	case "lxc":
+		return &LxcGuest{guestBase{client: c, vmr: vmr}}, nil
+	}
+	return nil, fmt.Errorf("guest %d has unknown type (%s)", vmr.vmId, vmr.vmType)
+}
@@ -0,0 +1,118 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Options for migrating a qemu guest or LXC container to another node.
+type MigrateOptions struct {
+	// Node the guest is migrated to.
+	Target string `json:"target"`
+	// Live migrate a running qemu guest.
+	Online bool `json:"online,omitempty"`
+	// Restart migration of a running LXC container, the container is shut down and started again on the target.
+	Restart bool `json:"restart,omitempty"`
+	// Also migrate the disks on local storage of a qemu guest, LXC containers always migrate their local volumes.
+	WithLocalDisks bool `json:"with-local-disks,omitempty"`
+	// Storage on the target the local volumes are migrated to, when empty the storage with the same name is used.
+	TargetStorage string `json:"targetstorage,omitempty"`
+	BwLimit       int    `json:"bwlimit,omitempty"` // KiB/s
+}
+
+func (opts MigrateOptions) mapToApiValues(guestType string) map[string]interface{} {
+	params := map[string]interface{}{
+		"target": opts.Target,
+	}
+	if guestType == "lxc" {
+		params["target-storage"] = opts.TargetStorage
+		if opts.Restart {
+			params["restart"] = true
+		}
+	} else {
+		params["targetstorage"] = opts.TargetStorage
+		if opts.Online {
+			params["online"] = true
+		}
+		if opts.WithLocalDisks {
+			params["with-local-disks"] = true
+		}
+	}
+	if opts.BwLimit != 0 {
+		params["bwlimit"] = opts.BwLimit
+	}
+	return params
+}
+
+func (opts MigrateOptions) Validate(guestType string) error {
+	if err := ValidateStringNotEmpty(opts.Target, "target"); err != nil {
+		return err
+	}
+	if opts.BwLimit < 0 {
+		return errors.New("bwlimit may not be negative")
+	}
+	if guestType == "lxc" {
+		if opts.Online {
+			return errors.New("lxc containers can not be migrated online, use restart instead")
+		}
+		if opts.WithLocalDisks {
+			return errors.New("with-local-disks is only supported for qemu guests")
+		}
+		return nil
+	}
+	if opts.Restart {
+		return errors.New("restart is only supported for lxc containers")
+	}
+	return nil
+}
+
+func (c *Client) startMigrateGuest(ctx context.Context, vmr *VmRef, opts MigrateOptions) (map[string]interface{}, error) {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	if err := opts.Validate(vmr.vmType); err != nil {
+		return nil, err
+	}
+	if opts.Target == vmr.node {
+		return nil, fmt.Errorf("guest %d already resides on node %s", vmr.vmId, opts.Target)
+	}
+	reqbody := ParamsToBody(opts.mapToApiValues(vmr.vmType))
+	url := fmt.Sprintf("/nodes/%s/%s/%d/migrate", vmr.node, vmr.vmType, vmr.vmId)
+	resp, err := c.session.Post(ctx, url, nil, nil, &reqbody)
+	if err != nil {
+		return nil, fmt.Errorf("error migrating guest %d: %v, error status: %s", vmr.vmId, err, c.HandleTaskError(resp))
+	}
+	return ResponseJSON(resp)
+}
+
+// MigrateGuest migrates the qemu guest or LXC container to the target node and waits for the migration to complete.
+// Returns the UPID of the migration task.
+func (c *Client) MigrateGuest(ctx context.Context, vmr *VmRef, opts MigrateOptions) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	taskResponse, err := c.startMigrateGuest(ctx, vmr, opts)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	if err == nil {
+		vmr.node = opts.Target
+	}
+	return
+}
+
+// StartMigrateGuest is MigrateGuest without waiting for the migration task, use WaitForTask to wait for the migration to complete.
+func (c *Client) StartMigrateGuest(ctx context.Context, vmr *VmRef, opts MigrateOptions) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	taskResponse, err := c.startMigrateGuest(ctx, vmr, opts)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	return
+}
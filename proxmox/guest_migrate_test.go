@@ -0,0 +1,24 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MigrateOptions_mapToApiValues(t *testing.T) {
+	opts := MigrateOptions{Target: "pve2", Online: true, Restart: true, WithLocalDisks: true, TargetStorage: "local-lvm", BwLimit: 1024}
+	require.Equal(t, "bwlimit=1024&online=1&target=pve2&targetstorage=local-lvm&with-local-disks=1", ParamsToValues(opts.mapToApiValues("qemu")).Encode())
+	require.Equal(t, "bwlimit=1024&restart=1&target=pve2&target-storage=local-lvm", ParamsToValues(opts.mapToApiValues("lxc")).Encode())
+	require.Equal(t, "target=pve2", ParamsToValues(MigrateOptions{Target: "pve2"}.mapToApiValues("qemu")).Encode())
+}
+
+func Test_MigrateOptions_Validate(t *testing.T) {
+	require.NoError(t, MigrateOptions{Target: "pve2", Online: true, WithLocalDisks: true}.Validate("qemu"))
+	require.NoError(t, MigrateOptions{Target: "pve2", Restart: true}.Validate("lxc"))
+	require.Error(t, MigrateOptions{}.Validate("qemu"))
+	require.Error(t, MigrateOptions{Target: "pve2", BwLimit: -1}.Validate("qemu"))
+	require.Error(t, MigrateOptions{Target: "pve2", Restart: true}.Validate("qemu"))
+	require.Error(t, MigrateOptions{Target: "pve2", Online: true}.Validate("lxc"))
+	require.Error(t, MigrateOptions{Target: "pve2", WithLocalDisks: true}.Validate("lxc"))
+}
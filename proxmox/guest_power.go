@@ -0,0 +1,81 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+)
+
+type PowerAction string
+
+const (
+	PowerAction_Start    PowerAction = "start"
+	PowerAction_Stop     PowerAction = "stop"
+	PowerAction_Shutdown PowerAction = "shutdown"
+	PowerAction_Reboot   PowerAction = "reboot"
+	PowerAction_Reset    PowerAction = "reset"
+	PowerAction_Suspend  PowerAction = "suspend"
+	PowerAction_Resume   PowerAction = "resume"
+)
+
+// Reset is only supported by qemu guests.
+func (action PowerAction) Validate(guestType string) error {
+	switch action {
+	case PowerAction_Start, PowerAction_Stop, PowerAction_Shutdown, PowerAction_Reboot, PowerAction_Suspend, PowerAction_Resume:
+		return nil
+	case PowerAction_Reset:
+		if guestType == "lxc" {
+			return errors.New("lxc containers can not be reset")
+		}
+		return nil
+	}
+	return errors.New("power action must be one of (start,stop,shutdown,reboot,reset,suspend,resume)")
+}
+
+// The timeout in seconds only applies to shutdown and reboot, 0 uses the default of Proxmox.
+func (action PowerAction) mapToApiValues(timeout uint) map[string]interface{} {
+	params := map[string]interface{}{}
+	if timeout != 0 && (action == PowerAction_Shutdown || action == PowerAction_Reboot) {
+		params["timeout"] = timeout
+	}
+	return params
+}
+
+func (c *Client) startPowerAction(ctx context.Context, vmr *VmRef, action PowerAction, timeout uint) (map[string]interface{}, error) {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	if err := action.Validate(vmr.vmType); err != nil {
+		return nil, err
+	}
+	taskResponse, _, err := c.startStatusChangeVm(ctx, vmr, action.mapToApiValues(timeout), string(action))
+	return taskResponse, err
+}
+
+// ChangePowerState performs the power action on the qemu guest or LXC container and waits for it to complete.
+// When shutting down or rebooting, the task fails and the guest keeps running when it did not shut down within timeout seconds.
+// Returns the UPID of the task.
+func (c *Client) ChangePowerState(ctx context.Context, vmr *VmRef, action PowerAction, timeout uint) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	taskResponse, err := c.startPowerAction(ctx, vmr, action, timeout)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
+
+// StartChangePowerState is ChangePowerState without waiting for the task, use WaitForTask to wait for it to complete.
+func (c *Client) StartChangePowerState(ctx context.Context, vmr *VmRef, action PowerAction, timeout uint) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	taskResponse, err := c.startPowerAction(ctx, vmr, action, timeout)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	return
+}
@@ -0,0 +1,22 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PowerAction_Validate(t *testing.T) {
+	require.NoError(t, PowerAction_Reset.Validate("qemu"))
+	require.NoError(t, PowerAction_Suspend.Validate("lxc"))
+	require.Error(t, PowerAction_Reset.Validate("lxc"))
+	require.Error(t, PowerAction("hibernate").Validate("qemu"))
+	require.Error(t, PowerAction("").Validate("qemu"))
+}
+
+func Test_PowerAction_mapToApiValues(t *testing.T) {
+	require.Equal(t, "timeout=60", ParamsToValues(PowerAction_Shutdown.mapToApiValues(60)).Encode())
+	require.Equal(t, "timeout=60", ParamsToValues(PowerAction_Reboot.mapToApiValues(60)).Encode())
+	require.Equal(t, "", ParamsToValues(PowerAction_Stop.mapToApiValues(60)).Encode())
+	require.Equal(t, "", ParamsToValues(PowerAction_Shutdown.mapToApiValues(0)).Encode())
+}
@@ -0,0 +1,67 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+)
+
+// Empty is valid and converts all disks.
+func validateTemplateDisk(diskID string) error {
+	if diskID == "" || diskID == "efidisk0" || diskID == "tpmstate0" || rxDiskName.FindString(diskID) == diskID {
+		return nil
+	}
+	return fmt.Errorf("disk (%s) is not a valid disk of a virtual machine", diskID)
+}
+
+func (c *Client) startConvertToTemplate(ctx context.Context, vmr *VmRef, diskID string) (map[string]interface{}, error) {
+	if err := validateTemplateDisk(diskID); err != nil {
+		return nil, err
+	}
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{}
+	if diskID != "" {
+		// containers are always converted as a whole
+		if vmr.vmType != "qemu" {
+			return nil, fmt.Errorf("guest %d is not a virtual machine, only the disks of virtual machines can be converted separately", vmr.vmId)
+		}
+		params["disk"] = diskID
+	}
+	reqbody := ParamsToBody(params)
+	url := fmt.Sprintf("/nodes/%s/%s/%d/template", vmr.node, vmr.vmType, vmr.vmId)
+	resp, err := c.session.Post(ctx, url, nil, nil, &reqbody)
+	if err != nil {
+		return nil, fmt.Errorf("error converting guest %d to a template: %v, error status: %s", vmr.vmId, err, c.HandleTaskError(resp))
+	}
+	return ResponseJSON(resp)
+}
+
+// ConvertToTemplate converts the QEMU or LXC guest into a template and waits for the conversion to complete.
+// When diskID (e.g. "scsi0") is not empty only that disk of the virtual machine is converted to a base image.
+// Returns the UPID of the conversion task.
+func (c *Client) ConvertToTemplate(ctx context.Context, vmr *VmRef, diskID string) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	taskResponse, err := c.startConvertToTemplate(ctx, vmr, diskID)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
+
+// StartConvertToTemplate is ConvertToTemplate without waiting for the conversion task, use WaitForTask to wait for the conversion to complete.
+func (c *Client) StartConvertToTemplate(ctx context.Context, vmr *VmRef, diskID string) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	taskResponse, err := c.startConvertToTemplate(ctx, vmr, diskID)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	return
+}
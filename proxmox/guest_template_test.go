@@ -0,0 +1,16 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateTemplateDisk(t *testing.T) {
+	for _, e := range []string{"", "scsi0", "virtio15", "ide2", "sata1", "efidisk0", "tpmstate0"} {
+		require.NoError(t, validateTemplateDisk(e), e)
+	}
+	for _, e := range []string{"scsi", "net0", "unused0", "xscsi0"} {
+		require.Error(t, validateTemplateDisk(e), e)
+	}
+}
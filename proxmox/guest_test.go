@@ -0,0 +1,27 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newGuest(t *testing.T) {
+	vmr := NewVmRef(100)
+	vmr.vmType = "qemu"
+	guest, err := newGuest(nil, vmr)
+	require.NoError(t, err)
+	require.IsType(t, &QemuGuest{}, guest)
+	require.Equal(t, vmr, guest.VmRef())
+
+	vmr = NewVmRef(101)
+	vmr.vmType = "lxc"
+	guest, err = newGuest(nil, vmr)
+	require.NoError(t, err)
+	require.IsType(t, &LxcGuest{}, guest)
+
+	vmr = NewVmRef(102)
+	vmr.vmType = "unknown"
+	_, err = newGuest(nil, vmr)
+	require.Error(t, err)
+}
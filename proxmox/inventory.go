@@ -0,0 +1,100 @@
+package proxmox
+
+import (
+	"context"
+	"sort"
+)
+
+// Inventory of the cluster, all pools with their members and all guests with their pool and tags.
+type Inventory struct {
+	Guests []InventoryGuest `json:"guests"`
+	Pools  []InventoryPool  `json:"pools"`
+	// All tags in use by at least one guest.
+	Tags []string `json:"tags"`
+}
+
+type InventoryGuest struct {
+	ID   uint     `json:"id"`
+	Name string   `json:"name"`
+	Node string   `json:"node"`
+	Pool string   `json:"pool,omitempty"`
+	Tags []string `json:"tags"`
+	// "qemu" or "lxc"
+	Type string `json:"type"`
+}
+
+type InventoryPool struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+	// IDs of the guests in the pool.
+	Guests   []uint   `json:"guests"`
+	Storages []string `json:"storages"`
+}
+
+// ExportInventory aggregates the cluster resources and pool memberships into a single Inventory.
+// Everything is sorted so consecutive exports can be compared directly.
+func (c *Client) ExportInventory(ctx context.Context) (*Inventory, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	resources, err := c.GetVmList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	poolList, err := c.GetPoolList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pools := map[string]map[string]interface{}{}
+	for _, e := range poolList["data"].([]interface{}) {
+		poolName := e.(map[string]interface{})["poolid"].(string)
+		// the pool list does not include the members
+		pools[poolName], err = c.GetPoolInfo(ctx, poolName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buildInventory(resources["data"].([]interface{}), pools), nil
+}
+
+func buildInventory(resources []interface{}, pools map[string]map[string]interface{}) *Inventory {
+	inventory := Inventory{Guests: []InventoryGuest{}, Pools: []InventoryPool{}}
+	allTags := []string{}
+	for _, e := range resources {
+		resource := e.(map[string]interface{})
+		guest := InventoryGuest{Tags: []string{}}
+		if _, isSet := resource["vmid"]; isSet {
+			guest.ID = uint(resource["vmid"].(float64))
+		}
+		if _, isSet := resource["name"]; isSet {
+			guest.Name = resource["name"].(string)
+		}
+		if _, isSet := resource["node"]; isSet {
+			guest.Node = resource["node"].(string)
+		}
+		if _, isSet := resource["pool"]; isSet {
+			guest.Pool = resource["pool"].(string)
+		}
+		if _, isSet := resource["tags"]; isSet {
+			guest.Tags = parseTags(resource["tags"].(string))
+		}
+		if _, isSet := resource["type"]; isSet {
+			guest.Type = resource["type"].(string)
+		}
+		allTags = append(allTags, guest.Tags...)
+		inventory.Guests = append(inventory.Guests, guest)
+	}
+	inventory.Tags = normalizeTags(allTags)
+	sort.Slice(inventory.Guests, func(i, j int) bool { return inventory.Guests[i].ID < inventory.Guests[j].ID })
+
+	for name, info := range pools {
+		pool := InventoryPool{Name: name}
+		if _, isSet := info["comment"]; isSet {
+			pool.Comment = info["comment"].(string)
+		}
+		pool.Guests, pool.Storages = poolMembersFromApi(info)
+		inventory.Pools = append(inventory.Pools, pool)
+	}
+	sort.Slice(inventory.Pools, func(i, j int) bool { return inventory.Pools[i].Name < inventory.Pools[j].Name })
+	return &inventory
+}
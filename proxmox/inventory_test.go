@@ -0,0 +1,33 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_buildInventory(t *testing.T) {
+	resources := []interface{}{
+		map[string]interface{}{"vmid": float64(101), "name": "db", "node": "pve2", "type": "lxc", "tags": "prod;db"},
+		map[string]interface{}{"vmid": float64(100), "name": "web", "node": "pve1", "type": "qemu", "pool": "prod", "tags": "Prod;web"},
+	}
+	pools := map[string]map[string]interface{}{
+		"prod": {"comment": "production", "members": []interface{}{
+			map[string]interface{}{"type": "qemu", "vmid": float64(100)},
+			map[string]interface{}{"type": "storage", "storage": "local", "node": "pve2"},
+			map[string]interface{}{"type": "storage", "storage": "local", "node": "pve1"},
+		}},
+		"empty": {},
+	}
+	require.Equal(t, &Inventory{
+		Guests: []InventoryGuest{
+			{ID: 100, Name: "web", Node: "pve1", Pool: "prod", Tags: []string{"prod", "web"}, Type: "qemu"},
+			{ID: 101, Name: "db", Node: "pve2", Tags: []string{"db", "prod"}, Type: "lxc"},
+		},
+		Pools: []InventoryPool{
+			{Name: "empty", Guests: []uint{}, Storages: []string{}},
+			{Name: "prod", Comment: "production", Guests: []uint{100}, Storages: []string{"local"}},
+		},
+		Tags: []string{"db", "prod", "web"},
+	}, buildInventory(resources, pools))
+}
@@ -55,3 +55,99 @@ func (c *Client) ShutdownNode(ctx context.Context, node string) (exitStatus stri
 func (c *Client) RebootNode(ctx context.Context, node string) (exitStatus string, err error) {
 	return c.nodeStatusCommand(ctx, node, "reboot")
 }
+
+// LogOptions filters the lines returned by GetNodeSyslog.
+// Since and Until use the "YYYY-MM-DD HH:MM:SS" format.
+type LogOptions struct {
+	Since   string
+	Until   string
+	Start   uint
+	Limit   uint
+	Service string
+}
+
+func (opts LogOptions) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{
+		"since":   opts.Since,
+		"until":   opts.Until,
+		"service": opts.Service,
+	}
+	if opts.Start != 0 {
+		params["start"] = opts.Start
+	}
+	if opts.Limit != 0 {
+		params["limit"] = opts.Limit
+	}
+	return params
+}
+
+// JournalOptions filters the lines returned by GetNodeJournal.
+// Since and Until are unix epochs, LastEntries limits the output to the last n entries.
+type JournalOptions struct {
+	Since       uint
+	Until       uint
+	LastEntries uint
+	StartCursor string
+	EndCursor   string
+}
+
+func (opts JournalOptions) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{
+		"startcursor": opts.StartCursor,
+		"endcursor":   opts.EndCursor,
+	}
+	if opts.Since != 0 {
+		params["since"] = opts.Since
+	}
+	if opts.Until != 0 {
+		params["until"] = opts.Until
+	}
+	if opts.LastEntries != 0 {
+		params["lastentries"] = opts.LastEntries
+	}
+	return params
+}
+
+// Map the syslog entries from the API to a list of lines.
+func createSyslogList(lineList []interface{}) []string {
+	lines := make([]string, len(lineList))
+	for i := range lineList {
+		itemMap := lineList[i].(map[string]interface{})
+		if _, isSet := itemMap["t"]; isSet {
+			lines[i] = itemMap["t"].(string)
+		}
+	}
+	return lines
+}
+
+// GetNodeSyslog returns the syslog lines of the specified node.
+func (c *Client) GetNodeSyslog(ctx context.Context, node string, opts LogOptions) (lines []string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	url := "/nodes/" + node + "/syslog"
+	if values := ParamsToValues(opts.mapToApiValues()); len(values) != 0 {
+		url += "?" + values.Encode()
+	}
+	lineList, err := c.GetItemListInterfaceArray(ctx, url)
+	if err != nil {
+		return
+	}
+	return createSyslogList(lineList), nil
+}
+
+// GetNodeJournal returns the systemd journal lines of the specified node.
+func (c *Client) GetNodeJournal(ctx context.Context, node string, opts JournalOptions) (lines []string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	url := "/nodes/" + node + "/journal"
+	if values := ParamsToValues(opts.mapToApiValues()); len(values) != 0 {
+		url += "?" + values.Encode()
+	}
+	lineList, err := c.GetItemListInterfaceArray(ctx, url)
+	if err != nil {
+		return
+	}
+	return ArrayToStringType(lineList), nil
+}
@@ -0,0 +1,125 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type AptUpdateOptions struct {
+	// Send a notification mail about new packages to root@pam.
+	Notify bool `json:"notify,omitempty"`
+	// Only produces output suitable for logging, omitting progress information.
+	Quiet bool `json:"quiet,omitempty"`
+}
+
+func (opts AptUpdateOptions) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{}
+	if opts.Notify {
+		params["notify"] = "1"
+	}
+	if opts.Quiet {
+		params["quiet"] = "1"
+	}
+	return params
+}
+
+// Package that can be upgraded on the node.
+type AptPackageUpdate struct {
+	Package     string `json:"package"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// Version that will be installed.
+	Version string `json:"version"`
+	// Version that is currently installed, empty when the package is not installed yet.
+	OldVersion string `json:"old_version,omitempty"`
+	Priority   string `json:"priority"`
+	Section    string `json:"section,omitempty"`
+	Arch       string `json:"arch,omitempty"`
+	Origin     string `json:"origin,omitempty"`
+}
+
+func (AptPackageUpdate) mapToStruct(params map[string]interface{}) AptPackageUpdate {
+	update := AptPackageUpdate{}
+	if _, isSet := params["Package"]; isSet {
+		update.Package = params["Package"].(string)
+	}
+	if _, isSet := params["Title"]; isSet {
+		update.Title = params["Title"].(string)
+	}
+	if _, isSet := params["Description"]; isSet {
+		update.Description = params["Description"].(string)
+	}
+	if _, isSet := params["Version"]; isSet {
+		update.Version = params["Version"].(string)
+	}
+	if _, isSet := params["OldVersion"]; isSet {
+		update.OldVersion = params["OldVersion"].(string)
+	}
+	if _, isSet := params["Priority"]; isSet {
+		update.Priority = params["Priority"].(string)
+	}
+	if _, isSet := params["Section"]; isSet {
+		update.Section = params["Section"].(string)
+	}
+	if _, isSet := params["Arch"]; isSet {
+		update.Arch = params["Arch"].(string)
+	}
+	if _, isSet := params["Origin"]; isSet {
+		update.Origin = params["Origin"].(string)
+	}
+	return update
+}
+
+// AptUpdate refreshes the package database of the node.
+// Returns the UPID of the update task.
+func (c *Client) AptUpdate(ctx context.Context, node string, opts AptUpdateOptions) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	reqbody := ParamsToBody(opts.mapToApiValues())
+	resp, err := c.session.Post(ctx, "/nodes/"+node+"/apt/update", nil, nil, &reqbody)
+	if err != nil {
+		return "", fmt.Errorf("error updating package database: %v, error status: %s", err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
+
+// ListAvailableUpdates returns the packages that can be upgraded,
+// based on the package database from the last AptUpdate.
+func (c *Client) ListAvailableUpdates(ctx context.Context, node string) ([]AptPackageUpdate, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/nodes/"+node+"/apt/update")
+	if err != nil {
+		return nil, err
+	}
+	updates := make([]AptPackageUpdate, len(list))
+	for i, e := range list {
+		updates[i] = AptPackageUpdate{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return updates, nil
+}
+
+// GetPackageChangelog returns the changelog of the package,
+// an empty version returns the changelog of the candidate version.
+func (c *Client) GetPackageChangelog(ctx context.Context, node, name, version string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ValidateStringNotEmpty(name, "name"); err != nil {
+		return "", err
+	}
+	query := url.Values{"name": []string{name}}
+	if version != "" {
+		query.Set("version", version)
+	}
+	return c.GetItemConfigString(ctx, "/nodes/"+node+"/apt/changelog?"+query.Encode(), "changelog", "CONFIG")
+}
@@ -0,0 +1,34 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AptUpdateOptions_mapToApiValues(t *testing.T) {
+	require.Equal(t, map[string]interface{}{}, AptUpdateOptions{}.mapToApiValues())
+	require.Equal(t, map[string]interface{}{"notify": "1", "quiet": "1"}, AptUpdateOptions{Notify: true, Quiet: true}.mapToApiValues())
+}
+
+func Test_AptPackageUpdate_mapToStruct(t *testing.T) {
+	require.Equal(t, AptPackageUpdate{
+		Package:    "pve-manager",
+		Title:      "Proxmox Virtual Environment Management Tools",
+		Version:    "8.2.4",
+		OldVersion: "8.2.2",
+		Priority:   "important",
+		Section:    "admin",
+		Arch:       "amd64",
+		Origin:     "Proxmox",
+	}, AptPackageUpdate{}.mapToStruct(map[string]interface{}{
+		"Package":    "pve-manager",
+		"Title":      "Proxmox Virtual Environment Management Tools",
+		"Version":    "8.2.4",
+		"OldVersion": "8.2.2",
+		"Priority":   "important",
+		"Section":    "admin",
+		"Arch":       "amd64",
+		"Origin":     "Proxmox",
+	}))
+}
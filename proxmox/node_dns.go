@@ -0,0 +1,119 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+const nodeMaxNameservers = 3
+
+// DNS settings of a node, Proxmox writes these to /etc/resolv.conf.
+type NodeDNS struct {
+	Search string `json:"search"`
+	// At most 3 IP addresses, in order of preference.
+	Nameservers []string `json:"nameservers,omitempty"`
+}
+
+func (dns NodeDNS) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{"search": dns.Search}
+	for i, e := range dns.Nameservers {
+		params["dns"+strconv.Itoa(i+1)] = e
+	}
+	return params
+}
+
+func (NodeDNS) mapToStruct(params map[string]interface{}) *NodeDNS {
+	dns := NodeDNS{Nameservers: []string{}}
+	if _, isSet := params["search"]; isSet {
+		dns.Search = params["search"].(string)
+	}
+	for i := 1; i <= nodeMaxNameservers; i++ {
+		if nameserver, isSet := params["dns"+strconv.Itoa(i)]; isSet && nameserver != "" {
+			dns.Nameservers = append(dns.Nameservers, nameserver.(string))
+		}
+	}
+	return &dns
+}
+
+func (dns NodeDNS) Validate() error {
+	if dns.Search == "" {
+		return errors.New("dns search domain may not be empty")
+	}
+	if len(dns.Nameservers) > nodeMaxNameservers {
+		return fmt.Errorf("a node can have at most %d nameservers", nodeMaxNameservers)
+	}
+	for _, e := range dns.Nameservers {
+		if net.ParseIP(e) == nil {
+			return fmt.Errorf("nameserver (%s) is not a valid IP address", e)
+		}
+	}
+	return nil
+}
+
+// GetDNS returns the DNS settings of the node.
+func (c *Client) GetDNS(ctx context.Context, node string) (*NodeDNS, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/nodes/"+node+"/dns", "node", "DNS")
+	if err != nil {
+		return nil, err
+	}
+	return NodeDNS{}.mapToStruct(params), nil
+}
+
+// SetDNS replaces the DNS settings of the node, nameservers that are not listed are removed.
+func (c *Client) SetDNS(ctx context.Context, node string, dns NodeDNS) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := dns.Validate(); err != nil {
+		return err
+	}
+	return c.Put(ctx, dns.mapToApiValues(), "/nodes/"+node+"/dns")
+}
+
+// Content of /etc/hosts of a node.
+type NodeHosts struct {
+	Data string `json:"data"`
+	// Digest of the content that was read, when set SetHosts fails if the file was changed in the meantime.
+	Digest string `json:"digest,omitempty"`
+}
+
+func (NodeHosts) mapToStruct(params map[string]interface{}) *NodeHosts {
+	hosts := NodeHosts{}
+	if _, isSet := params["data"]; isSet {
+		hosts.Data = params["data"].(string)
+	}
+	if _, isSet := params["digest"]; isSet {
+		hosts.Digest = params["digest"].(string)
+	}
+	return &hosts
+}
+
+// GetHosts returns the content of /etc/hosts of the node.
+func (c *Client) GetHosts(ctx context.Context, node string) (*NodeHosts, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/nodes/"+node+"/hosts", "node", "HOSTS")
+	if err != nil {
+		return nil, err
+	}
+	return NodeHosts{}.mapToStruct(params), nil
+}
+
+// SetHosts replaces the content of /etc/hosts of the node.
+func (c *Client) SetHosts(ctx context.Context, node string, hosts NodeHosts) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params := map[string]interface{}{"data": hosts.Data}
+	if hosts.Digest != "" {
+		params["digest"] = hosts.Digest
+	}
+	return c.Post(ctx, params, "/nodes/"+node+"/hosts")
+}
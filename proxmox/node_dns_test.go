@@ -0,0 +1,31 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NodeDNS_mapToApiValues(t *testing.T) {
+	require.Equal(t, map[string]interface{}{"search": "example.com", "dns1": "10.0.0.1", "dns2": "2001:db8::1"},
+		NodeDNS{Search: "example.com", Nameservers: []string{"10.0.0.1", "2001:db8::1"}}.mapToApiValues())
+	require.Equal(t, map[string]interface{}{"search": "example.com"}, NodeDNS{Search: "example.com"}.mapToApiValues())
+}
+
+func Test_NodeDNS_mapToStruct(t *testing.T) {
+	require.Equal(t, &NodeDNS{Search: "example.com", Nameservers: []string{"10.0.0.1", "10.0.0.3"}},
+		NodeDNS{}.mapToStruct(map[string]interface{}{"search": "example.com", "dns1": "10.0.0.1", "dns3": "10.0.0.3"}))
+	require.Equal(t, &NodeDNS{Nameservers: []string{}}, NodeDNS{}.mapToStruct(map[string]interface{}{}))
+}
+
+func Test_NodeDNS_Validate(t *testing.T) {
+	require.NoError(t, NodeDNS{Search: "example.com", Nameservers: []string{"10.0.0.1", "2001:db8::1", "1.1.1.1"}}.Validate())
+	require.Error(t, NodeDNS{Nameservers: []string{"10.0.0.1"}}.Validate())
+	require.Error(t, NodeDNS{Search: "example.com", Nameservers: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}}.Validate())
+	require.Error(t, NodeDNS{Search: "example.com", Nameservers: []string{"dns.example.com"}}.Validate())
+}
+
+func Test_NodeHosts_mapToStruct(t *testing.T) {
+	require.Equal(t, &NodeHosts{Data: "127.0.0.1 localhost\n", Digest: "abc"},
+		NodeHosts{}.mapToStruct(map[string]interface{}{"data": "127.0.0.1 localhost\n", "digest": "abc"}))
+}
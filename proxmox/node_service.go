@@ -0,0 +1,108 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ServiceAction string
+
+const (
+	ServiceAction_Start   ServiceAction = "start"
+	ServiceAction_Stop    ServiceAction = "stop"
+	ServiceAction_Restart ServiceAction = "restart"
+	ServiceAction_Reload  ServiceAction = "reload"
+)
+
+func (action ServiceAction) Validate() error {
+	switch action {
+	case ServiceAction_Start, ServiceAction_Stop, ServiceAction_Restart, ServiceAction_Reload:
+		return nil
+	}
+	return errors.New("service action must be one of (start,stop,restart,reload)")
+}
+
+// Systemd service on a node, e.g. pveproxy, pvedaemon or sshd.
+type NodeService struct {
+	Service     string `json:"service"`
+	Name        string `json:"name"`
+	Description string `json:"desc"`
+	// Simplified state, e.g. running, stopped or unknown.
+	State string `json:"state"`
+	// State as reported by systemd.
+	ActiveState string `json:"active-state"`
+	SubState    string `json:"sub-state"`
+	UnitState   string `json:"unit-state"`
+}
+
+func (NodeService) mapToStruct(params map[string]interface{}) NodeService {
+	service := NodeService{}
+	if _, isSet := params["service"]; isSet {
+		service.Service = params["service"].(string)
+	}
+	if _, isSet := params["name"]; isSet {
+		service.Name = params["name"].(string)
+	}
+	if _, isSet := params["desc"]; isSet {
+		service.Description = params["desc"].(string)
+	}
+	if _, isSet := params["state"]; isSet {
+		service.State = params["state"].(string)
+	}
+	if _, isSet := params["active-state"]; isSet {
+		service.ActiveState = params["active-state"].(string)
+	}
+	if _, isSet := params["sub-state"]; isSet {
+		service.SubState = params["sub-state"].(string)
+	}
+	if _, isSet := params["unit-state"]; isSet {
+		service.UnitState = params["unit-state"].(string)
+	}
+	return service
+}
+
+// ListServices returns the services Proxmox manages on the node.
+func (c *Client) ListServices(ctx context.Context, node string) ([]NodeService, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, "/nodes/"+node+"/services")
+	if err != nil {
+		return nil, err
+	}
+	services := make([]NodeService, len(list))
+	for i, e := range list {
+		services[i] = NodeService{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return services, nil
+}
+
+// ServiceAction starts, stops, restarts or reloads the service on the node.
+// Returns the UPID of the task.
+func (c *Client) ServiceAction(ctx context.Context, node, service string, action ServiceAction) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = ValidateStringNotEmpty(service, "service"); err != nil {
+		return
+	}
+	if strings.Contains(service, "/") {
+		return "", errors.New("service may not contain a '/'")
+	}
+	if err = action.Validate(); err != nil {
+		return
+	}
+	resp, err := c.session.Post(ctx, "/nodes/"+node+"/services/"+service+"/"+string(action), nil, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("error performing %s on service %s: %v, error status: %s", action, service, err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return
+	}
+	upid, _ = taskResponse["data"].(string)
+	_, err = c.WaitForCompletion(ctx, taskResponse)
+	return
+}
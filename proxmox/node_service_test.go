@@ -0,0 +1,35 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ServiceAction_Validate(t *testing.T) {
+	for _, e := range []ServiceAction{ServiceAction_Start, ServiceAction_Stop, ServiceAction_Restart, ServiceAction_Reload} {
+		require.NoError(t, e.Validate())
+	}
+	require.Error(t, ServiceAction("").Validate())
+	require.Error(t, ServiceAction("enable").Validate())
+}
+
+func Test_NodeService_mapToStruct(t *testing.T) {
+	require.Equal(t, NodeService{
+		Service:     "pveproxy",
+		Name:        "pveproxy",
+		Description: "PVE API Proxy Server",
+		State:       "running",
+		ActiveState: "active",
+		SubState:    "running",
+		UnitState:   "enabled",
+	}, NodeService{}.mapToStruct(map[string]interface{}{
+		"service":      "pveproxy",
+		"name":         "pveproxy",
+		"desc":         "PVE API Proxy Server",
+		"state":        "running",
+		"active-state": "active",
+		"sub-state":    "running",
+		"unit-state":   "enabled",
+	}))
+}
@@ -0,0 +1,138 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+)
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatus_Active    SubscriptionStatus = "active"
+	SubscriptionStatus_Expired   SubscriptionStatus = "expired"
+	SubscriptionStatus_Invalid   SubscriptionStatus = "invalid"
+	SubscriptionStatus_New       SubscriptionStatus = "new"
+	SubscriptionStatus_NotFound  SubscriptionStatus = "notfound"
+	SubscriptionStatus_Suspended SubscriptionStatus = "suspended"
+)
+
+// Subscription status of a node as reported by the Proxmox API
+type Subscription struct {
+	CheckTime   time.Time          `json:"checktime,omitempty"`
+	Key         string             `json:"key,omitempty"`
+	Level       string             `json:"level,omitempty"`
+	Message     string             `json:"message,omitempty"`
+	NextDueDate string             `json:"nextduedate,omitempty"`
+	ProductName string             `json:"productname,omitempty"`
+	RegDate     string             `json:"regdate,omitempty"`
+	ServerID    string             `json:"serverid,omitempty"`
+	Sockets     uint               `json:"sockets,omitempty"`
+	Status      SubscriptionStatus `json:"status"`
+}
+
+// Returns true when the node has a valid subscription and thereby access to the enterprise repository.
+func (sub Subscription) IsActive() bool {
+	return sub.Status == SubscriptionStatus_Active
+}
+
+// Returns true when no subscription key is set on the node, this is a normal state for nodes without a subscription.
+func (sub Subscription) IsNotFound() bool {
+	return sub.Status == SubscriptionStatus_NotFound
+}
+
+// Maps the API values from proxmox to a struct
+func (Subscription) mapToStruct(params map[string]interface{}) *Subscription {
+	sub := Subscription{}
+	if _, isSet := params["checktime"]; isSet {
+		sub.CheckTime = time.Unix(int64(params["checktime"].(float64)), 0)
+	}
+	if _, isSet := params["key"]; isSet {
+		sub.Key = params["key"].(string)
+	}
+	if _, isSet := params["level"]; isSet {
+		sub.Level = params["level"].(string)
+	}
+	if _, isSet := params["message"]; isSet {
+		sub.Message = params["message"].(string)
+	}
+	if _, isSet := params["nextduedate"]; isSet {
+		sub.NextDueDate = params["nextduedate"].(string)
+	}
+	if _, isSet := params["productname"]; isSet {
+		sub.ProductName = params["productname"].(string)
+	}
+	if _, isSet := params["regdate"]; isSet {
+		sub.RegDate = params["regdate"].(string)
+	}
+	if _, isSet := params["serverid"]; isSet {
+		sub.ServerID = params["serverid"].(string)
+	}
+	if _, isSet := params["sockets"]; isSet {
+		sub.Sockets = uint(params["sockets"].(float64))
+	}
+	if _, isSet := params["status"]; isSet {
+		sub.Status = SubscriptionStatus(params["status"].(string))
+	}
+	return &sub
+}
+
+// GetNodeSubscription returns the subscription status of the specified node.
+func (c *Client) GetNodeSubscription(ctx context.Context, node string) (*Subscription, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/nodes/"+node+"/subscription", "node", "SUBSCRIPTION")
+	if err != nil {
+		return nil, err
+	}
+	return Subscription{}.mapToStruct(params), nil
+}
+
+// CheckSubscription makes the specified node re-check its subscription against the Proxmox shop.
+// The updated status can be read with GetNodeSubscription afterwards.
+func (c *Client) CheckSubscription(ctx context.Context, node string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.Post(ctx, map[string]interface{}{"force": true}, "/nodes/"+node+"/subscription")
+}
+
+// Subscription keys look like pve<sockets><level>-<10 hex characters>, e.g. pve2c-0123456789.
+var rxSubscriptionKey = regexp.MustCompile(`^pve\d+[cbsp]-[0-9a-f]{10}$`)
+
+func validateSubscriptionKey(key string) error {
+	if !rxSubscriptionKey.MatchString(key) {
+		return errors.New("subscription key must be in the format pve<sockets><level>-<10 hex characters>")
+	}
+	return nil
+}
+
+// SetSubscriptionKey sets the subscription key of the specified node, Proxmox checks the key right away.
+func (c *Client) SetSubscriptionKey(ctx context.Context, node, key string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateSubscriptionKey(key); err != nil {
+		return err
+	}
+	return c.Put(ctx, map[string]interface{}{"key": key}, "/nodes/"+node+"/subscription")
+}
+
+// UpdateSubscriptionKey sets the key on the specified node unless it is already set and active,
+// and returns the resulting subscription status.
+// A key that turns out to be invalid or expired is reported through the status, not as an error.
+func (c *Client) UpdateSubscriptionKey(ctx context.Context, node, key string) (*Subscription, error) {
+	sub, err := c.GetNodeSubscription(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Key == key && sub.IsActive() {
+		return sub, nil
+	}
+	if err = c.SetSubscriptionKey(ctx, node, key); err != nil {
+		return nil, err
+	}
+	return c.GetNodeSubscription(ctx, node)
+}
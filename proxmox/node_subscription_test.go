@@ -0,0 +1,62 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test the mapping of the subscription API values to the Subscription struct
+func Test_Subscription_mapToStruct(t *testing.T) {
+	input := []map[string]interface{}{
+		{
+			"checktime":   float64(1680000000),
+			"key":         "pve2c-0123456789",
+			"level":       "c",
+			"message":     "",
+			"nextduedate": "2024-04-01",
+			"productname": "Proxmox VE Community Subscription 2 CPUs/year",
+			"regdate":     "2023-04-01 00:00:00",
+			"serverid":    "0123456789ABCDEF0123456789ABCDEF",
+			"sockets":     float64(2),
+			"status":      "active",
+		},
+		{
+			"message": "There is no subscription key",
+			"status":  "notfound",
+		},
+	}
+	output := []*Subscription{
+		{
+			CheckTime:   time.Unix(1680000000, 0),
+			Key:         "pve2c-0123456789",
+			Level:       "c",
+			NextDueDate: "2024-04-01",
+			ProductName: "Proxmox VE Community Subscription 2 CPUs/year",
+			RegDate:     "2023-04-01 00:00:00",
+			ServerID:    "0123456789ABCDEF0123456789ABCDEF",
+			Sockets:     2,
+			Status:      SubscriptionStatus_Active,
+		},
+		{
+			Message: "There is no subscription key",
+			Status:  SubscriptionStatus_NotFound,
+		},
+	}
+	for i := range input {
+		sub := Subscription{}.mapToStruct(input[i])
+		require.Equal(t, output[i], sub)
+		require.Equal(t, i == 0, sub.IsActive())
+		require.Equal(t, i == 1, sub.IsNotFound())
+	}
+}
+
+func Test_validateSubscriptionKey(t *testing.T) {
+	for _, key := range []string{"pve2c-0123456789", "pve4p-abcdef0123", "pve16b-0a1b2c3d4e"} {
+		require.NoError(t, validateSubscriptionKey(key), key)
+	}
+	for _, key := range []string{"", "pve2c-012345678", "pbs2c-0123456789", "pve2x-0123456789", "pve2c-ABCDEF0123"} {
+		require.Error(t, validateSubscriptionKey(key), key)
+	}
+}
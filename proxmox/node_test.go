@@ -0,0 +1,37 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test the mapping of the syslog options to the API values
+func Test_LogOptions_mapToApiValues(t *testing.T) {
+	input := []LogOptions{
+		{},
+		{Since: "2022-01-01 00:00:00", Until: "2022-01-02 00:00:00", Start: 10, Limit: 50, Service: "pvedaemon"},
+	}
+	output := []string{
+		"",
+		"limit=50&service=pvedaemon&since=2022-01-01+00%3A00%3A00&start=10&until=2022-01-02+00%3A00%3A00",
+	}
+	for i := range input {
+		require.Equal(t, output[i], ParamsToValues(input[i].mapToApiValues()).Encode())
+	}
+}
+
+// Test the formatting of the syslog entries into plain lines
+func Test_createSyslogList(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{"n": float64(1), "t": "Jan 01 00:00:00 pve systemd[1]: Started Session 1 of user root."},
+		map[string]interface{}{"n": float64(2), "t": "Jan 01 00:00:01 pve pvedaemon[1234]: <root@pam> successful auth for user 'root@pam'"},
+		map[string]interface{}{"n": float64(3)},
+	}
+	output := []string{
+		"Jan 01 00:00:00 pve systemd[1]: Started Session 1 of user root.",
+		"Jan 01 00:00:01 pve pvedaemon[1234]: <root@pam> successful auth for user 'root@pam'",
+		"",
+	}
+	require.Equal(t, output, createSyslogList(input))
+}
@@ -0,0 +1,66 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+)
+
+// Time settings of a node.
+type NodeTime struct {
+	// Current time of the node in its timezone, the offset is not known so it is represented as UTC.
+	LocalTime time.Time `json:"localtime"`
+	UTC       time.Time `json:"time"`
+	Timezone  string    `json:"timezone"`
+}
+
+func (NodeTime) mapToStruct(params map[string]interface{}) *NodeTime {
+	nodeTime := NodeTime{}
+	if _, isSet := params["localtime"]; isSet {
+		nodeTime.LocalTime = time.Unix(int64(params["localtime"].(float64)), 0).UTC()
+	}
+	if _, isSet := params["time"]; isSet {
+		nodeTime.UTC = time.Unix(int64(params["time"].(float64)), 0).UTC()
+	}
+	if _, isSet := params["timezone"]; isSet {
+		nodeTime.Timezone = params["timezone"].(string)
+	}
+	return &nodeTime
+}
+
+// Timezones are names from the tz database like "UTC" or "Europe/Vienna".
+var rxTimezone = regexp.MustCompile(`^[A-Za-z0-9_+\-]+(/[A-Za-z0-9_+\-]+)*$`)
+
+func validateTimezone(timezone string) error {
+	if timezone == "" {
+		return errors.New("timezone may not be empty")
+	}
+	if !rxTimezone.MatchString(timezone) {
+		return errors.New("timezone (" + timezone + ") must be a name from the tz database, like Europe/Vienna")
+	}
+	return nil
+}
+
+// GetNodeTime returns the current time and timezone of the node.
+func (c *Client) GetNodeTime(ctx context.Context, node string) (*NodeTime, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/nodes/"+node+"/time", "node", "TIME")
+	if err != nil {
+		return nil, err
+	}
+	return NodeTime{}.mapToStruct(params), nil
+}
+
+// SetNodeTimezone sets the timezone of the node, Proxmox rejects names it does not know.
+func (c *Client) SetNodeTimezone(ctx context.Context, node, timezone string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := validateTimezone(timezone); err != nil {
+		return err
+	}
+	return c.Put(ctx, map[string]interface{}{"timezone": timezone}, "/nodes/"+node+"/time")
+}
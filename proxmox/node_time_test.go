@@ -0,0 +1,31 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NodeTime_mapToStruct(t *testing.T) {
+	input := map[string]interface{}{
+		"localtime": float64(1680007200),
+		"time":      float64(1680000000),
+		"timezone":  "Europe/Vienna",
+	}
+	output := &NodeTime{
+		LocalTime: time.Unix(1680007200, 0).UTC(),
+		UTC:       time.Unix(1680000000, 0).UTC(),
+		Timezone:  "Europe/Vienna",
+	}
+	require.Equal(t, output, NodeTime{}.mapToStruct(input))
+}
+
+func Test_validateTimezone(t *testing.T) {
+	for _, timezone := range []string{"UTC", "Europe/Vienna", "America/Argentina/Buenos_Aires", "Etc/GMT+2"} {
+		require.NoError(t, validateTimezone(timezone), timezone)
+	}
+	for _, timezone := range []string{"", "/Europe", "Europe/", "Europe Vienna"} {
+		require.Error(t, validateTimezone(timezone), timezone)
+	}
+}
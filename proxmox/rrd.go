@@ -0,0 +1,188 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+type RRDTimeframe string
+
+const (
+	RRDTimeframe_Hour  RRDTimeframe = "hour"
+	RRDTimeframe_Day   RRDTimeframe = "day"
+	RRDTimeframe_Week  RRDTimeframe = "week"
+	RRDTimeframe_Month RRDTimeframe = "month"
+	RRDTimeframe_Year  RRDTimeframe = "year"
+)
+
+func (timeframe RRDTimeframe) Validate() error {
+	switch timeframe {
+	case RRDTimeframe_Hour, RRDTimeframe_Day, RRDTimeframe_Week, RRDTimeframe_Month, RRDTimeframe_Year:
+		return nil
+	}
+	return errors.New("rrd timeframe must be one of (hour,day,week,month,year)")
+}
+
+type RRDConsolidation string
+
+const (
+	RRDConsolidation_Average RRDConsolidation = "AVERAGE"
+	RRDConsolidation_Max     RRDConsolidation = "MAX"
+)
+
+// Empty is valid, Proxmox then uses AVERAGE.
+func (cf RRDConsolidation) Validate() error {
+	switch cf {
+	case "", RRDConsolidation_Average, RRDConsolidation_Max:
+		return nil
+	}
+	return errors.New("rrd consolidation function must be one of (AVERAGE,MAX)")
+}
+
+type RRDOptions struct {
+	Timeframe     RRDTimeframe     `json:"timeframe"`
+	Consolidation RRDConsolidation `json:"cf,omitempty"`
+}
+
+func (opts RRDOptions) mapToApiValues() map[string]interface{} {
+	return map[string]interface{}{
+		"timeframe": string(opts.Timeframe),
+		"cf":        string(opts.Consolidation),
+	}
+}
+
+func (opts RRDOptions) Validate() error {
+	if err := opts.Timeframe.Validate(); err != nil {
+		return err
+	}
+	return opts.Consolidation.Validate()
+}
+
+// Sample of a guest, values are nil when Proxmox has no data for the moment,
+// e.g. when the guest was not running.
+type GuestRRDPoint struct {
+	Time      time.Time `json:"time"`
+	CPU       *float64  `json:"cpu,omitempty"` // fraction of MaxCPU in use
+	MaxCPU    *float64  `json:"maxcpu,omitempty"`
+	Mem       *float64  `json:"mem,omitempty"`
+	MaxMem    *float64  `json:"maxmem,omitempty"`
+	Disk      *float64  `json:"disk,omitempty"`
+	MaxDisk   *float64  `json:"maxdisk,omitempty"`
+	DiskRead  *float64  `json:"diskread,omitempty"` // bytes per second
+	DiskWrite *float64  `json:"diskwrite,omitempty"`
+	NetIn     *float64  `json:"netin,omitempty"` // bytes per second
+	NetOut    *float64  `json:"netout,omitempty"`
+}
+
+func (GuestRRDPoint) mapToStruct(params map[string]interface{}) GuestRRDPoint {
+	return GuestRRDPoint{
+		Time:      rrdTime(params),
+		CPU:       rrdValue(params, "cpu"),
+		MaxCPU:    rrdValue(params, "maxcpu"),
+		Mem:       rrdValue(params, "mem"),
+		MaxMem:    rrdValue(params, "maxmem"),
+		Disk:      rrdValue(params, "disk"),
+		MaxDisk:   rrdValue(params, "maxdisk"),
+		DiskRead:  rrdValue(params, "diskread"),
+		DiskWrite: rrdValue(params, "diskwrite"),
+		NetIn:     rrdValue(params, "netin"),
+		NetOut:    rrdValue(params, "netout"),
+	}
+}
+
+// Sample of a node, values are nil when Proxmox has no data for the moment.
+type NodeRRDPoint struct {
+	Time      time.Time `json:"time"`
+	CPU       *float64  `json:"cpu,omitempty"` // fraction of MaxCPU in use
+	MaxCPU    *float64  `json:"maxcpu,omitempty"`
+	IOWait    *float64  `json:"iowait,omitempty"`
+	LoadAvg   *float64  `json:"loadavg,omitempty"`
+	MemTotal  *float64  `json:"memtotal,omitempty"`
+	MemUsed   *float64  `json:"memused,omitempty"`
+	SwapTotal *float64  `json:"swaptotal,omitempty"`
+	SwapUsed  *float64  `json:"swapused,omitempty"`
+	RootTotal *float64  `json:"roottotal,omitempty"`
+	RootUsed  *float64  `json:"rootused,omitempty"`
+	NetIn     *float64  `json:"netin,omitempty"` // bytes per second
+	NetOut    *float64  `json:"netout,omitempty"`
+}
+
+func (NodeRRDPoint) mapToStruct(params map[string]interface{}) NodeRRDPoint {
+	return NodeRRDPoint{
+		Time:      rrdTime(params),
+		CPU:       rrdValue(params, "cpu"),
+		MaxCPU:    rrdValue(params, "maxcpu"),
+		IOWait:    rrdValue(params, "iowait"),
+		LoadAvg:   rrdValue(params, "loadavg"),
+		MemTotal:  rrdValue(params, "memtotal"),
+		MemUsed:   rrdValue(params, "memused"),
+		SwapTotal: rrdValue(params, "swaptotal"),
+		SwapUsed:  rrdValue(params, "swapused"),
+		RootTotal: rrdValue(params, "roottotal"),
+		RootUsed:  rrdValue(params, "rootused"),
+		NetIn:     rrdValue(params, "netin"),
+		NetOut:    rrdValue(params, "netout"),
+	}
+}
+
+func rrdTime(params map[string]interface{}) time.Time {
+	if seconds, isSet := params["time"].(float64); isSet {
+		return time.Unix(int64(seconds), 0).UTC()
+	}
+	return time.Time{}
+}
+
+// Missing samples are either left out or null, some versions of Proxmox send numbers as strings.
+func rrdValue(params map[string]interface{}, key string) *float64 {
+	switch value := params[key].(type) {
+	case float64:
+		return &value
+	case string:
+		if number, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(number) {
+			return &number
+		}
+	}
+	return nil
+}
+
+func (c *Client) getRRDData(ctx context.Context, url string, opts RRDOptions) ([]interface{}, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return c.GetItemListInterfaceArray(ctx, url+"/rrddata?"+ParamsToValues(opts.mapToApiValues()).Encode())
+}
+
+// GetRRDData returns the historical usage of the qemu or lxc guest, ordered by time.
+func (c *Client) GetRRDData(ctx context.Context, vmr *VmRef, opts RRDOptions) ([]GuestRRDPoint, error) {
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	list, err := c.getRRDData(ctx, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId), opts)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]GuestRRDPoint, len(list))
+	for i, e := range list {
+		points[i] = GuestRRDPoint{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return points, nil
+}
+
+// GetNodeRRDData returns the historical usage of the node, ordered by time.
+func (c *Client) GetNodeRRDData(ctx context.Context, node string, opts RRDOptions) ([]NodeRRDPoint, error) {
+	list, err := c.getRRDData(ctx, "/nodes/"+node, opts)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]NodeRRDPoint, len(list))
+	for i, e := range list {
+		points[i] = NodeRRDPoint{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return points, nil
+}
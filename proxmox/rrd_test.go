@@ -0,0 +1,47 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RRDOptions(t *testing.T) {
+	require.Equal(t, "cf=MAX&timeframe=day", ParamsToValues(RRDOptions{Timeframe: RRDTimeframe_Day, Consolidation: RRDConsolidation_Max}.mapToApiValues()).Encode())
+	require.Equal(t, "timeframe=hour", ParamsToValues(RRDOptions{Timeframe: RRDTimeframe_Hour}.mapToApiValues()).Encode())
+	require.NoError(t, RRDOptions{Timeframe: RRDTimeframe_Year}.Validate())
+	require.Error(t, RRDOptions{}.Validate())
+	require.Error(t, RRDOptions{Timeframe: "decade"}.Validate())
+	require.Error(t, RRDOptions{Timeframe: RRDTimeframe_Week, Consolidation: "MIN"}.Validate())
+}
+
+func Test_GuestRRDPoint_mapToStruct(t *testing.T) {
+	point := GuestRRDPoint{}.mapToStruct(map[string]interface{}{
+		"time":   float64(1700000000),
+		"cpu":    0.25,
+		"maxcpu": float64(2),
+		"netin":  "1024.5",
+		"mem":    nil,
+	})
+	require.Equal(t, time.Unix(1700000000, 0).UTC(), point.Time)
+	require.Equal(t, 0.25, *point.CPU)
+	require.Equal(t, float64(2), *point.MaxCPU)
+	require.Equal(t, 1024.5, *point.NetIn)
+	require.Nil(t, point.Mem)
+	require.Nil(t, point.DiskRead)
+}
+
+func Test_NodeRRDPoint_mapToStruct(t *testing.T) {
+	point := NodeRRDPoint{}.mapToStruct(map[string]interface{}{
+		"time":     float64(1700000060),
+		"loadavg":  1.5,
+		"memused":  float64(1 << 30),
+		"swapused": "NaN",
+	})
+	require.Equal(t, time.Unix(1700000060, 0).UTC(), point.Time)
+	require.Equal(t, 1.5, *point.LoadAvg)
+	require.Equal(t, float64(1<<30), *point.MemUsed)
+	require.Nil(t, point.SwapUsed)
+	require.Nil(t, point.CPU)
+}
@@ -0,0 +1,249 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Proxmox uses its own ipam when a zone has none configured.
+	sdnDefaultIpam        = "pve"
+	sdnIPAllocateAttempts = 10
+)
+
+// Address allocated in the ipam of an SDN zone.
+type SdnIpamEntry struct {
+	Zone   SdnZoneName `json:"zone"`
+	Vnet   SdnVnetName `json:"vnet"`
+	Subnet string      `json:"subnet"`
+	IP     string      `json:"ip"`
+	Mac    string      `json:"mac,omitempty"`
+	// Hostname and VmID are set when the address belongs to a guest.
+	Hostname string `json:"hostname,omitempty"`
+	VmID     uint   `json:"vmid,omitempty"`
+	Gateway  bool   `json:"gateway"`
+}
+
+func (SdnIpamEntry) mapToStruct(params map[string]interface{}) *SdnIpamEntry {
+	entry := SdnIpamEntry{}
+	if _, isSet := params["zone"]; isSet {
+		entry.Zone = SdnZoneName(params["zone"].(string))
+	}
+	if _, isSet := params["vnet"]; isSet {
+		entry.Vnet = SdnVnetName(params["vnet"].(string))
+	}
+	if _, isSet := params["subnet"]; isSet {
+		entry.Subnet = params["subnet"].(string)
+	}
+	if _, isSet := params["ip"]; isSet {
+		entry.IP = params["ip"].(string)
+	}
+	if _, isSet := params["mac"]; isSet {
+		entry.Mac = params["mac"].(string)
+	}
+	if _, isSet := params["hostname"]; isSet {
+		entry.Hostname = params["hostname"].(string)
+	}
+	switch vmid := params["vmid"].(type) {
+	case float64:
+		entry.VmID = uint(vmid)
+	case string:
+		if id, err := strconv.ParseUint(vmid, 10, 32); err == nil {
+			entry.VmID = uint(id)
+		}
+	}
+	if _, isSet := params["gateway"]; isSet {
+		entry.Gateway = Itob(int(params["gateway"].(float64)))
+	}
+	return &entry
+}
+
+// ListSdnIpamEntries returns all addresses allocated in the ipam, "pve" is the built in ipam.
+func (c *Client) ListSdnIpamEntries(ctx context.Context, ipam string) ([]SdnIpamEntry, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if ipam == "" {
+		return nil, errors.New("ipam may not be empty")
+	}
+	list, err := c.GetItemListInterfaceArray(ctx, sdnUrl+"/ipams/"+ipam+"/status")
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]SdnIpamEntry, len(list))
+	for i, e := range list {
+		entries[i] = *SdnIpamEntry{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return entries, nil
+}
+
+// ListSdnVnetIPs returns the addresses allocated in the vnet, including the dhcp mappings of guests.
+func (c *Client) ListSdnVnetIPs(ctx context.Context, vnet SdnVnetName) ([]SdnIpamEntry, error) {
+	zone, err := c.sdnVnetZone(ctx, vnet)
+	if err != nil {
+		return nil, err
+	}
+	ipam := zone.Ipam
+	if ipam == "" {
+		ipam = sdnDefaultIpam
+	}
+	entries, err := c.ListSdnIpamEntries(ctx, ipam)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []SdnIpamEntry{}
+	for _, e := range entries {
+		if e.Vnet == vnet {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered, nil
+}
+
+// AllocateSdnIP registers the address for the mac in the ipam of the vnet.
+func (c *Client) AllocateSdnIP(ctx context.Context, vnet SdnVnetName, ip, mac string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.sdnIPParams(ctx, vnet, ip, mac)
+	if err != nil {
+		return err
+	}
+	return c.Post(ctx, params, sdnVnetUrl(vnet)+"/ips")
+}
+
+// ReleaseSdnIP removes the address of the mac from the ipam of the vnet.
+func (c *Client) ReleaseSdnIP(ctx context.Context, vnet SdnVnetName, ip, mac string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.sdnIPParams(ctx, vnet, ip, mac)
+	if err != nil {
+		return err
+	}
+	values := ParamsToValues(params)
+	_, err = c.session.Delete(ctx, sdnVnetUrl(vnet)+"/ips", &values, nil)
+	return err
+}
+
+// RequestNextFreeSdnIP allocates the first free address of the subnet for the mac and returns it.
+// Addresses are taken from the dhcp ranges of the subnet, or from the whole subnet when it has none.
+// When another client takes the same address first, the next free address is tried.
+func (c *Client) RequestNextFreeSdnIP(ctx context.Context, vnet SdnVnetName, cidr, mac string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	subnets, err := c.ListSdnSubnets(ctx, vnet)
+	if err != nil {
+		return "", err
+	}
+	var subnet *ConfigSdnSubnet
+	for i := range subnets {
+		if subnets[i].CIDR == cidr {
+			subnet = &subnets[i]
+			break
+		}
+	}
+	if subnet == nil {
+		return "", errors.New("vnet " + string(vnet) + " has no subnet " + cidr)
+	}
+	used := map[string]bool{}
+	for attempt := 0; attempt < sdnIPAllocateAttempts; attempt++ {
+		entries, err := c.ListSdnVnetIPs(ctx, vnet)
+		if err != nil {
+			return "", err
+		}
+		for _, e := range entries {
+			used[e.IP] = true
+		}
+		ip, err := nextFreeSdnIP(*subnet, used)
+		if err != nil {
+			return "", err
+		}
+		err = c.AllocateSdnIP(ctx, vnet, ip, mac)
+		if err == nil {
+			return ip, nil
+		}
+		if !strings.Contains(err.Error(), "already exist") {
+			return "", err
+		}
+		used[ip] = true
+	}
+	return "", errors.New("no free address could be allocated in subnet " + cidr)
+}
+
+func (c *Client) sdnVnetZone(ctx context.Context, vnet SdnVnetName) (*ConfigSdnZone, error) {
+	config, err := c.GetSdnVnet(ctx, vnet)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetSdnZone(ctx, config.Zone)
+}
+
+func (c *Client) sdnIPParams(ctx context.Context, vnet SdnVnetName, ip, mac string) (map[string]interface{}, error) {
+	if net.ParseIP(ip) == nil {
+		return nil, errors.New("ip (" + ip + ") must be an IPv4 or IPv6 address")
+	}
+	if _, err := net.ParseMAC(mac); err != nil {
+		return nil, errors.New("mac (" + mac + ") must be a valid mac address")
+	}
+	config, err := c.GetSdnVnet(ctx, vnet)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"zone": string(config.Zone), "ip": ip, "mac": mac}, nil
+}
+
+// Returns the first address of the subnet that is not used, skipping the network, gateway and IPv4 broadcast address.
+func nextFreeSdnIP(subnet ConfigSdnSubnet, used map[string]bool) (string, error) {
+	_, network, err := net.ParseCIDR(subnet.CIDR)
+	if err != nil {
+		return "", err
+	}
+	ranges := subnet.DhcpRanges
+	if len(ranges) == 0 {
+		ranges = []SdnDhcpRange{{Start: network.IP.String(), End: lastIP(network).String()}}
+	}
+	broadcast := ""
+	if network.IP.To4() != nil {
+		broadcast = lastIP(network).String()
+	}
+	for _, r := range ranges {
+		end := net.ParseIP(r.End)
+		for ip := net.ParseIP(r.Start); ip != nil && compareIP(ip, end) <= 0; ip = nextIP(ip) {
+			address := ip.String()
+			if used[address] || address == subnet.Gateway || address == broadcast || ip.Equal(network.IP) {
+				continue
+			}
+			return address, nil
+		}
+	}
+	return "", errors.New("subnet " + subnet.CIDR + " has no free address")
+}
+
+// Returns the address after ip, or nil when ip is the last address.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	if v4 := next.To4(); v4 != nil {
+		next = v4
+	}
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next
+		}
+	}
+	return nil
+}
+
+func lastIP(network *net.IPNet) net.IP {
+	last := make(net.IP, len(network.IP))
+	for i := range network.IP {
+		last[i] = network.IP[i] | ^network.Mask[i]
+	}
+	return last
+}
@@ -0,0 +1,47 @@
+package proxmox
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SdnIpamEntry_mapToStruct(t *testing.T) {
+	require.Equal(t, &SdnIpamEntry{Zone: "lan", Vnet: "web", Subnet: "10.0.0.0/24", IP: "10.0.0.5", Mac: "BC:24:11:00:00:01", Hostname: "web1", VmID: 100},
+		SdnIpamEntry{}.mapToStruct(map[string]interface{}{
+			"zone":     "lan",
+			"vnet":     "web",
+			"subnet":   "10.0.0.0/24",
+			"ip":       "10.0.0.5",
+			"mac":      "BC:24:11:00:00:01",
+			"hostname": "web1",
+			"vmid":     "100",
+		}))
+	require.Equal(t, &SdnIpamEntry{Zone: "lan", IP: "10.0.0.1", Gateway: true, VmID: 101},
+		SdnIpamEntry{}.mapToStruct(map[string]interface{}{"zone": "lan", "ip": "10.0.0.1", "gateway": float64(1), "vmid": float64(101)}))
+}
+
+func Test_nextFreeSdnIP(t *testing.T) {
+	subnet := ConfigSdnSubnet{CIDR: "10.0.0.0/30", Gateway: "10.0.0.1"}
+	ip, err := nextFreeSdnIP(subnet, map[string]bool{})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.2", ip)
+	_, err = nextFreeSdnIP(subnet, map[string]bool{"10.0.0.2": true})
+	require.Error(t, err)
+
+	ranges := ConfigSdnSubnet{CIDR: "10.0.0.0/24", DhcpRanges: []SdnDhcpRange{{Start: "10.0.0.10", End: "10.0.0.11"}, {Start: "10.0.0.50", End: "10.0.0.60"}}}
+	ip, err = nextFreeSdnIP(ranges, map[string]bool{"10.0.0.10": true, "10.0.0.11": true})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.50", ip)
+
+	ip, err = nextFreeSdnIP(ConfigSdnSubnet{CIDR: "fd00::/64", Gateway: "fd00::1"}, map[string]bool{"fd00::2": true})
+	require.NoError(t, err)
+	require.Equal(t, "fd00::3", ip)
+}
+
+func Test_nextIP(t *testing.T) {
+	require.Equal(t, "10.0.1.0", nextIP(net.ParseIP("10.0.0.255")).String())
+	require.Equal(t, "fd00::1:0", nextIP(net.ParseIP("fd00::ffff")).String())
+	require.Nil(t, nextIP(net.ParseIP("255.255.255.255")))
+}
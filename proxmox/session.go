@@ -140,6 +140,12 @@ func ParamsToValuesWithAllEmpty(params map[string]interface{}, allowedEmpty []st
 			} else {
 				v = "0"
 			}
+		// Array parameters are sent as the same key repeated for every value.
+		case []string:
+			if len(intrV) > 0 {
+				vals[k] = intrV
+			}
+			continue
 		default:
 			v = fmt.Sprintf("%v", intrV)
 		}
@@ -29,6 +29,13 @@ func TestParamsTo(t *testing.T) {
 			"comment": "",
 		},
 		output: []string{"poolid=test"},
+	}, {
+		name: "array_values_are_repeated",
+		input: map[string]interface{}{
+			"range": []string{"a", "b"},
+			"empty": []string{},
+		},
+		output: []string{"range=a&range=b"},
 	}}
 
 	for _, test := range tests {
@@ -2,8 +2,10 @@ package proxmox
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strconv"
 )
 
@@ -21,21 +23,33 @@ func (config *ConfigSnapshot) mapToApiValues() map[string]interface{} {
 	}
 }
 
-func (config *ConfigSnapshot) CreateSnapshot(ctx context.Context, c *Client, guestId uint) (err error) {
-	if ctx == nil {
-		ctx = context.Background()
+// Proxmox config ids, used for snapshot names and backup job ids.
+var rxConfigID = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]{1,39}$`)
+
+func ValidateSnapshotName(name string) error {
+	if name == snapshotCurrent {
+		return errors.New("snapshot name may not be " + snapshotCurrent)
 	}
-	params := config.mapToApiValues()
-	vmr := NewVmRef(int(guestId))
-	_, err = c.GetVmInfo(ctx, vmr)
-	if err != nil {
-		return
+	if !rxConfigID.MatchString(name) {
+		return errors.New("snapshot name must start with a letter, only contain letters, numbers, '-' and '_' and be 2 to 40 characters long")
 	}
-	_, err = c.PostWithTask(ctx, params, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/snapshot/")
-	if err != nil {
-		params, _ := json.Marshal(&params)
-		return fmt.Errorf("error creating Snapshot: %v, (params: %v)", err, string(params))
+	return nil
+}
+
+// Only QEMU guests can include the RAM in a snapshot.
+func (config *ConfigSnapshot) Validate(vmType string) error {
+	if err := ValidateSnapshotName(config.Name); err != nil {
+		return err
 	}
+	if config.VmState && vmType != "qemu" {
+		return errors.New("vmstate is only supported for qemu guests")
+	}
+	return nil
+}
+
+// Deprecated: use Client.CreateSnapshot instead.
+func (config *ConfigSnapshot) CreateSnapshot(ctx context.Context, c *Client, guestId uint) (err error) {
+	_, err = c.CreateSnapshot(ctx, NewVmRef(int(guestId)), *config)
 	return
 }
 
@@ -62,26 +76,16 @@ func UpdateSnapshotDescription(ctx context.Context, c *Client, vmr *VmRef, snaps
 	return c.Put(ctx, map[string]interface{}{"description": description}, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/snapshot/"+snapshot+"/config")
 }
 
+// Deprecated: use Client.DeleteSnapshot instead.
 func DeleteSnapshot(ctx context.Context, c *Client, vmr *VmRef, snapshot string) (exitStatus string, err error) {
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	err = c.CheckVmRef(ctx, vmr)
-	if err != nil {
-		return
-	}
-	return c.DeleteWithTask(ctx, "/nodes/" + vmr.node + "/" + vmr.vmType + "/" + strconv.Itoa(vmr.vmId) + "/snapshot/" + snapshot)
+	_, exitStatus, err = c.deleteSnapshot(ctx, vmr, snapshot)
+	return
 }
 
+// Deprecated: use Client.RollbackSnapshot instead.
 func RollbackSnapshot(ctx context.Context, c *Client, vmr *VmRef, snapshot string) (exitStatus string, err error) {
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	err = c.CheckVmRef(ctx, vmr)
-	if err != nil {
-		return
-	}
-	return c.PostWithTask(ctx, nil, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/snapshot/"+snapshot+"/rollback")
+	_, exitStatus, err = c.rollbackSnapshot(ctx, vmr, snapshot)
+	return
 }
 
 // Used for formatting the output when retrieving snapshots
@@ -120,7 +124,10 @@ func FormatSnapshotsList(taskResponse []interface{}) (list []*Snapshot) {
 
 // Formats a list of snapshots as a tree of snapshots
 func FormatSnapshotsTree(taskResponse []interface{}) (tree []*Snapshot) {
-	list := FormatSnapshotsList(taskResponse)
+	return snapshotsTree(FormatSnapshotsList(taskResponse))
+}
+
+func snapshotsTree(list []*Snapshot) (tree []*Snapshot) {
 	for _, e := range list {
 		for _, ee := range list {
 			if e.Parent == ee.Name {
@@ -137,3 +144,182 @@ func FormatSnapshotsTree(taskResponse []interface{}) (tree []*Snapshot) {
 	}
 	return
 }
+
+// Name of the pseudo snapshot that represents the active state of the guest in the list of snapshots.
+const snapshotCurrent = "current"
+
+// Removes the "current" pseudo snapshot, which is the active config and not a snapshot.
+func filterSnapshots(list []*Snapshot) (snapshots []*Snapshot) {
+	snapshots = []*Snapshot{}
+	for _, e := range list {
+		if e.Name != snapshotCurrent {
+			snapshots = append(snapshots, e)
+		}
+	}
+	return
+}
+
+// NewConfigQemuWithSnapshotsFromApi returns the active config of the guest and its snapshots separately.
+// The config never contains settings of a snapshot, so it can be compared against the desired state regardless of the snapshots.
+func NewConfigQemuWithSnapshotsFromApi(ctx context.Context, vmr *VmRef, client *Client) (config *ConfigQemu, snapshots []*Snapshot, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	config, err = NewConfigQemuFromApi(ctx, vmr, client)
+	if err != nil {
+		return
+	}
+	taskResponse, err := ListSnapshots(ctx, client, vmr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return config, filterSnapshots(FormatSnapshotsList(taskResponse)), nil
+}
+
+func snapshotUrl(vmr *VmRef) string {
+	return "/nodes/" + vmr.node + "/" + vmr.vmType + "/" + strconv.Itoa(vmr.vmId) + "/snapshot"
+}
+
+// Returns the UPID and exit status of the snapshot task and waits for it to complete.
+func (c *Client) snapshotTask(ctx context.Context, resp *http.Response, err error, action string) (upid, exitStatus string, _ error) {
+	if err != nil {
+		return "", "", fmt.Errorf("error %s snapshot: %v, error status: %s", action, err, c.HandleTaskError(resp))
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return "", "", err
+	}
+	upid, _ = taskResponse["data"].(string)
+	exitStatus, err = c.WaitForCompletion(ctx, taskResponse)
+	return upid, exitStatus, err
+}
+
+// CreateSnapshot creates a snapshot of the QEMU or LXC guest.
+// Returns the UPID of the snapshot task.
+func (c *Client) CreateSnapshot(ctx context.Context, vmr *VmRef, config ConfigSnapshot) (upid string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = c.CheckVmRef(ctx, vmr); err != nil {
+		return
+	}
+	if err = config.Validate(vmr.vmType); err != nil {
+		return
+	}
+	params := config.mapToApiValues()
+	if vmr.vmType != "qemu" {
+		// LXC does not know the vmstate parameter
+		delete(params, "vmstate")
+	}
+	reqbody := ParamsToBody(params)
+	resp, err := c.session.Post(ctx, snapshotUrl(vmr), nil, nil, &reqbody)
+	upid, _, err = c.snapshotTask(ctx, resp, err, "creating")
+	return
+}
+
+// ListSnapshots returns the snapshots of the QEMU or LXC guest, without the "current" pseudo snapshot.
+func (c *Client) ListSnapshots(ctx context.Context, vmr *VmRef) ([]*Snapshot, error) {
+	taskResponse, err := ListSnapshots(ctx, c, vmr)
+	if err != nil {
+		return nil, err
+	}
+	return filterSnapshots(FormatSnapshotsList(taskResponse)), nil
+}
+
+// GetSnapshotTree returns the snapshots of the QEMU or LXC guest as a tree,
+// every snapshot holds the snapshots taken on top of it as its children.
+func (c *Client) GetSnapshotTree(ctx context.Context, vmr *VmRef) ([]*Snapshot, error) {
+	snapshots, err := c.ListSnapshots(ctx, vmr)
+	if err != nil {
+		return nil, err
+	}
+	return snapshotsTree(snapshots), nil
+}
+
+// DeleteSnapshot deletes the snapshot of the QEMU or LXC guest.
+// Returns the UPID of the delete task.
+func (c *Client) DeleteSnapshot(ctx context.Context, vmr *VmRef, snapshot string) (upid string, err error) {
+	upid, _, err = c.deleteSnapshot(ctx, vmr, snapshot)
+	return
+}
+
+func (c *Client) deleteSnapshot(ctx context.Context, vmr *VmRef, snapshot string) (upid, exitStatus string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = c.CheckVmRef(ctx, vmr); err != nil {
+		return
+	}
+	if err = ValidateSnapshotName(snapshot); err != nil {
+		return
+	}
+	resp, err := c.session.Delete(ctx, snapshotUrl(vmr)+"/"+snapshot, nil, nil)
+	return c.snapshotTask(ctx, resp, err, "deleting")
+}
+
+// RollbackSnapshot reverts the QEMU or LXC guest to the state of the snapshot.
+// Returns the UPID of the rollback task.
+func (c *Client) RollbackSnapshot(ctx context.Context, vmr *VmRef, snapshot string) (upid string, err error) {
+	upid, _, err = c.rollbackSnapshot(ctx, vmr, snapshot)
+	return
+}
+
+func (c *Client) rollbackSnapshot(ctx context.Context, vmr *VmRef, snapshot string) (upid, exitStatus string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err = c.CheckVmRef(ctx, vmr); err != nil {
+		return
+	}
+	if err = ValidateSnapshotName(snapshot); err != nil {
+		return
+	}
+	resp, err := c.session.Post(ctx, snapshotUrl(vmr)+"/"+snapshot+"/rollback", nil, nil, nil)
+	return c.snapshotTask(ctx, resp, err, "rolling back")
+}
+
+// GetSnapshotConfig returns the raw config of the QEMU or LXC guest as it was captured in the snapshot.
+func (c *Client) GetSnapshotConfig(ctx context.Context, vmr *VmRef, snapshot string) (map[string]interface{}, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := c.CheckVmRef(ctx, vmr); err != nil {
+		return nil, err
+	}
+	if err := ValidateSnapshotName(snapshot); err != nil {
+		return nil, err
+	}
+	return c.GetItemConfigMapStringInterface(ctx, snapshotUrl(vmr)+"/"+snapshot+"/config", "snapshot", "CONFIG")
+}
+
+// GetQemuSnapshotConfig returns the config the QEMU guest would be rolled back to.
+func (c *Client) GetQemuSnapshotConfig(ctx context.Context, vmr *VmRef, snapshot string) (*ConfigQemu, error) {
+	vmConfig, err := c.GetSnapshotConfig(ctx, vmr, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if vmr.vmType != "qemu" {
+		return nil, fmt.Errorf("guest %d is not a qemu guest", vmr.vmId)
+	}
+	return newConfigQemuFromApiConfig(ctx, vmr, c, vmConfig)
+}
+
+// GetLxcSnapshotConfig returns the config the LXC container would be rolled back to.
+func (c *Client) GetLxcSnapshotConfig(ctx context.Context, vmr *VmRef, snapshot string) (*ConfigLxc, error) {
+	lxcConfig, err := c.GetSnapshotConfig(ctx, vmr, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if vmr.vmType != "lxc" {
+		return nil, fmt.Errorf("guest %d is not an lxc container", vmr.vmId)
+	}
+	return newConfigLxcFromApiConfig(ctx, vmr, c, lxcConfig)
+}
+
+// UpdateSnapshotDescription changes the description of an existing snapshot of the QEMU or LXC guest.
+func (c *Client) UpdateSnapshotDescription(ctx context.Context, vmr *VmRef, snapshot, description string) error {
+	if err := ValidateSnapshotName(snapshot); err != nil {
+		return err
+	}
+	return UpdateSnapshotDescription(ctx, c, vmr, snapshot, description)
+}
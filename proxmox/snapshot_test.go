@@ -204,3 +204,31 @@ func test_FormatSnapshotsList_Output() []string {
 		"name":"bba","time":1666362071,"parent":"bb"},{
 		"name":"bbb","time":1666362062,"parent":"bb"}]`}
 }
+
+func Test_filterSnapshots(t *testing.T) {
+	list := []*Snapshot{{Name: "aa"}, {Name: "current", Parent: "aa"}, {Name: "bb", Parent: "aa"}}
+	require.Equal(t, []*Snapshot{{Name: "aa"}, {Name: "bb", Parent: "aa"}}, filterSnapshots(list))
+	require.Equal(t, []*Snapshot{}, filterSnapshots([]*Snapshot{{Name: "current"}}))
+}
+
+func Test_ConfigSnapshot_Validate(t *testing.T) {
+	require.NoError(t, (&ConfigSnapshot{Name: "before-upgrade_1"}).Validate("lxc"))
+	require.NoError(t, (&ConfigSnapshot{Name: "ram", VmState: true}).Validate("qemu"))
+	require.Error(t, (&ConfigSnapshot{Name: "ram", VmState: true}).Validate("lxc"))
+	for _, e := range []string{"", "a", "1abc", "has space", "dot.name", snapshotCurrent, "a2345678901234567890123456789012345678901"} {
+		require.Error(t, ValidateSnapshotName(e), e)
+	}
+}
+
+func Test_snapshotsTree_withoutCurrent(t *testing.T) {
+	tree := snapshotsTree(filterSnapshots(FormatSnapshotsList([]interface{}{
+		map[string]interface{}{"name": "base", "snaptime": float64(1)},
+		map[string]interface{}{"name": "child", "snaptime": float64(2), "parent": "base"},
+		map[string]interface{}{"name": snapshotCurrent, "parent": "child"},
+	})))
+	require.Len(t, tree, 1)
+	require.Equal(t, "base", tree[0].Name)
+	require.Len(t, tree[0].Children, 1)
+	require.Equal(t, "child", tree[0].Children[0].Name)
+	require.Nil(t, tree[0].Children[0].Children)
+}
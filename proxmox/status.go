@@ -0,0 +1,358 @@
+package proxmox
+
+import (
+	"context"
+	"sort"
+	"strconv"
+)
+
+// Current status shared by QEMU virtual machines and LXC containers, sizes are in bytes and uptime in seconds.
+type GuestStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"` // running or stopped
+	CPU       float64 `json:"cpu"`    // fraction of CPUs in use
+	CPUs      uint    `json:"cpus"`
+	Mem       uint64  `json:"mem"`
+	MaxMem    uint64  `json:"maxmem"`
+	Disk      uint64  `json:"disk"`
+	MaxDisk   uint64  `json:"maxdisk"`
+	DiskRead  uint64  `json:"diskread"`
+	DiskWrite uint64  `json:"diskwrite"`
+	NetIn     uint64  `json:"netin"`
+	NetOut    uint64  `json:"netout"`
+	Uptime    uint64  `json:"uptime"`
+	PID       uint    `json:"pid,omitempty"`
+	// State of the guest in the HA manager, empty when the guest is not managed by HA.
+	HaState string `json:"ha_state,omitempty"`
+	Lock    string `json:"lock,omitempty"`
+}
+
+func (GuestStatus) mapToStruct(params map[string]interface{}) GuestStatus {
+	status := GuestStatus{}
+	if _, isSet := params["name"]; isSet {
+		status.Name = params["name"].(string)
+	}
+	if _, isSet := params["status"]; isSet {
+		status.Status = params["status"].(string)
+	}
+	if _, isSet := params["cpu"]; isSet {
+		status.CPU = params["cpu"].(float64)
+	}
+	if _, isSet := params["cpus"]; isSet {
+		status.CPUs = uint(params["cpus"].(float64))
+	}
+	if _, isSet := params["mem"]; isSet {
+		status.Mem = uint64(params["mem"].(float64))
+	}
+	if _, isSet := params["maxmem"]; isSet {
+		status.MaxMem = uint64(params["maxmem"].(float64))
+	}
+	if _, isSet := params["disk"]; isSet {
+		status.Disk = uint64(params["disk"].(float64))
+	}
+	if _, isSet := params["maxdisk"]; isSet {
+		status.MaxDisk = uint64(params["maxdisk"].(float64))
+	}
+	if _, isSet := params["diskread"]; isSet {
+		status.DiskRead = uint64(params["diskread"].(float64))
+	}
+	if _, isSet := params["diskwrite"]; isSet {
+		status.DiskWrite = uint64(params["diskwrite"].(float64))
+	}
+	if _, isSet := params["netin"]; isSet {
+		status.NetIn = uint64(params["netin"].(float64))
+	}
+	if _, isSet := params["netout"]; isSet {
+		status.NetOut = uint64(params["netout"].(float64))
+	}
+	if _, isSet := params["uptime"]; isSet {
+		status.Uptime = uint64(params["uptime"].(float64))
+	}
+	if _, isSet := params["pid"]; isSet {
+		status.PID = statusUint(params["pid"])
+	}
+	if ha, isSet := params["ha"].(map[string]interface{}); isSet {
+		if _, isSet := ha["state"]; isSet {
+			status.HaState = ha["state"].(string)
+		}
+	}
+	if _, isSet := params["lock"]; isSet {
+		status.Lock = params["lock"].(string)
+	}
+	return status
+}
+
+// Memory balloon statistics reported by the guest, sizes are in bytes.
+type VmBalloonStatus struct {
+	Actual          uint64 `json:"actual"`
+	MaxMem          uint64 `json:"max_mem"`
+	FreeMem         uint64 `json:"free_mem"`
+	TotalMem        uint64 `json:"total_mem"`
+	MajorPageFaults uint64 `json:"major_page_faults"`
+	MinorPageFaults uint64 `json:"minor_page_faults"`
+	MemSwappedIn    uint64 `json:"mem_swapped_in"`
+	MemSwappedOut   uint64 `json:"mem_swapped_out"`
+}
+
+func (VmBalloonStatus) mapToStruct(params map[string]interface{}) *VmBalloonStatus {
+	balloon := VmBalloonStatus{}
+	if _, isSet := params["actual"]; isSet {
+		balloon.Actual = uint64(params["actual"].(float64))
+	}
+	if _, isSet := params["max_mem"]; isSet {
+		balloon.MaxMem = uint64(params["max_mem"].(float64))
+	}
+	if _, isSet := params["free_mem"]; isSet {
+		balloon.FreeMem = uint64(params["free_mem"].(float64))
+	}
+	if _, isSet := params["total_mem"]; isSet {
+		balloon.TotalMem = uint64(params["total_mem"].(float64))
+	}
+	if _, isSet := params["major_page_faults"]; isSet {
+		balloon.MajorPageFaults = uint64(params["major_page_faults"].(float64))
+	}
+	if _, isSet := params["minor_page_faults"]; isSet {
+		balloon.MinorPageFaults = uint64(params["minor_page_faults"].(float64))
+	}
+	if _, isSet := params["mem_swapped_in"]; isSet {
+		balloon.MemSwappedIn = uint64(params["mem_swapped_in"].(float64))
+	}
+	if _, isSet := params["mem_swapped_out"]; isSet {
+		balloon.MemSwappedOut = uint64(params["mem_swapped_out"].(float64))
+	}
+	return &balloon
+}
+
+// I/O statistics of a disk of a running virtual machine.
+type VmBlockStat struct {
+	Device          string `json:"device"`
+	ReadBytes       uint64 `json:"rd_bytes"`
+	WriteBytes      uint64 `json:"wr_bytes"`
+	ReadOperations  uint64 `json:"rd_operations"`
+	WriteOperations uint64 `json:"wr_operations"`
+	FlushOperations uint64 `json:"flush_operations"`
+}
+
+func (VmBlockStat) mapToStruct(device string, params map[string]interface{}) VmBlockStat {
+	stat := VmBlockStat{Device: device}
+	if _, isSet := params["rd_bytes"]; isSet {
+		stat.ReadBytes = uint64(params["rd_bytes"].(float64))
+	}
+	if _, isSet := params["wr_bytes"]; isSet {
+		stat.WriteBytes = uint64(params["wr_bytes"].(float64))
+	}
+	if _, isSet := params["rd_operations"]; isSet {
+		stat.ReadOperations = uint64(params["rd_operations"].(float64))
+	}
+	if _, isSet := params["wr_operations"]; isSet {
+		stat.WriteOperations = uint64(params["wr_operations"].(float64))
+	}
+	if _, isSet := params["flush_operations"]; isSet {
+		stat.FlushOperations = uint64(params["flush_operations"].(float64))
+	}
+	return stat
+}
+
+type VmStatus struct {
+	GuestStatus
+	// Status reported by QEMU itself, e.g. running, paused or prelaunch.
+	QmpStatus string `json:"qmpstatus,omitempty"`
+	// The guest agent is enabled in the configuration.
+	Agent          bool             `json:"agent"`
+	RunningMachine string           `json:"running-machine,omitempty"`
+	RunningQemu    string           `json:"running-qemu,omitempty"`
+	Balloon        *VmBalloonStatus `json:"ballooninfo,omitempty"`
+	// Sorted by device, only present while the virtual machine is running.
+	BlockStat []VmBlockStat `json:"blockstat,omitempty"`
+}
+
+func (VmStatus) mapToStruct(params map[string]interface{}) *VmStatus {
+	status := VmStatus{GuestStatus: GuestStatus{}.mapToStruct(params)}
+	if _, isSet := params["qmpstatus"]; isSet {
+		status.QmpStatus = params["qmpstatus"].(string)
+	}
+	if _, isSet := params["agent"]; isSet {
+		status.Agent = statusUint(params["agent"]) == 1
+	}
+	if _, isSet := params["running-machine"]; isSet {
+		status.RunningMachine = params["running-machine"].(string)
+	}
+	if _, isSet := params["running-qemu"]; isSet {
+		status.RunningQemu = params["running-qemu"].(string)
+	}
+	if balloon, isSet := params["ballooninfo"].(map[string]interface{}); isSet {
+		status.Balloon = VmBalloonStatus{}.mapToStruct(balloon)
+	}
+	if blockStat, isSet := params["blockstat"].(map[string]interface{}); isSet {
+		devices := make([]string, 0, len(blockStat))
+		for device := range blockStat {
+			devices = append(devices, device)
+		}
+		sort.Strings(devices)
+		for _, device := range devices {
+			if stat, isMap := blockStat[device].(map[string]interface{}); isMap {
+				status.BlockStat = append(status.BlockStat, VmBlockStat{}.mapToStruct(device, stat))
+			}
+		}
+	}
+	return &status
+}
+
+type LxcStatus struct {
+	GuestStatus
+	Swap    uint64 `json:"swap"`
+	MaxSwap uint64 `json:"maxswap"`
+}
+
+func (LxcStatus) mapToStruct(params map[string]interface{}) *LxcStatus {
+	status := LxcStatus{GuestStatus: GuestStatus{}.mapToStruct(params)}
+	if _, isSet := params["swap"]; isSet {
+		status.Swap = uint64(params["swap"].(float64))
+	}
+	if _, isSet := params["maxswap"]; isSet {
+		status.MaxSwap = uint64(params["maxswap"].(float64))
+	}
+	return &status
+}
+
+// Total, used and free space in bytes.
+type NodeStatusUsage struct {
+	Total uint64 `json:"total"`
+	Used  uint64 `json:"used"`
+	Free  uint64 `json:"free"`
+}
+
+func (NodeStatusUsage) mapToStruct(params map[string]interface{}) NodeStatusUsage {
+	usage := NodeStatusUsage{}
+	if _, isSet := params["total"]; isSet {
+		usage.Total = uint64(params["total"].(float64))
+	}
+	if _, isSet := params["used"]; isSet {
+		usage.Used = uint64(params["used"].(float64))
+	}
+	if _, isSet := params["free"]; isSet {
+		usage.Free = uint64(params["free"].(float64))
+	}
+	return usage
+}
+
+type NodeCpuInfo struct {
+	Model   string `json:"model"`
+	Sockets uint   `json:"sockets"`
+	Cores   uint   `json:"cores"`
+	CPUs    uint   `json:"cpus"`
+	MHz     string `json:"mhz"`
+}
+
+type NodeStatus struct {
+	CPU float64 `json:"cpu"` // fraction of all cpus in use
+	// Fraction of time spent waiting on I/O.
+	IOWait float64 `json:"wait"`
+	// Load average over 1, 5 and 15 minutes.
+	LoadAvg       []float64       `json:"loadavg"`
+	Uptime        uint64          `json:"uptime"`
+	Memory        NodeStatusUsage `json:"memory"`
+	Swap          NodeStatusUsage `json:"swap"`
+	RootFS        NodeStatusUsage `json:"rootfs"`
+	CpuInfo       NodeCpuInfo     `json:"cpuinfo"`
+	KernelVersion string          `json:"kversion"`
+	PveVersion    string          `json:"pveversion"`
+}
+
+func (NodeStatus) mapToStruct(params map[string]interface{}) *NodeStatus {
+	status := NodeStatus{}
+	if _, isSet := params["cpu"]; isSet {
+		status.CPU = params["cpu"].(float64)
+	}
+	if _, isSet := params["wait"]; isSet {
+		status.IOWait = params["wait"].(float64)
+	}
+	if loadAvg, isSet := params["loadavg"].([]interface{}); isSet {
+		status.LoadAvg = make([]float64, 0, len(loadAvg))
+		for _, e := range loadAvg {
+			// Proxmox returns the load averages as strings.
+			if load, err := strconv.ParseFloat(e.(string), 64); err == nil {
+				status.LoadAvg = append(status.LoadAvg, load)
+			}
+		}
+	}
+	if _, isSet := params["uptime"]; isSet {
+		status.Uptime = uint64(params["uptime"].(float64))
+	}
+	if memory, isSet := params["memory"].(map[string]interface{}); isSet {
+		status.Memory = NodeStatusUsage{}.mapToStruct(memory)
+	}
+	if swap, isSet := params["swap"].(map[string]interface{}); isSet {
+		status.Swap = NodeStatusUsage{}.mapToStruct(swap)
+	}
+	if rootfs, isSet := params["rootfs"].(map[string]interface{}); isSet {
+		status.RootFS = NodeStatusUsage{}.mapToStruct(rootfs)
+	}
+	if cpuInfo, isSet := params["cpuinfo"].(map[string]interface{}); isSet {
+		if _, isSet := cpuInfo["model"]; isSet {
+			status.CpuInfo.Model = cpuInfo["model"].(string)
+		}
+		if _, isSet := cpuInfo["sockets"]; isSet {
+			status.CpuInfo.Sockets = uint(cpuInfo["sockets"].(float64))
+		}
+		if _, isSet := cpuInfo["cores"]; isSet {
+			status.CpuInfo.Cores = uint(cpuInfo["cores"].(float64))
+		}
+		if _, isSet := cpuInfo["cpus"]; isSet {
+			status.CpuInfo.CPUs = uint(cpuInfo["cpus"].(float64))
+		}
+		if _, isSet := cpuInfo["mhz"]; isSet {
+			status.CpuInfo.MHz = cpuInfo["mhz"].(string)
+		}
+	}
+	if _, isSet := params["kversion"]; isSet {
+		status.KernelVersion = params["kversion"].(string)
+	}
+	if _, isSet := params["pveversion"]; isSet {
+		status.PveVersion = params["pveversion"].(string)
+	}
+	return &status
+}
+
+// Proxmox returns some numbers as strings, e.g. the pid and the agent flag.
+func statusUint(value interface{}) uint {
+	switch value := value.(type) {
+	case float64:
+		return uint(value)
+	case string:
+		if number, err := strconv.ParseUint(value, 10, 32); err == nil {
+			return uint(number)
+		}
+	}
+	return 0
+}
+
+// GetVmStatus returns the current status of the QEMU virtual machine.
+func (c *Client) GetVmStatus(ctx context.Context, vmr *VmRef) (*VmStatus, error) {
+	params, err := c.GetVmState(ctx, vmr)
+	if err != nil {
+		return nil, err
+	}
+	return VmStatus{}.mapToStruct(params), nil
+}
+
+// GetLxcStatus returns the current status of the LXC container.
+func (c *Client) GetLxcStatus(ctx context.Context, vmr *VmRef) (*LxcStatus, error) {
+	params, err := c.GetVmState(ctx, vmr)
+	if err != nil {
+		return nil, err
+	}
+	return LxcStatus{}.mapToStruct(params), nil
+}
+
+// GetNodeStatus returns the current usage and versions of the node.
+func (c *Client) GetNodeStatus(ctx context.Context, node string) (*NodeStatus, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, "/nodes/"+node+"/status", "node status", "STATUS")
+	if err != nil {
+		return nil, err
+	}
+	return NodeStatus{}.mapToStruct(params), nil
+}
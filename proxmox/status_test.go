@@ -0,0 +1,86 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VmStatus_mapToStruct(t *testing.T) {
+	status := VmStatus{}.mapToStruct(map[string]interface{}{
+		"name":      "test",
+		"status":    "running",
+		"qmpstatus": "paused",
+		"cpu":       0.5,
+		"cpus":      float64(2),
+		"mem":       float64(1 << 30),
+		"maxmem":    float64(2 << 30),
+		"netin":     float64(100),
+		"uptime":    float64(3600),
+		"pid":       "1234",
+		"agent":     float64(1),
+		"ha":        map[string]interface{}{"managed": float64(1), "state": "started"},
+		"ballooninfo": map[string]interface{}{
+			"actual":   float64(1 << 30),
+			"free_mem": float64(512),
+		},
+		"blockstat": map[string]interface{}{
+			"scsi0": map[string]interface{}{"rd_bytes": float64(10), "wr_operations": float64(3)},
+			"ide2":  map[string]interface{}{"flush_operations": float64(1)},
+		},
+	})
+	require.Equal(t, "test", status.Name)
+	require.Equal(t, "running", status.Status)
+	require.Equal(t, "paused", status.QmpStatus)
+	require.Equal(t, 0.5, status.CPU)
+	require.Equal(t, uint(2), status.CPUs)
+	require.Equal(t, uint64(2<<30), status.MaxMem)
+	require.Equal(t, uint64(3600), status.Uptime)
+	require.Equal(t, uint(1234), status.PID)
+	require.True(t, status.Agent)
+	require.Equal(t, "started", status.HaState)
+	require.Equal(t, uint64(1<<30), status.Balloon.Actual)
+	require.Equal(t, uint64(512), status.Balloon.FreeMem)
+	require.Equal(t, []VmBlockStat{
+		{Device: "ide2", FlushOperations: 1},
+		{Device: "scsi0", ReadBytes: 10, WriteOperations: 3},
+	}, status.BlockStat)
+
+	stopped := VmStatus{}.mapToStruct(map[string]interface{}{"status": "stopped", "ha": map[string]interface{}{"managed": float64(0)}})
+	require.Nil(t, stopped.Balloon)
+	require.Nil(t, stopped.BlockStat)
+	require.Empty(t, stopped.HaState)
+}
+
+func Test_LxcStatus_mapToStruct(t *testing.T) {
+	status := LxcStatus{}.mapToStruct(map[string]interface{}{
+		"status":  "running",
+		"lock":    "backup",
+		"swap":    float64(256),
+		"maxswap": float64(512),
+	})
+	require.Equal(t, "running", status.Status)
+	require.Equal(t, "backup", status.Lock)
+	require.Equal(t, uint64(256), status.Swap)
+	require.Equal(t, uint64(512), status.MaxSwap)
+}
+
+func Test_NodeStatus_mapToStruct(t *testing.T) {
+	status := NodeStatus{}.mapToStruct(map[string]interface{}{
+		"cpu":        0.1,
+		"wait":       0.01,
+		"loadavg":    []interface{}{"0.50", "0.25", "0.10"},
+		"uptime":     float64(86400),
+		"memory":     map[string]interface{}{"total": float64(8 << 30), "used": float64(2 << 30), "free": float64(6 << 30)},
+		"rootfs":     map[string]interface{}{"total": float64(100), "used": float64(40), "free": float64(60), "avail": float64(55)},
+		"cpuinfo":    map[string]interface{}{"model": "Xeon", "sockets": float64(1), "cores": float64(4), "cpus": float64(8), "mhz": "2400.000"},
+		"kversion":   "Linux 6.8.12",
+		"pveversion": "pve-manager/8.2.4",
+	})
+	require.Equal(t, []float64{0.5, 0.25, 0.1}, status.LoadAvg)
+	require.Equal(t, 0.01, status.IOWait)
+	require.Equal(t, NodeStatusUsage{Total: 8 << 30, Used: 2 << 30, Free: 6 << 30}, status.Memory)
+	require.Equal(t, uint64(40), status.RootFS.Used)
+	require.Equal(t, NodeCpuInfo{Model: "Xeon", Sockets: 1, Cores: 4, CPUs: 8, MHz: "2400.000"}, status.CpuInfo)
+	require.Equal(t, "pve-manager/8.2.4", status.PveVersion)
+}
@@ -0,0 +1,102 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Capacity and state of a storage on a node, sizes are in bytes.
+type StorageStatus struct {
+	Storage   string        `json:"storage"`
+	Type      string        `json:"type"`
+	Total     uint64        `json:"total"`
+	Used      uint64        `json:"used"`
+	Available uint64        `json:"available"`
+	Active    bool          `json:"active"`
+	Enabled   bool          `json:"enabled"`
+	Shared    bool          `json:"shared"`
+	Content   []ContentType `json:"content"`
+}
+
+func (StorageStatus) mapToStruct(storage string, status map[string]interface{}) *StorageStatus {
+	result := StorageStatus{Storage: storage, Content: []ContentType{}}
+	if _, isSet := status["type"]; isSet {
+		result.Type = status["type"].(string)
+	}
+	if _, isSet := status["total"]; isSet {
+		result.Total = uint64(status["total"].(float64))
+	}
+	if _, isSet := status["used"]; isSet {
+		result.Used = uint64(status["used"].(float64))
+	}
+	if _, isSet := status["avail"]; isSet {
+		result.Available = uint64(status["avail"].(float64))
+	}
+	if _, isSet := status["active"]; isSet {
+		result.Active = Itob(int(status["active"].(float64)))
+	}
+	if _, isSet := status["enabled"]; isSet {
+		result.Enabled = Itob(int(status["enabled"].(float64)))
+	}
+	if _, isSet := status["shared"]; isSet {
+		result.Shared = Itob(int(status["shared"].(float64)))
+	}
+	if content, isSet := status["content"]; isSet && content != "" {
+		for _, e := range strings.Split(content.(string), ",") {
+			result.Content = append(result.Content, contentTypeFromApiValue(e))
+		}
+	}
+	return &result
+}
+
+// GetNodeStorageStatus returns the capacity and state of the storage on the node.
+// Unlike GetStorageStatus it does not require a guest on the node.
+func (c *Client) GetNodeStorageStatus(ctx context.Context, node, storage string) (*StorageStatus, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var data map[string]interface{}
+	err := c.GetJsonRetryable(ctx, fmt.Sprintf("/nodes/%s/storage/%s/status", node, storage), &data, requestNumberRetries)
+	if err != nil {
+		return nil, err
+	}
+	status, ok := data["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("storage STATUS not readable")
+	}
+	return StorageStatus{}.mapToStruct(storage, status), nil
+}
+
+// MostAvailableStorage returns the active and enabled storage with the most available space.
+// Returns nil when none of the storages is usable.
+func MostAvailableStorage(storages []StorageStatus) *StorageStatus {
+	var best *StorageStatus
+	for i := range storages {
+		if !storages[i].Active || !storages[i].Enabled {
+			continue
+		}
+		if best == nil || storages[i].Available > best.Available {
+			best = &storages[i]
+		}
+	}
+	return best
+}
+
+// PickStorageWithMostSpace returns the status of the storage on the node with the most available space.
+func (c *Client) PickStorageWithMostSpace(ctx context.Context, node string, storages []string) (*StorageStatus, error) {
+	statuses := make([]StorageStatus, len(storages))
+	for i, storage := range storages {
+		status, err := c.GetNodeStorageStatus(ctx, node, storage)
+		if err != nil {
+			return nil, err
+		}
+		statuses[i] = *status
+	}
+	best := MostAvailableStorage(statuses)
+	if best == nil {
+		return nil, errors.New("none of the storages (" + strings.Join(storages, ",") + ") is active and enabled")
+	}
+	return best, nil
+}
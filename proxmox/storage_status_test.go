@@ -0,0 +1,44 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StorageStatus_mapToStruct(t *testing.T) {
+	input := map[string]interface{}{
+		"active":  float64(1),
+		"avail":   float64(1000),
+		"content": "images,rootdir",
+		"enabled": float64(1),
+		"shared":  float64(0),
+		"total":   float64(3000),
+		"type":    "lvmthin",
+		"used":    float64(2000),
+	}
+	output := &StorageStatus{
+		Storage:   "local-lvm",
+		Type:      "lvmthin",
+		Total:     3000,
+		Used:      2000,
+		Available: 1000,
+		Active:    true,
+		Enabled:   true,
+		Content:   []ContentType{ContentType_DiskImage, ContentType_Container},
+	}
+	require.Equal(t, output, StorageStatus{}.mapToStruct("local-lvm", input))
+	require.Equal(t, &StorageStatus{Storage: "empty", Content: []ContentType{}}, StorageStatus{}.mapToStruct("empty", map[string]interface{}{}))
+}
+
+func Test_MostAvailableStorage(t *testing.T) {
+	storages := []StorageStatus{
+		{Storage: "a", Available: 100, Active: true, Enabled: true},
+		{Storage: "b", Available: 500, Active: false, Enabled: true},
+		{Storage: "c", Available: 300, Active: true, Enabled: true},
+		{Storage: "d", Available: 900, Active: true, Enabled: false},
+	}
+	require.Equal(t, "c", MostAvailableStorage(storages).Storage)
+	require.Nil(t, MostAvailableStorage(storages[1:2]))
+	require.Nil(t, MostAvailableStorage(nil))
+}
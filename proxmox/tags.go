@@ -0,0 +1,114 @@
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var rxTag = regexp.MustCompile(`^[a-z0-9_][a-z0-9_\-+.]*$`)
+
+// Parses the tags of a guest, Proxmox separates them by semicolons but older versions also used commas and spaces.
+// The tags are lowercased, deduplicated and sorted.
+func parseTags(tags string) []string {
+	return normalizeTags(strings.FieldsFunc(tags, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	}))
+}
+
+func normalizeTags(tags []string) []string {
+	unique := map[string]bool{}
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || unique[tag] {
+			continue
+		}
+		unique[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func formatTags(tags []string) string {
+	return strings.Join(normalizeTags(tags), ";")
+}
+
+// TagList returns the parsed tags of the virtual machine.
+func (config ConfigQemu) TagList() []string {
+	return parseTags(config.Tags)
+}
+
+// TagList returns the parsed tags of the container.
+func (config ConfigLxc) TagList() []string {
+	return parseTags(config.Tags)
+}
+
+func validateTag(tag string) error {
+	if !rxTag.MatchString(strings.ToLower(tag)) {
+		return fmt.Errorf("tag (%s) may only contain letters, numbers and (_-+.), and may not start with (-+.)", tag)
+	}
+	return nil
+}
+
+// AddTag adds the tags to the guest while keeping its existing tags.
+func (c *Client) AddTag(ctx context.Context, vmr *VmRef, tags ...string) error {
+	for _, tag := range tags {
+		if err := validateTag(tag); err != nil {
+			return err
+		}
+	}
+	return c.updateTags(ctx, vmr, func(current []string) []string {
+		return append(current, tags...)
+	})
+}
+
+// RemoveTag removes the tags from the guest while keeping its other tags.
+func (c *Client) RemoveTag(ctx context.Context, vmr *VmRef, tags ...string) error {
+	remove := map[string]bool{}
+	for _, tag := range tags {
+		remove[strings.ToLower(tag)] = true
+	}
+	return c.updateTags(ctx, vmr, func(current []string) []string {
+		kept := []string{}
+		for _, tag := range current {
+			if !remove[tag] {
+				kept = append(kept, tag)
+			}
+		}
+		return kept
+	})
+}
+
+// Read-modify-write of the guest tags, the digest makes Proxmox reject the update when the config changed in between.
+func (c *Client) updateTags(ctx context.Context, vmr *VmRef, modify func([]string) []string) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	vmConfig, err := c.GetVmConfig(ctx, vmr)
+	if err != nil {
+		return
+	}
+	current := []string{}
+	if _, isSet := vmConfig["tags"]; isSet {
+		current = parseTags(vmConfig["tags"].(string))
+	}
+	updated := normalizeTags(modify(current))
+	if strings.Join(current, ";") == strings.Join(updated, ";") {
+		return
+	}
+	params := map[string]interface{}{}
+	if len(updated) == 0 {
+		params["delete"] = "tags"
+	} else {
+		params["tags"] = strings.Join(updated, ";")
+	}
+	if digest, isSet := vmConfig["digest"]; isSet {
+		params["digest"] = digest
+	}
+	return c.Put(ctx, params, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/config")
+}
@@ -0,0 +1,41 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseTags(t *testing.T) {
+	testData := []struct {
+		input  string
+		output []string
+	}{
+		{input: "", output: []string{}},
+		{input: "web;db", output: []string{"db", "web"}},
+		{input: "web,DB Prod", output: []string{"db", "prod", "web"}},
+		{input: "web;;Web;web ", output: []string{"web"}},
+	}
+	for _, e := range testData {
+		require.Equal(t, e.output, parseTags(e.input), e.input)
+	}
+}
+
+func Test_TagList(t *testing.T) {
+	require.Equal(t, []string{"db", "web"}, ConfigQemu{Tags: "web;DB"}.TagList())
+	require.Equal(t, []string{"db", "web"}, ConfigLxc{Tags: "web,db"}.TagList())
+}
+
+func Test_formatTags(t *testing.T) {
+	require.Equal(t, "", formatTags(nil))
+	require.Equal(t, "a;b;c", formatTags([]string{"c", "A", "b", "a"}))
+}
+
+func Test_validateTag(t *testing.T) {
+	for _, tag := range []string{"web", "Prod", "k8s_node", "v1.2", "a-b+c", "_x"} {
+		require.NoError(t, validateTag(tag), tag)
+	}
+	for _, tag := range []string{"", "-web", ".x", "a;b", "a b", "é"} {
+		require.Error(t, validateTag(tag), tag)
+	}
+}
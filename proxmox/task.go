@@ -0,0 +1,312 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Number of log lines requested at once, proxmox returns 50 lines when no limit is given.
+const taskLogPageSize = 500
+
+func taskNode(upid string) (string, error) {
+	parsed, err := ParseUPID(upid)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Node, nil
+}
+
+func (c *Client) getTaskLogPage(ctx context.Context, node, upid string, start, limit uint) ([]string, error) {
+	params := map[string]interface{}{"start": start, "limit": limit}
+	lineList, err := c.GetItemListInterfaceArray(ctx, fmt.Sprintf("/nodes/%s/tasks/%s/log?%s", node, upid, ParamsToValues(params).Encode()))
+	if err != nil {
+		return nil, err
+	}
+	return createSyslogList(lineList), nil
+}
+
+// Reads all log lines starting at line start.
+func (c *Client) getTaskLogFrom(ctx context.Context, node, upid string, start uint) ([]string, error) {
+	lines := []string{}
+	for {
+		page, err := c.getTaskLogPage(ctx, node, upid, start, taskLogPageSize)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, page...)
+		if len(page) < taskLogPageSize {
+			return lines, nil
+		}
+		start += taskLogPageSize
+	}
+}
+
+// GetTaskLog returns all log lines of the task in order.
+func (c *Client) GetTaskLog(ctx context.Context, upid string) ([]string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	node, err := taskNode(upid)
+	if err != nil {
+		return nil, err
+	}
+	return c.getTaskLogFrom(ctx, node, upid, 0)
+}
+
+// TailTaskLog calls lineFunc for every log line of the task, including the lines logged before it was called,
+// and keeps polling for new lines until the task completes or the context is cancelled.
+// Returns the error of the task when it failed.
+func (c *Client) TailTaskLog(ctx context.Context, upid string, lineFunc func(line string)) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	node, err := taskNode(upid)
+	if err != nil {
+		return err
+	}
+	var read uint
+	readNewLines := func() error {
+		lines, err := c.getTaskLogFrom(ctx, node, upid, read)
+		if err != nil {
+			return err
+		}
+		for _, e := range lines {
+			lineFunc(e)
+		}
+		read += uint(len(lines))
+		return nil
+	}
+	for {
+		if err = readNewLines(); err != nil {
+			return err
+		}
+		exitStatus, statErr := c.GetTaskExitstatus(ctx, upid)
+		if statErr != nil && statErr != io.ErrUnexpectedEOF && exitStatus == nil {
+			return statErr
+		}
+		if exitStatus != nil {
+			// the last lines may have been logged after they were read
+			if err = readNewLines(); err != nil {
+				return err
+			}
+			return statErr
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(TaskStatusCheckInterval * time.Second):
+		}
+	}
+}
+
+// Status of a task, ExitStatus is only set once the task stopped.
+type TaskStatus struct {
+	UPID       string    `json:"upid"`
+	Node       string    `json:"node"`
+	Type       string    `json:"type"`
+	ID         string    `json:"id,omitempty"`
+	User       string    `json:"user"`
+	StartTime  time.Time `json:"starttime"`
+	Running    bool      `json:"running"`
+	ExitStatus string    `json:"exitstatus,omitempty"`
+}
+
+// Returns true when the task stopped with the status OK or WARNINGS.
+func (status TaskStatus) Succeeded() bool {
+	return !status.Running && rxExitStatusSuccess.MatchString(status.ExitStatus)
+}
+
+func (TaskStatus) mapToStruct(params map[string]interface{}) *TaskStatus {
+	status := TaskStatus{}
+	if _, isSet := params["upid"]; isSet {
+		status.UPID = params["upid"].(string)
+	}
+	if _, isSet := params["node"]; isSet {
+		status.Node = params["node"].(string)
+	}
+	if _, isSet := params["type"]; isSet {
+		status.Type = params["type"].(string)
+	}
+	if _, isSet := params["id"]; isSet {
+		status.ID = params["id"].(string)
+	}
+	if _, isSet := params["user"]; isSet {
+		status.User = params["user"].(string)
+	}
+	if _, isSet := params["starttime"]; isSet {
+		status.StartTime = time.Unix(int64(params["starttime"].(float64)), 0)
+	}
+	if _, isSet := params["status"]; isSet {
+		status.Running = params["status"].(string) == "running"
+	}
+	if _, isSet := params["exitstatus"]; isSet {
+		status.ExitStatus = params["exitstatus"].(string)
+	}
+	return &status
+}
+
+// GetTaskStatus returns the current status of the task.
+func (c *Client) GetTaskStatus(ctx context.Context, upid string) (*TaskStatus, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	node, err := taskNode(upid)
+	if err != nil {
+		return nil, err
+	}
+	params, err := c.GetItemConfigMapStringInterface(ctx, fmt.Sprintf("/nodes/%s/tasks/%s/status", node, upid), "task", "STATUS")
+	if err != nil {
+		return nil, err
+	}
+	return TaskStatus{}.mapToStruct(params), nil
+}
+
+// Options for WaitForTask.
+type TaskWaitOptions struct {
+	// Time between status checks, defaults to TaskStatusCheckInterval seconds.
+	Interval time.Duration
+	// Called with the status after every check, including the final one.
+	Progress func(status TaskStatus)
+}
+
+// WaitForTask blocks until the task stopped or the context is done, use a context with a deadline to limit the wait.
+// Returns the exit status of the task, which is an error unless it is OK or WARNINGS.
+func (c *Client) WaitForTask(ctx context.Context, upid string, opts *TaskWaitOptions) (exitStatus string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts == nil {
+		opts = &TaskWaitOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = TaskStatusCheckInterval * time.Second
+	}
+	for {
+		status, err := c.GetTaskStatus(ctx, upid)
+		if err != nil && err != io.ErrUnexpectedEOF { // don't give up on ErrUnexpectedEOF
+			return "", err
+		}
+		if status != nil {
+			if opts.Progress != nil {
+				opts.Progress(*status)
+			}
+			if !status.Running {
+				if !status.Succeeded() {
+					return status.ExitStatus, errors.New(status.ExitStatus)
+				}
+				return status.ExitStatus, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Filters the tasks returned by ListTasks, zero values are not filtered on.
+type TaskFilter struct {
+	// Task type, e.g. "qmstart" or "vzdump".
+	Type string
+	// User that started the task, e.g. "root@pam".
+	User       string
+	VmID       uint
+	ErrorsOnly bool
+	Since      time.Time
+	Until      time.Time
+	// Pagination, proxmox returns 50 tasks when no limit is given.
+	Start uint
+	Limit uint
+}
+
+func (filter TaskFilter) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{
+		"typefilter": filter.Type,
+		"userfilter": filter.User,
+	}
+	if filter.VmID != 0 {
+		params["vmid"] = filter.VmID
+	}
+	if filter.ErrorsOnly {
+		params["errors"] = true
+	}
+	if !filter.Since.IsZero() {
+		params["since"] = filter.Since.Unix()
+	}
+	if !filter.Until.IsZero() {
+		params["until"] = filter.Until.Unix()
+	}
+	if filter.Start != 0 {
+		params["start"] = filter.Start
+	}
+	if filter.Limit != 0 {
+		params["limit"] = filter.Limit
+	}
+	return params
+}
+
+// Task as listed by ListTasks, EndTime and Status are empty while the task is running.
+type Task struct {
+	UPID      string    `json:"upid"`
+	Node      string    `json:"node"`
+	Type      string    `json:"type"`
+	ID        string    `json:"id,omitempty"`
+	User      string    `json:"user"`
+	StartTime time.Time `json:"starttime"`
+	EndTime   time.Time `json:"endtime,omitempty"`
+	Status    string    `json:"status,omitempty"`
+}
+
+func (Task) mapToStruct(params map[string]interface{}) *Task {
+	task := Task{}
+	if _, isSet := params["upid"]; isSet {
+		task.UPID = params["upid"].(string)
+	}
+	if _, isSet := params["node"]; isSet {
+		task.Node = params["node"].(string)
+	}
+	if _, isSet := params["type"]; isSet {
+		task.Type = params["type"].(string)
+	}
+	if _, isSet := params["id"]; isSet {
+		task.ID = params["id"].(string)
+	}
+	if _, isSet := params["user"]; isSet {
+		task.User = params["user"].(string)
+	}
+	if _, isSet := params["starttime"]; isSet {
+		task.StartTime = time.Unix(int64(params["starttime"].(float64)), 0)
+	}
+	if _, isSet := params["endtime"]; isSet {
+		task.EndTime = time.Unix(int64(params["endtime"].(float64)), 0)
+	}
+	if _, isSet := params["status"]; isSet {
+		task.Status = params["status"].(string)
+	}
+	return &task
+}
+
+// ListTasks returns the tasks of the node, newest first.
+func (c *Client) ListTasks(ctx context.Context, node string, filter TaskFilter) ([]Task, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	url := "/nodes/" + node + "/tasks"
+	if values := ParamsToValues(filter.mapToApiValues()); len(values) != 0 {
+		url += "?" + values.Encode()
+	}
+	taskList, err := c.GetItemListInterfaceArray(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]Task, len(taskList))
+	for i, e := range taskList {
+		tasks[i] = *Task{}.mapToStruct(e.(map[string]interface{}))
+	}
+	return tasks, nil
+}
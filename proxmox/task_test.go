@@ -0,0 +1,92 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_taskNode(t *testing.T) {
+	node, err := taskNode("UPID:pve1:00001234:0A1B2C3D:64250C80:qmstart:100:root@pam:")
+	require.NoError(t, err)
+	require.Equal(t, "pve1", node)
+	_, err = taskNode("pve1:qmstart")
+	require.Error(t, err)
+}
+
+func Test_TaskStatus_mapToStruct(t *testing.T) {
+	status := TaskStatus{}.mapToStruct(map[string]interface{}{
+		"upid":       "UPID:pve1:00001234:0A1B2C3D:64250C80:qmstart:100:root@pam:",
+		"node":       "pve1",
+		"type":       "qmstart",
+		"id":         "100",
+		"user":       "root@pam",
+		"starttime":  float64(1680149632),
+		"status":     "stopped",
+		"exitstatus": "WARNINGS: 1",
+	})
+	require.Equal(t, &TaskStatus{
+		UPID:       "UPID:pve1:00001234:0A1B2C3D:64250C80:qmstart:100:root@pam:",
+		Node:       "pve1",
+		Type:       "qmstart",
+		ID:         "100",
+		User:       "root@pam",
+		StartTime:  time.Unix(1680149632, 0),
+		ExitStatus: "WARNINGS: 1",
+	}, status)
+	require.True(t, status.Succeeded())
+}
+
+func Test_TaskStatus_Succeeded(t *testing.T) {
+	require.True(t, TaskStatus{ExitStatus: "OK"}.Succeeded())
+	require.False(t, TaskStatus{Running: true}.Succeeded())
+	require.False(t, TaskStatus{ExitStatus: "command failed"}.Succeeded())
+}
+
+func Test_TaskFilter_mapToApiValues(t *testing.T) {
+	require.Equal(t, map[string]interface{}{"typefilter": "", "userfilter": ""}, TaskFilter{}.mapToApiValues())
+	require.Equal(t, map[string]interface{}{
+		"typefilter": "vzdump",
+		"userfilter": "root@pam",
+		"vmid":       uint(100),
+		"errors":     true,
+		"since":      int64(1680000000),
+		"until":      int64(1680086400),
+		"start":      uint(50),
+		"limit":      uint(25),
+	}, TaskFilter{
+		Type:       "vzdump",
+		User:       "root@pam",
+		VmID:       100,
+		ErrorsOnly: true,
+		Since:      time.Unix(1680000000, 0),
+		Until:      time.Unix(1680086400, 0),
+		Start:      50,
+		Limit:      25,
+	}.mapToApiValues())
+}
+
+func Test_Task_mapToStruct(t *testing.T) {
+	require.Equal(t, &Task{
+		UPID:      "UPID:pve1:00001234:0A1B2C3D:64250C80:vzdump:100:root@pam:",
+		Node:      "pve1",
+		Type:      "vzdump",
+		ID:        "100",
+		User:      "root@pam",
+		StartTime: time.Unix(1680149632, 0),
+		EndTime:   time.Unix(1680149700, 0),
+		Status:    "OK",
+	}, Task{}.mapToStruct(map[string]interface{}{
+		"upid":      "UPID:pve1:00001234:0A1B2C3D:64250C80:vzdump:100:root@pam:",
+		"node":      "pve1",
+		"pid":       float64(4660),
+		"pstart":    float64(169552957),
+		"type":      "vzdump",
+		"id":        "100",
+		"user":      "root@pam",
+		"starttime": float64(1680149632),
+		"endtime":   float64(1680149700),
+		"status":    "OK",
+	}))
+}
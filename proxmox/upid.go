@@ -0,0 +1,62 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unique process ID of a task, as returned by every API call that starts one.
+// Format: UPID:<node>:<pid>:<pstart>:<starttime>:<type>:<id>:<user>:
+// where pid, pstart and starttime are hexadecimal numbers of at least 8 digits.
+type UPID struct {
+	Node string `json:"node"`
+	PID  uint64 `json:"pid"`
+	// Start time of the process in clock ticks since boot, exceeds 32 bits on nodes with a long uptime.
+	PStart    uint64    `json:"pstart"`
+	StartTime time.Time `json:"starttime"`
+	Type      string    `json:"type"`
+	// Mostly the ID of the guest the task is about, empty for tasks that are not about a single object.
+	ID   string `json:"id,omitempty"`
+	User string `json:"user"`
+}
+
+// ParseUPID splits the UPID of a task into its fields.
+func ParseUPID(upid string) (*UPID, error) {
+	fields := strings.Split(upid, ":")
+	if len(fields) != 9 || fields[0] != "UPID" || fields[8] != "" {
+		return nil, fmt.Errorf("invalid upid (%s), must be in the format UPID:<node>:<pid>:<pstart>:<starttime>:<type>:<id>:<user>:", upid)
+	}
+	if fields[1] == "" {
+		return nil, errors.New("invalid upid, node may not be empty")
+	}
+	if fields[5] == "" {
+		return nil, errors.New("invalid upid, type may not be empty")
+	}
+	if fields[7] == "" {
+		return nil, errors.New("invalid upid, user may not be empty")
+	}
+	hex := make([]uint64, 3)
+	for i, e := range fields[2:5] {
+		number, err := strconv.ParseUint(e, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upid, %s is not a hexadecimal number", e)
+		}
+		hex[i] = number
+	}
+	return &UPID{
+		Node:      fields[1],
+		PID:       hex[0],
+		PStart:    hex[1],
+		StartTime: time.Unix(int64(hex[2]), 0),
+		Type:      fields[5],
+		ID:        fields[6],
+		User:      fields[7],
+	}, nil
+}
+
+func (upid UPID) String() string {
+	return fmt.Sprintf("UPID:%s:%08X:%08X:%08X:%s:%s:%s:", upid.Node, upid.PID, upid.PStart, upid.StartTime.Unix(), upid.Type, upid.ID, upid.User)
+}
@@ -0,0 +1,51 @@
+package proxmox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseUPID(t *testing.T) {
+	testData := []struct {
+		input  string
+		output *UPID
+	}{
+		{
+			input:  "UPID:pve1:00001234:0A1B2C3D:64250C80:qmstart:100:root@pam:",
+			output: &UPID{Node: "pve1", PID: 0x1234, PStart: 0x0A1B2C3D, StartTime: time.Unix(0x64250C80, 0), Type: "qmstart", ID: "100", User: "root@pam"},
+		},
+		{
+			input:  "UPID:pve-2:0000ABCD:00000001:64250C80:aptupdate::automation@pve!token:",
+			output: &UPID{Node: "pve-2", PID: 0xABCD, PStart: 1, StartTime: time.Unix(0x64250C80, 0), Type: "aptupdate", User: "automation@pve!token"},
+		},
+		{
+			input:  "UPID:pve1:00001234:1A1B2C3D4:64250C80:vzdump::root@pam:",
+			output: &UPID{Node: "pve1", PID: 0x1234, PStart: 0x1A1B2C3D4, StartTime: time.Unix(0x64250C80, 0), Type: "vzdump", User: "root@pam"},
+		},
+	}
+	for _, e := range testData {
+		upid, err := ParseUPID(e.input)
+		require.NoError(t, err, e.input)
+		require.Equal(t, e.output, upid, e.input)
+		require.Equal(t, e.input, upid.String())
+	}
+}
+
+func Test_ParseUPID_Invalid(t *testing.T) {
+	for _, e := range []string{
+		"",
+		"UPID:pve1:00001234:0A1B2C3D:64250C80:qmstart:100:root@pam",
+		"UPID:pve1:00001234:0A1B2C3D:64250C80:qmstart:100:root@pam:extra:",
+		"PID:pve1:00001234:0A1B2C3D:64250C80:qmstart:100:root@pam:",
+		"UPID::00001234:0A1B2C3D:64250C80:qmstart:100:root@pam:",
+		"UPID:pve1:0000123G:0A1B2C3D:64250C80:qmstart:100:root@pam:",
+		"UPID:pve1:00001234:0A1B2C3D:10000000000000000:qmstart:100:root@pam:",
+		"UPID:pve1:00001234:0A1B2C3D:64250C80::100:root@pam:",
+		"UPID:pve1:00001234:0A1B2C3D:64250C80:qmstart:100::",
+	} {
+		_, err := ParseUPID(e)
+		require.Error(t, err, e)
+	}
+}
@@ -178,6 +178,11 @@ func PointerInt(number int) *int {
 	return &number
 }
 
+// Creates a pointer to an uint
+func PointerUint(number uint) *uint {
+	return &number
+}
+
 // Creates a pointer to a bool
 func PointerBool(boolean bool) *bool {
 	return &boolean
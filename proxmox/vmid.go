@@ -0,0 +1,123 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	vmIdMinimum = 100
+	vmIdMaximum = 999999999
+	// Number of times a create is retried with the next free ID after it conflicted with a concurrent create.
+	vmIdReserveAttempts = 10
+)
+
+// Range of IDs ReserveNextVmId may hand out, both ends are inclusive.
+// A zero value for either end means the Proxmox limit (100 or 999999999).
+type VmIdRange struct {
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+}
+
+func (idRange VmIdRange) bounds() (min, max int) {
+	min, max = idRange.Min, idRange.Max
+	if min == 0 {
+		min = vmIdMinimum
+	}
+	if max == 0 {
+		max = vmIdMaximum
+	}
+	return
+}
+
+func (idRange VmIdRange) Validate() error {
+	min, max := idRange.bounds()
+	if min < vmIdMinimum || max > vmIdMaximum {
+		return fmt.Errorf("vmid range must be between %d and %d", vmIdMinimum, vmIdMaximum)
+	}
+	if min > max {
+		return errors.New("vmid range minimum may not be larger than its maximum")
+	}
+	return nil
+}
+
+// Proxmox refuses to create a guest when the config of the ID already exists,
+// which is what happens when another caller got the same ID from /cluster/nextid.
+func vmIdConflict(err error) bool {
+	return strings.Contains(err.Error(), "already exists")
+}
+
+// ReserveNextVmId picks the next free ID in the range and calls create with it.
+// Proxmox has no way to reserve an ID, so when create fails because a concurrent caller created a guest
+// with the same ID in the meantime, the next free ID is picked and create is called again.
+// create must fail when the ID is taken, e.g. by not using an update-or-create path.
+// Returns the ID the guest was created with.
+func (c *Client) ReserveNextVmId(ctx context.Context, idRange VmIdRange, create func(ctx context.Context, id int) error) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := idRange.Validate(); err != nil {
+		return 0, err
+	}
+	_, max := idRange.bounds()
+	return reserveVmId(ctx, idRange, func(currentID int) (int, error) {
+		// without a minimum Proxmox picks the lowest free ID in a single request
+		if currentID == 0 {
+			return c.GetNextID(ctx, 0)
+		}
+		return firstFreeVmId(currentID, max, func(id int) (bool, error) {
+			return c.vmIdFree(ctx, id)
+		})
+	}, create)
+}
+
+// Checks a single ID, /cluster/nextid answers with 400 when the requested ID is taken.
+func (c *Client) vmIdFree(ctx context.Context, id int) (bool, error) {
+	var data map[string]interface{}
+	_, err := c.session.GetJSON(ctx, "/cluster/nextid?vmid="+strconv.Itoa(id), nil, nil, &data)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "400 ") {
+			return false, nil
+		}
+		return false, err
+	}
+	return data["errors"] == nil, nil
+}
+
+// Returns the first free ID from currentID up to and including max, or max+1 when all of them are taken.
+func firstFreeVmId(currentID, max int, free func(id int) (bool, error)) (int, error) {
+	for id := currentID; id <= max; id++ {
+		isFree, err := free(id)
+		if err != nil || isFree {
+			return id, err
+		}
+	}
+	return max + 1, nil
+}
+
+// currentID is 0 for the first call of nextID when the range has no minimum.
+func reserveVmId(ctx context.Context, idRange VmIdRange, nextID func(currentID int) (int, error), create func(ctx context.Context, id int) error) (int, error) {
+	min, max := idRange.bounds()
+	currentID := idRange.Min
+	for i := 0; i < vmIdReserveAttempts; i++ {
+		id, err := nextID(currentID)
+		if err != nil {
+			return 0, err
+		}
+		if id > max {
+			return 0, fmt.Errorf("no free vmid between %d and %d", min, max)
+		}
+		err = create(ctx, id)
+		if err == nil {
+			return id, nil
+		}
+		if !vmIdConflict(err) {
+			return 0, err
+		}
+		currentID = id + 1
+	}
+	return 0, fmt.Errorf("unable to create a guest with a free vmid after %d attempts", vmIdReserveAttempts)
+}
@@ -0,0 +1,79 @@
+package proxmox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VmIdRange_Validate(t *testing.T) {
+	require.NoError(t, VmIdRange{}.Validate())
+	require.NoError(t, VmIdRange{Min: 1000, Max: 1999}.Validate())
+	require.NoError(t, VmIdRange{Min: 500, Max: 500}.Validate())
+	require.Error(t, VmIdRange{Min: 99}.Validate())
+	require.Error(t, VmIdRange{Max: 1000000000}.Validate())
+	require.Error(t, VmIdRange{Min: 2000, Max: 1000}.Validate())
+}
+
+func Test_firstFreeVmId(t *testing.T) {
+	probed := []int{}
+	free := func(id int) (bool, error) {
+		probed = append(probed, id)
+		return id == 1003, nil
+	}
+	id, err := firstFreeVmId(1000, 1010, free)
+	require.NoError(t, err)
+	require.Equal(t, 1003, id)
+	require.Equal(t, []int{1000, 1001, 1002, 1003}, probed)
+
+	// probing stops at the maximum
+	probed = []int{}
+	id, err = firstFreeVmId(1000, 1002, free)
+	require.NoError(t, err)
+	require.Equal(t, 1003, id)
+	require.Equal(t, []int{1000, 1001, 1002}, probed)
+
+	_, err = firstFreeVmId(1000, 1010, func(int) (bool, error) { return false, errors.New("401 permission denied") })
+	require.Error(t, err)
+}
+
+func Test_reserveVmId(t *testing.T) {
+	taken := map[int]bool{1000: true, 1001: true}
+	nextID := func(currentID int) (int, error) {
+		for taken[currentID] {
+			currentID++
+		}
+		return currentID, nil
+	}
+	// 1002 and 1003 are taken by a concurrent caller between nextid and the create.
+	racing := map[int]bool{1002: true, 1003: true}
+	create := func(_ context.Context, id int) error {
+		if racing[id] {
+			return errors.New("500 unable to create VM - config file already exists")
+		}
+		taken[id] = true
+		return nil
+	}
+	id, err := reserveVmId(context.Background(), VmIdRange{Min: 1000, Max: 1010}, nextID, create)
+	require.NoError(t, err)
+	require.Equal(t, 1004, id)
+
+	_, err = reserveVmId(context.Background(), VmIdRange{Min: 1000, Max: 1004}, nextID, create)
+	require.Error(t, err)
+
+	// without a minimum the first ID is left to Proxmox
+	var first int
+	_, err = reserveVmId(context.Background(), VmIdRange{}, func(currentID int) (int, error) {
+		first = currentID
+		return 100, nil
+	}, func(context.Context, int) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, 0, first)
+
+	_, err = reserveVmId(context.Background(), VmIdRange{Min: 2000, Max: 2010}, nextID, func(context.Context, int) error {
+		return errors.New("500 storage does not exist")
+	})
+	require.EqualError(t, err, "500 storage does not exist")
+}